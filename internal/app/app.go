@@ -1,20 +1,41 @@
 package app
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/ansi"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/google/go-github/v57/github"
+	"github.com/stefrushxyz/nitpick/internal/blame"
+	"github.com/stefrushxyz/nitpick/internal/bookmarks"
 	"github.com/stefrushxyz/nitpick/internal/clipboard"
+	"github.com/stefrushxyz/nitpick/internal/codeowners"
+	"github.com/stefrushxyz/nitpick/internal/config"
+	"github.com/stefrushxyz/nitpick/internal/diff"
+	"github.com/stefrushxyz/nitpick/internal/editor"
 	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/gitrepo"
+	"github.com/stefrushxyz/nitpick/internal/prefetch"
+	"github.com/stefrushxyz/nitpick/internal/progress"
 	"github.com/stefrushxyz/nitpick/internal/prompt"
+	"github.com/stefrushxyz/nitpick/internal/provider"
+	"github.com/stefrushxyz/nitpick/internal/stats"
+	"github.com/stefrushxyz/nitpick/internal/suggestion"
+	"github.com/stefrushxyz/nitpick/internal/symbol"
+	"github.com/stefrushxyz/nitpick/internal/tickets"
 	"github.com/stefrushxyz/nitpick/internal/ui"
+	"github.com/stefrushxyz/nitpick/internal/workboard"
 )
 
 // State represents the current view state
@@ -25,16 +46,28 @@ const (
 	StatePRs
 	StateComments
 	StateCommentDetail
+	StateStats
+	StateSummary
+	StateBoard
+	StateInbox
+	StateFiles
+	StateFileDiff
+	StateCommits
 )
 
 // App represents the main application
 type App struct {
-	client          *ghclient.Client
+	cfg             *config.Config
+	client          *provider.Source
 	promptGen       *prompt.Generator
 	state           State
 	repoList        list.Model
 	prList          list.Model
 	commentList     list.Model
+	boardList       list.Model
+	inboxList       list.Model
+	fileList        list.Model
+	commitList      list.Model
 	commentViewport viewport.Model
 	currentRepo     *github.Repository
 	currentPR       *github.PullRequest
@@ -43,59 +76,281 @@ type App struct {
 	err             error
 	width           int
 	height          int
-	copyStatus      string // Status message for copy operations
-	showReplies     bool   // Whether to show reply comments
-	useSimplePrompt bool   // Whether to use simple prompt template
+	copyStatus      string                       // Status message for copy operations
+	showReplies     bool                         // Whether to show reply comments
+	useSimplePrompt bool                         // Whether to use simple prompt template
+	commentSort     string                       // Sort order of the comment list
+	prState         string                       // State of the pull requests listed: open, closed or all
+	hideBots        bool                         // Whether to hide comments from bot accounts
+	hideBotsPref    bool                         // Saved bot toggle, applied when a repository without a hide_bots filter is opened
+	showResolved    bool                         // Whether to show comments of resolved threads
+	comments        []*github.PullRequestComment // Comments of the current PR, before filtering
+	usage           *stats.Stats                 // Usage stats shown on the stats screen
+	progress        *progress.Store              // Addressed and ignored marks of review comments
+	prevState       State                        // State to return to from the stats and summary screens
+	clipboardLimit  int                          // Maximum prompt size in bytes before falling back to file export
+	clipboardTarget string                       // Clipboard backend used for copy operations
+	markdownStyle   ansi.StyleConfig             // Glamour style used to render markdown
+	theme           string                       // Theme in use; the configured one unless toggled this session
+	palette         ui.Palette                   // Colors of the TUI's own elements, from the theme
+	ascii           bool                         // Whether only ASCII characters are drawn
+	filters         config.Filters               // Default filters of the current repository
+	startOwner      string                       // Owner of the repository to open on startup
+	startRepo       string                       // Name of the repository to open on startup
+	startPR         int                          // Number of the pull request to open on startup
+	startComment    int64                        // ID of the comment of the pull request to open on startup
+	startBoard      bool                         // Whether to open the workboard on startup
+	fromCards       State                        // Card list the current pull request was opened from: StateBoard or StateInbox, else StateRepos
+	inboxLane       workboard.Lane               // Lane of the workboard the inbox lists
+	filesPR         string                       // Key of the pull request whose changed files are listed
+	currentFile     *github.CommitFile           // Changed file whose diff is shown
+	commitsPR       string                       // Key of the pull request whose commits are listed
+	commits         []*github.RepositoryCommit   // Commits of the pull request commitsPR, oldest first
+	promptCommits   bool                         // Whether prompts list the commits of their pull request, once loaded
+	checkout        *gitrepo.Checkout            // Local git checkout nitpick runs in; its open pull request is offered on startup
+	checkoutRepo    *github.Repository           // Repository of the checkout
+	checkoutPR      *github.PullRequest          // Open pull request of the checkout's branch, if found
+	compact         bool                         // Whether the minimal single pull request layout is used
+	compactHeight   int                          // Maximum height of the compact layout; 0 for the terminal's height
+	linker          *tickets.Linker              // Finds the tickets of the configured issue trackers, if any
+	linked          map[string][]*tickets.Ticket // Tickets linked to each pull request opened, by owner/name#N
+	issues          map[string][]*github.Issue   // Issues each pull request opened closes, by owner/name#N
+	owners          map[string]*codeowners.File  // CODEOWNERS of each repository opened, by owner/name@ref; nil when it has none
+	blames          map[int64][]blame.Range      // Commits that last changed the lines of each comment opened, by comment ID
+	symbols         map[int64]*symbol.Symbol     // Declarations enclosing the lines of each comment opened, by comment ID
+	contexts        map[int64]string             // Lines around the lines of each comment opened, by comment ID
+	reactingTo      *github.PullRequestComment   // Comment the next key picks a reaction for, after "+"
+	replyTo         *github.PullRequestComment   // Comment the reply being written answers, while composing
+	editing         *github.PullRequestComment   // Comment whose body is being edited, while composing
+	composer        textarea.Model               // Reply or edited body being written
+	deleting        *github.PullRequestComment   // Comment the next key confirms deleting, after "d"
+	commentingOn    *diff.Line                   // Line of the file diff a new comment is being written on
+	reviewEvent     string                       // Verdict of the review being written, while composing
+	choosingVerdict bool                         // Whether the next key picks the verdict of a review, after "a"
+	diffLines       []diff.Line                  // Lines of the diff of currentFile
+	diffCursor      int                          // Index in diffLines of the line under the cursor
+	viewer          string                       // Login of the signed-in user, once fetched
+	viewerAsked     bool                         // Whether the signed-in user was fetched
+	queue           *prefetch.Queue              // Background fetches, grouped by the view they serve
+	repoLoad        int                          // Number of repository lists fetched, identifying the one being shown
+	foundRepos      []*github.Repository         // Repositories found by searches, kept when the list is fetched again
+	commentsPR      string                       // Pull request the loaded comments belong to, as owner/name#N
+	commentsAt      time.Time                    // When the loaded comments were fetched; zero if read from the cache
+	fresh           map[int64]bool               // Comments posted or edited by others since first loaded, until opened
+	pollSeq         int                          // Number of pollings of comments started, identifying the current one
+	summaries       map[string]*prSummary        // Threads and CI status of each pull request summarized, by owner/name#N
+	back            []location                   // Views visited before the current one, most recent last
+	forward         []location                   // Views gone back from, most recent last
 }
 
+// Groups of background fetches; leaving a view cancels the fetches of its group
+const (
+	prefetchPRs     = "prs"     // Comments of the listed pull requests
+	prefetchPR      = "pr"      // Tickets and code owners of the open pull request
+	prefetchComment = "comment" // Blame and enclosing function of the open comment
+)
+
 // New creates a new application instance
-func New(token string) *App {
-	// Create GitHub client
-	client := ghclient.New(token)
+func New(cfg *config.Config) (*App, error) {
+	// Create the client of the configured provider
+	client, err := provider.NewSource(cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create prompt generator
 	promptGen := prompt.New()
+	promptGen.SetTemplateDir(cfg.TemplatesDir)
 
 	// Initialize lists
 	repoList := list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
 	repoList.Title = "GitHub Repositories"
-	repoList.Styles.TitleBar.PaddingLeft(0)
 	repoList.SetShowStatusBar(false)
 	repoList.SetFilteringEnabled(true)
+	repoList.Filter = ui.LowercaseFilter
 
 	prList := list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
 	prList.Title = "Pull Requests"
-	prList.Styles.TitleBar.PaddingLeft(0)
 	prList.SetShowStatusBar(false)
 	prList.SetFilteringEnabled(true)
 
 	commentList := list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
 	commentList.Title = "PR Comments"
-	commentList.Styles.TitleBar.PaddingLeft(0)
 	commentList.SetShowStatusBar(false)
 	commentList.SetFilteringEnabled(true)
 
+	boardList := list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
+	boardList.Title = "Workboard"
+	boardList.SetShowStatusBar(false)
+	boardList.SetFilteringEnabled(true)
+
+	inboxList := list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
+	inboxList.SetShowStatusBar(false)
+	inboxList.SetFilteringEnabled(true)
+
+	fileList := list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
+	fileList.Title = "Files changed"
+	fileList.SetShowStatusBar(false)
+	fileList.SetFilteringEnabled(true)
+
+	commitList := list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
+	commitList.Title = "Commits"
+	commitList.SetShowStatusBar(false)
+	commitList.SetFilteringEnabled(true)
+
 	// Initialize viewport for comment details
 	commentViewport := viewport.New(0, 0)
 
+	// Toggles saved by a previous session take precedence over the configured defaults
+	uiState := config.UIState{
+		ShowReplies:    cfg.ShowReplies,
+		PromptTemplate: cfg.PromptTemplate,
+		CommentSort:    config.SortUpdated,
+	}
+	if saved, ok, err := config.LoadState(); err != nil {
+		return nil, err
+	} else if ok {
+		uiState = saved
+	}
+
+	marks, err := progress.LoadDefault()
+	if err != nil {
+		return nil, err
+	}
+
+	markdownStyle, err := ui.MarkdownStyle(markdownTheme(cfg.Theme, cfg.ASCII))
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Theme == config.ThemeHighContrast {
+		// Resolve the theme toggled to up front: detecting the terminal's background once the TUI reads input
+		// would swallow its reply
+		if _, err := ui.MarkdownStyle(config.DefaultTheme); err != nil {
+			return nil, err
+		}
+	}
+	palette := ui.PaletteFor(cfg.Theme)
+	for _, l := range []*list.Model{&repoList, &prList, &commentList, &boardList, &inboxList, &fileList, &commitList} {
+		palette.StyleList(l)
+	}
+
+	linker, err := tickets.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	return &App{
+		cfg:             cfg,
 		client:          client,
 		promptGen:       promptGen,
 		state:           StateRepos,
 		repoList:        repoList,
 		prList:          prList,
 		commentList:     commentList,
+		boardList:       boardList,
+		inboxList:       inboxList,
+		fileList:        fileList,
+		commitList:      commitList,
 		commentViewport: commentViewport,
 		loading:         true,
-		showReplies:     false,
-		useSimplePrompt: false,
-	}
+		showReplies:     uiState.ShowReplies,
+		useSimplePrompt: uiState.PromptTemplate == prompt.TemplateSimple,
+		commentSort:     uiState.CommentSort,
+		prState:         "open",
+		hideBots:        uiState.HideBots,
+		hideBotsPref:    uiState.HideBots,
+		showResolved:    uiState.ShowResolved,
+		clipboardLimit:  cfg.Clipboard.Limit,
+		clipboardTarget: cfg.Clipboard.Backend,
+		markdownStyle:   markdownStyle,
+		theme:           cfg.Theme,
+		palette:         palette,
+		ascii:           cfg.ASCII,
+		progress:        marks,
+		linker:          linker,
+		linked:          make(map[string][]*tickets.Ticket),
+		issues:          make(map[string][]*github.Issue),
+		owners:          make(map[string]*codeowners.File),
+		blames:          make(map[int64][]blame.Range),
+		symbols:         make(map[int64]*symbol.Symbol),
+		contexts:        make(map[int64]string),
+		composer:        newComposer(),
+		summaries:       make(map[string]*prSummary),
+		fresh:           make(map[int64]bool),
+		queue:           prefetch.New(cfg.Prefetch.Concurrency),
+	}, nil
+}
+
+// Preselect makes the application open the given repository, and optionally pull request, on startup.
+// A prNumber of 0 opens the repository's pull request list.
+func (a *App) Preselect(owner, repo string, prNumber int) {
+	a.startOwner = owner
+	a.startRepo = repo
+	a.startPR = prNumber
+}
+
+// PreselectComment makes the application open a comment of the preselected pull request on startup, once
+// its comments are loaded
+func (a *App) PreselectComment(id int64) {
+	a.startComment = id
+}
+
+// ShowBoard makes the application open the workboard on startup, unless a repository was preselected
+func (a *App) ShowBoard() {
+	a.startBoard = true
+}
+
+// DetectCheckout makes the application open commented files in a local git checkout, and, unless a pull
+// request was preselected, look up the checkout's open pull request on startup and offer to open its comments
+func (a *App) DetectCheckout(checkout *gitrepo.Checkout) {
+	a.checkout = checkout
+}
+
+// SetCompact makes the application render a minimal layout bound to a single pull request, for small
+// transient windows such as tmux popups: it stays out of the alternate screen, takes at most height lines
+// (0 for the whole terminal), and quits when leaving the comments. The pull request is the preselected one,
+// or else the open pull request of the detected git checkout.
+func (a *App) SetCompact(height int) {
+	a.compact = true
+	a.compactHeight = height
+	a.commentList.SetShowTitle(false)
+	a.commentList.SetShowHelp(false)
 }
 
 // Init initializes the application
 func (a *App) Init() tea.Cmd {
+	var lookup tea.Cmd
+	if a.checkout != nil && a.startPR == 0 {
+		lookup = a.client.FetchCheckoutPR(a.checkout.Owner, a.checkout.Name, a.checkout.Branch, a.checkout.Commit)
+	}
+
+	if a.compact {
+		if a.startPR != 0 {
+			return a.client.FetchRepo(a.startOwner, a.startRepo)
+		}
+		return lookup
+	}
+
+	if a.startRepo != "" {
+		return tea.Batch(
+			a.client.FetchRepo(a.startOwner, a.startRepo),
+			lookup,
+			tea.EnterAltScreen,
+		)
+	}
+
+	if a.startBoard {
+		a.state = StateBoard
+		return tea.Batch(
+			a.client.FetchBoard(),
+			lookup,
+			tea.EnterAltScreen,
+		)
+	}
+
 	return tea.Batch(
 		a.fetchRepos(),
+		lookup,
 		tea.EnterAltScreen,
 	)
 }
@@ -104,10 +359,18 @@ func (a *App) Init() tea.Cmd {
 func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
+		if a.compact {
+			a.resizeCompact(msg.Width, msg.Height)
+			break
+		}
 		a.width = msg.Width
 		a.height = msg.Height
 		a.repoList.SetSize(msg.Width-4, msg.Height-4)
 		a.prList.SetSize(msg.Width-4, msg.Height-4)
+		a.boardList.SetSize(msg.Width-4, msg.Height-4)
+		a.inboxList.SetSize(msg.Width-4, msg.Height-4)
+		a.fileList.SetSize(msg.Width-4, msg.Height-7)
+		a.commitList.SetSize(msg.Width-4, msg.Height-7)
 		a.commentList.SetSize(msg.Width-4, msg.Height-7)
 
 		availableHeight := msg.Height - 5
@@ -118,6 +381,18 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.commentViewport.Height = availableHeight
 
 	case tea.KeyMsg:
+		if a.reactingTo != nil && msg.String() != "ctrl+c" {
+			return a.handleReactionKey(msg)
+		}
+		if a.composing() && msg.String() != "ctrl+c" {
+			return a.handleComposerKey(msg)
+		}
+		if a.deleting != nil && msg.String() != "ctrl+c" {
+			return a.handleDeleteKey(msg)
+		}
+		if a.choosingVerdict && msg.String() != "ctrl+c" {
+			return a.handleVerdictKey(msg)
+		}
 		switch msg.String() {
 		case "ctrl+c", "q":
 			return a, tea.Quit
@@ -141,67 +416,325 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					a.commentList, cmd = a.commentList.Update(msg)
 					return a, cmd
 				}
+			case StateFiles:
+				if a.fileList.SettingFilter() {
+					var cmd tea.Cmd
+					a.fileList, cmd = a.fileList.Update(msg)
+					return a, cmd
+				}
+			case StateCommits:
+				if a.commitList.SettingFilter() {
+					var cmd tea.Cmd
+					a.commitList, cmd = a.commitList.Update(msg)
+					return a, cmd
+				}
+			case StateBoard, StateInbox:
+				if cards := a.cardList(a.state); cards.SettingFilter() {
+					var cmd tea.Cmd
+					*cards, cmd = cards.Update(msg)
+					return a, cmd
+				}
 			}
 			return a.handleBack()
 		case "enter":
 			return a.handleEnter()
+		case "ctrl+r":
+			return a.handleRefresh()
+		case "R":
+			if !a.settingFilter() {
+				return a.handleRefresh()
+			}
+		case "D":
+			if (a.state == StatePRs || a.state == StateComments) && !a.settingFilter() && !a.compact {
+				return a.handleShowSummary()
+			}
+		case "f":
+			if (a.state == StatePRs || a.state == StateComments) && !a.settingFilter() && !a.compact {
+				return a.handleShowFiles()
+			}
+		case "L":
+			if (a.state == StatePRs || a.state == StateComments) && !a.settingFilter() && !a.compact {
+				return a.handleShowCommits()
+			}
+		case "backspace", "ctrl+o":
+			if a.state != StateStats && !a.settingFilter() && !a.compact {
+				return a.handleHistoryBack()
+			}
+		case "tab":
+			if a.state != StateStats && !a.settingFilter() && !a.compact {
+				return a.handleHistoryForward()
+			}
 		case "c":
 			if a.state == StateCommentDetail {
 				return a.handleCopyPrompt()
 			}
+			if a.state == StateCommits && !a.commitList.SettingFilter() {
+				return a.handleCopySHA()
+			}
+		case "p":
+			if a.state == StateCommits && !a.commitList.SettingFilter() {
+				return a.handleTogglePromptCommits()
+			}
+		case "C":
+			if a.state == StateCommentDetail {
+				return a.handleCopyEverywhere()
+			}
 		case "t":
 			if a.state == StateCommentDetail {
 				return a.handleTogglePromptMode()
 			}
+		case "m":
+			if a.state != StateStats && !a.settingFilter() {
+				return a.handleToggleBookmark()
+			}
+		case "x":
+			if a.state == StateCommentDetail || (a.state == StateComments && !a.commentList.SettingFilter()) {
+				return a.handleToggleProgress(progress.Addressed)
+			}
+		case "i":
+			if a.state == StateCommentDetail || (a.state == StateComments && !a.commentList.SettingFilter()) {
+				return a.handleToggleProgress(progress.Ignored)
+			}
+		case "o":
+			if a.checkoutPR != nil && (a.state == StateRepos || a.state == StatePRs) && !a.settingFilter() {
+				return a.handleOpenCheckoutPR()
+			}
+		case "e":
+			if a.state == StateCommentDetail || (a.state == StateComments && !a.commentList.SettingFilter()) {
+				return a.handleOpenInEditor()
+			}
+		case "+":
+			if a.state == StateCommentDetail || (a.state == StateComments && !a.commentList.SettingFilter()) {
+				return a.handleStartReaction()
+			}
+		case "v":
+			if a.state == StateCommentDetail || (a.state == StateComments && !a.commentList.SettingFilter()) {
+				return a.handleToggleResolve()
+			}
+		case "E":
+			if a.state == StateCommentDetail && !a.compact {
+				return a.handleStartEdit()
+			}
+		case "d":
+			if a.state == StateCommentDetail || (a.state == StateComments && !a.commentList.SettingFilter()) {
+				return a.handleStartDelete()
+			}
+		case "w":
+			if a.state == StateCommentDetail || ((a.state == StatePRs || a.state == StateComments) && !a.settingFilter()) {
+				return a.handleCreateWorktree()
+			}
+		case "S":
+			if a.state != StateCommentDetail && a.state != StateStats && a.state != StateSummary && a.state != StateFiles && a.state != StateFileDiff && a.state != StateCommits && !a.settingFilter() && !a.compact {
+				return a.handleShowStats()
+			}
+		case "P":
+			if a.state == StateRepos && !a.repoList.SettingFilter() {
+				return a.handleSwitchProfile()
+			}
+		case "A":
+			if !a.settingFilter() {
+				return a.handleToggleASCII()
+			}
+		case "H":
+			if !a.settingFilter() {
+				return a.handleToggleHighContrast()
+			}
+		case "W":
+			if a.state == StateRepos && !a.repoList.SettingFilter() && !a.compact {
+				return a.handleShowBoard()
+			}
+		case "I":
+			if a.state == StateRepos && !a.repoList.SettingFilter() && !a.compact {
+				return a.handleShowInbox(workboard.ReviewRequested)
+			}
+		case "M":
+			if a.state == StateRepos && !a.repoList.SettingFilter() && !a.compact {
+				return a.handleShowInbox(workboard.Authored)
+			}
 		case "r":
-			if a.state == StateComments {
+			if a.state == StateComments && !a.commentList.SettingFilter() {
 				return a.handleToggleReplies()
 			}
+			if a.state == StateCommentDetail && !a.compact {
+				return a.handleStartReply()
+			}
+		case "s":
+			if a.state == StateComments && !a.commentList.SettingFilter() {
+				return a.handleCycleCommentSort()
+			}
+			if a.state == StatePRs && !a.prList.SettingFilter() {
+				return a.handleCyclePRState()
+			}
+		case "b":
+			if a.state == StateComments && !a.commentList.SettingFilter() {
+				return a.handleToggleBots()
+			}
+		case "u":
+			if a.state == StateComments && !a.commentList.SettingFilter() {
+				return a.handleToggleResolved()
+			}
+		case "a":
+			if a.state == StateComments && !a.commentList.SettingFilter() && !a.compact {
+				return a.handleStartReview()
+			}
+		case "n":
+			if a.state == StateFileDiff {
+				return a.handleStartComment()
+			}
 		case "up", "k":
-			if a.state == StateCommentDetail {
+			if a.state == StateFileDiff {
+				a.moveDiffCursor(-1)
+				return a, nil
+			}
+			if a.scrolling() {
 				a.commentViewport.LineUp(1)
 				return a, nil
 			}
 		case "down", "j":
-			if a.state == StateCommentDetail {
+			if a.state == StateFileDiff {
+				a.moveDiffCursor(1)
+				return a, nil
+			}
+			if a.scrolling() {
 				a.commentViewport.LineDown(1)
 				return a, nil
 			}
 		case "pgup", "h":
-			if a.state == StateCommentDetail {
+			if a.state == StateFileDiff {
+				a.moveDiffCursor(-max(a.commentViewport.Height/2, 1))
+				return a, nil
+			}
+			if a.scrolling() {
 				a.commentViewport.HalfViewUp()
 				return a, nil
 			}
 		case "pgdown", "l":
-			if a.state == StateCommentDetail {
+			if a.state == StateFileDiff {
+				a.moveDiffCursor(max(a.commentViewport.Height/2, 1))
+				return a, nil
+			}
+			if a.scrolling() {
 				a.commentViewport.HalfViewDown()
 				return a, nil
 			}
 		case "home", "g":
-			if a.state == StateCommentDetail {
+			if a.state == StateFileDiff {
+				a.moveDiffCursor(-len(a.diffLines))
+				return a, nil
+			}
+			if a.scrolling() {
 				a.commentViewport.GotoTop()
 				return a, nil
 			}
 		case "end", "G":
-			if a.state == StateCommentDetail {
+			if a.state == StateFileDiff {
+				a.moveDiffCursor(len(a.diffLines))
+				return a, nil
+			}
+			if a.scrolling() {
 				a.commentViewport.GotoBottom()
 				return a, nil
 			}
 		}
 
-	case ghclient.ReposMsg:
+	case provider.ReposMsg:
+		a.loading = false
+		if msg.Err != nil {
+			a.err = msg.Err
+			return a, nil
+		}
+		items, _ := a.addRepoItems(nil, msg.Repos)
+		items, _ = a.addRepoItems(items, a.foundRepos)
+		a.repoLoad++
+		// Partial results of a fetch spanning pages are shown while the next pages arrive
+		return a, tea.Batch(a.loadRepoItems(a.repoLoad, items, 0), msg.More)
+
+	case repoItemsMsg:
+		return a, a.loadRepoItems(msg.load, msg.items, msg.loaded)
+
+	case repoSearchMsg:
+		// Only the query typed last is searched, once typing pauses
+		if a.state != StateRepos || msg.query != a.repoQuery() {
+			return a, nil
+		}
+		return a, a.client.SearchRepos(msg.query)
+
+	case provider.RepoSearchMsg:
+		return a.handleRepoSearch(msg)
+
+	case provider.RepoMsg:
+		if msg.Err != nil {
+			a.loading = false
+			a.err = msg.Err
+			return a, nil
+		}
+		a.openRepo(msg.Repo)
+		if a.startPR != 0 {
+			return a, a.client.FetchPR(a.currentRepo, a.startPR)
+		}
+		return a, a.fetchPRs()
+
+	case provider.PRMsg:
+		if msg.Err != nil {
+			a.loading = false
+			a.err = msg.Err
+			return a, nil
+		}
+		a.currentPR = msg.PR
+		a.state = StateComments
+		return a, a.fetchComments()
+
+	case provider.BoardMsg:
+		a.loading = false
+		if msg.Err != nil {
+			a.err = msg.Err
+			return a, nil
+		}
+		items := make([]list.Item, len(msg.Cards))
+		for i, card := range msg.Cards {
+			items[i] = ui.CardItem{Card: card}
+		}
+		return a, a.boardList.SetItems(items)
+
+	case provider.LaneMsg:
+		if msg.Lane != a.inboxLane {
+			return a, nil
+		}
 		a.loading = false
 		if msg.Err != nil {
 			a.err = msg.Err
 			return a, nil
 		}
-		items := make([]list.Item, len(msg.Repos))
-		for i, repo := range msg.Repos {
-			items[i] = ui.RepoItem{Repo: repo}
+		items := make([]list.Item, len(msg.Cards))
+		for i, card := range msg.Cards {
+			items[i] = ui.CardItem{Card: card}
+		}
+		return a, a.inboxList.SetItems(items)
+
+	case provider.CheckoutPRMsg:
+		if a.compact {
+			return a.openCompactCheckoutPR(msg)
+		}
+		if msg.Err != nil {
+			slog.Warn("failed to look up the pull request of the git checkout", "err", msg.Err)
+			return a, nil
+		}
+		if msg.PR == nil {
+			return a, nil
+		}
+		a.checkoutRepo = msg.Repo
+		a.checkoutPR = msg.PR
+		if a.state == StateRepos || a.state == StatePRs || a.state == StateBoard || a.state == StateInbox {
+			a.copyStatus = fmt.Sprintf("🔀 #%d %s is open for your checkout: press o to open its comments",
+				msg.PR.GetNumber(), msg.PR.GetTitle())
 		}
-		a.repoList.SetItems(items)
+		return a, nil
 
-	case ghclient.PRsMsg:
+	case provider.PRsMsg:
+		if msg.Repo != a.currentRepo.GetFullName() || msg.State != a.prState {
+			// Pull requests of a repository or state left meanwhile; its remaining pages are not followed
+			return a, nil
+		}
 		a.loading = false
 		if msg.Err != nil {
 			a.err = msg.Err
@@ -209,30 +742,175 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		items := make([]list.Item, len(msg.PRs))
 		for i, pr := range msg.PRs {
-			items[i] = ui.PRItem{PR: pr}
+			items[i] = ui.PRItem{PR: pr, CI: a.knownCIStatus(pr)}
 		}
 		a.prList.SetItems(items)
+		if msg.More != nil {
+			return a, tea.Batch(msg.More, a.fetchListedCIStatus())
+		}
+		return a, tea.Batch(a.prefetchComments(msg.PRs), a.fetchListedCIStatus())
+
+	case provider.CommentsPrefetchedMsg:
+		if msg.Err != nil {
+			slog.Debug("failed to prefetch comments", "repo", msg.Repo, "pr", msg.PR, "err", msg.Err)
+		}
+		return a, nil
 
-	case ghclient.CommentsMsg:
+	case provider.CommentsMsg:
+		if msg.Repo != a.currentRepo.GetFullName() || msg.PR != a.currentPR.GetNumber() {
+			// Comments of a pull request left meanwhile; its remaining pages are not followed
+			return a, nil
+		}
 		a.loading = false
 		if msg.Err != nil {
 			a.err = msg.Err
 			return a, nil
 		}
 
-		// Filter comments based on showReplies setting
-		var filteredComments []*github.PullRequestComment
-		for _, comment := range msg.Comments {
-			if a.showReplies || comment.GetInReplyTo() == 0 {
-				filteredComments = append(filteredComments, comment)
+		selected := a.selectedCommentID()
+		a.comments = msg.Comments
+		a.updateCommentList()
+		a.selectComment(selected)
+		a.refreshCommentDetail()
+		if msg.More != nil {
+			return a, msg.More
+		}
+		if a.commentsPR != a.prKey() {
+			clear(a.fresh)
+		}
+		a.commentsPR, a.commentsAt = a.prKey(), msg.FetchedAt
+
+		// Resolved threads are hidden, and marks of threads resolved upstream pruned, once the threads are known
+		return a, tea.Batch(a.client.FetchReviewThreads(a.currentRepo, a.currentPR), a.startPolling(), a.openStartComment())
+
+	case pollTickMsg:
+		return a.handlePollTick(msg)
+
+	case polledCommentsMsg:
+		return a.handlePolledComments(msg)
+
+	case provider.ReviewThreadsMsg:
+		if msg.Err != nil {
+			slog.Warn("failed to fetch review threads", "repo", msg.Repo, "pr", msg.PR, "err", msg.Err)
+			return a, nil
+		}
+		summary := a.summary(fmt.Sprintf("%s#%d", msg.Repo, msg.PR))
+		summary.threads, summary.threadsOK = msg.Threads, true
+		if a.commentsPR == a.prKey() {
+			selected := a.selectedCommentID()
+			a.updateCommentList()
+			a.selectComment(selected)
+		}
+		return a.pruneResolved(msg.Threads)
+
+	case provider.FilesMsg:
+		return a.handleFiles(msg)
+
+	case provider.CommitsMsg:
+		return a.handleCommits(msg)
+
+	case provider.CIStatusMsg:
+		// A failed lookup shows the status as unknown
+		summary := a.summary(fmt.Sprintf("%s#%d", msg.Repo, msg.PR))
+		summary.ci, summary.ciOK = msg.Status, true
+		if msg.Err != nil {
+			slog.Warn("failed to fetch the CI status", "repo", msg.Repo, "pr", msg.PR, "err", msg.Err)
+		}
+		if msg.Repo == a.currentRepo.GetFullName() {
+			for i, item := range a.prList.Items() {
+				if item, ok := item.(ui.PRItem); ok && item.PR.GetNumber() == msg.PR {
+					return a, a.prList.SetItem(i, ui.PRItem{PR: item.PR, CI: msg.Status})
+				}
 			}
 		}
 
-		items := make([]list.Item, len(filteredComments))
-		for i, comment := range filteredComments {
-			items[i] = ui.CommentItem{Comment: comment}
+	case ticketsMsg:
+		a.linked[msg.pr] = msg.linked
+		if msg.err != nil {
+			slog.Warn("failed to fetch the linked tickets", "pr", msg.pr, "err", msg.err)
+			a.copyStatus = fmt.Sprintf("⚠️ Linked tickets: %v", msg.err)
+			return a, clearCopyStatusAfter(3 * time.Second)
+		}
+
+	case provider.LinkedIssuesMsg:
+		key := fmt.Sprintf("%s#%d", msg.Repo, msg.PR)
+		a.issues[key] = msg.Issues
+		if msg.Err != nil {
+			slog.Warn("failed to fetch the linked issues", "pr", key, "err", msg.Err)
+			a.copyStatus = fmt.Sprintf("⚠️ Linked issues: %v", msg.Err)
+			return a, clearCopyStatusAfter(3 * time.Second)
+		}
+
+	case provider.CodeownersMsg:
+		// A failed lookup is not retried; owners are only shown when known
+		a.owners[msg.Repo+"@"+msg.Ref] = msg.Owners
+		if msg.Err != nil {
+			slog.Warn("failed to load CODEOWNERS", "repo", msg.Repo, "ref", msg.Ref, "err", msg.Err)
+		}
+
+	case provider.BlameMsg:
+		// A failed lookup is not retried; blame is only shown when known
+		a.blames[msg.CommentID] = msg.Ranges
+		if msg.Err != nil {
+			slog.Warn("failed to blame the commented lines", "comment", msg.CommentID, "err", msg.Err)
+		}
+		if a.state == StateCommentDetail && a.currentComment.GetID() == msg.CommentID && len(msg.Ranges) > 0 {
+			a.commentViewport.SetContent(a.buildCommentDetail())
+		}
+
+	case provider.SymbolMsg:
+		a.symbols[msg.CommentID] = msg.Symbol
+		if msg.Err != nil {
+			slog.Warn("failed to find the function enclosing the commented lines", "comment", msg.CommentID, "err", msg.Err)
+		}
+		if a.state == StateCommentDetail && a.currentComment.GetID() == msg.CommentID && msg.Symbol != nil {
+			a.commentViewport.SetContent(a.buildCommentDetail())
+		}
+
+	case provider.ContextMsg:
+		a.contexts[msg.CommentID] = msg.Context
+		if msg.Err != nil {
+			slog.Warn("failed to fetch the lines around the commented lines", "comment", msg.CommentID, "err", msg.Err)
+		}
+		if a.state == StateCommentDetail && a.currentComment.GetID() == msg.CommentID && msg.Context != "" {
+			a.commentViewport.SetContent(a.buildCommentDetail())
+		}
+
+	case provider.ReactMsg:
+		return a.handleReact(msg)
+
+	case provider.ReplyMsg:
+		return a.handleReply(msg)
+
+	case provider.ResolveMsg:
+		return a.handleResolve(msg)
+
+	case provider.CommentMsg:
+		return a.handleComment(msg)
+
+	case provider.ReviewMsg:
+		return a.handleReview(msg)
+
+	case provider.EditMsg:
+		return a.handleEdit(msg)
+
+	case provider.DeleteMsg:
+		return a.handleDelete(msg)
+
+	case provider.ViewerMsg:
+		if msg.Err != nil {
+			slog.Warn("failed to fetch the signed-in user", "err", msg.Err)
+		}
+		a.viewer = msg.Login
+
+	case worktreeMsg:
+		return a.handleWorktreeCreated(msg)
+
+	case editorClosedMsg:
+		if msg.err != nil {
+			a.copyStatus = fmt.Sprintf("⚠️ Editor failed: %v", msg.err)
+			return a, clearCopyStatusAfter(3 * time.Second)
 		}
-		a.commentList.SetItems(items)
 
 	case clearCopyStatusMsg:
 		a.copyStatus = ""
@@ -242,13 +920,37 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	switch a.state {
 	case StateRepos:
+		query := a.repoQuery()
 		a.repoList, cmd = a.repoList.Update(msg)
+		if q := a.repoQuery(); q != query && a.repoList.SettingFilter() && len(q) >= repoSearchMin {
+			cmd = tea.Batch(cmd, tea.Tick(repoSearchDelay, func(time.Time) tea.Msg {
+				return repoSearchMsg{query: q}
+			}))
+		}
 	case StatePRs:
 		a.prList, cmd = a.prList.Update(msg)
+		cmd = tea.Batch(cmd, a.fetchListedCIStatus())
 	case StateComments:
+		if a.composing() {
+			a.composer, cmd = a.composer.Update(msg)
+			break
+		}
 		a.commentList, cmd = a.commentList.Update(msg)
-	case StateCommentDetail:
+	case StateCommentDetail, StateFileDiff:
+		if a.composing() {
+			// The composer's cursor blinks
+			a.composer, cmd = a.composer.Update(msg)
+			break
+		}
 		a.commentViewport, cmd = a.commentViewport.Update(msg)
+	case StateFiles:
+		a.fileList, cmd = a.fileList.Update(msg)
+	case StateCommits:
+		a.commitList, cmd = a.commitList.Update(msg)
+	case StateBoard:
+		a.boardList, cmd = a.boardList.Update(msg)
+	case StateInbox:
+		a.inboxList, cmd = a.inboxList.Update(msg)
 	}
 
 	return a, cmd
@@ -256,6 +958,14 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // View renders the application
 func (a *App) View() string {
+	if a.ascii {
+		return ui.ASCII(a.view())
+	}
+	return a.view()
+}
+
+// view renders the application with any characters
+func (a *App) view() string {
 	if a.loading {
 		return lipgloss.NewStyle().
 			Align(lipgloss.Center).
@@ -264,10 +974,14 @@ func (a *App) View() string {
 
 	if a.err != nil {
 		return lipgloss.NewStyle().
-			Foreground(lipgloss.Color("9")).
+			Foreground(a.palette.Error).
 			Render(fmt.Sprintf("Error: %v", a.err))
 	}
 
+	if a.compact {
+		return a.compactView()
+	}
+
 	var content string
 	var breadcrumb string
 
@@ -275,21 +989,66 @@ func (a *App) View() string {
 	case StateRepos:
 		content = a.repoList.View()
 		breadcrumb = "Repositories"
+		if a.cfg.Profile != "" {
+			breadcrumb = fmt.Sprintf("Repositories (%s)", a.cfg.Profile)
+		}
 	case StatePRs:
 		content = a.prList.View()
 		breadcrumb = fmt.Sprintf("Repositories > %s > Pull Requests", a.currentRepo.GetName())
 	case StateComments:
 		prInfo := a.buildPRInfo()
+		comments := a.commentList.View()
+		if a.composing() {
+			// The review being written takes the place of the list
+			comments = a.renderComposer()
+		}
 		content = lipgloss.JoinVertical(lipgloss.Left,
 			prInfo,
-			a.commentList.View(),
+			comments,
 		)
 		breadcrumb = fmt.Sprintf("Repositories > %s > Pull Requests > #%d > Comments",
 			a.currentRepo.GetName(), a.currentPR.GetNumber())
+	case StateStats:
+		content = a.buildStatsView()
+		breadcrumb = "Usage Stats"
+	case StateBoard:
+		content = a.boardList.View()
+		breadcrumb = "Workboard"
+	case StateInbox:
+		content = a.inboxList.View()
+		breadcrumb = a.inboxLane.Name
+	case StateSummary:
+		content = a.buildSummaryView()
+		breadcrumb = fmt.Sprintf("Repositories > %s > Pull Requests > #%d > Summary",
+			a.currentRepo.GetName(), a.currentPR.GetNumber())
 	case StateCommentDetail:
 		content = a.commentViewport.View()
+		if a.composing() {
+			content = lipgloss.JoinVertical(lipgloss.Left, content, "", a.renderComposer())
+		}
 		breadcrumb = fmt.Sprintf("Repositories > %s > Pull Requests > #%d > Comments > Comment",
 			a.currentRepo.GetName(), a.currentPR.GetNumber())
+	case StateFiles:
+		content = lipgloss.JoinVertical(lipgloss.Left,
+			a.buildPRInfo(),
+			a.fileList.View(),
+		)
+		breadcrumb = fmt.Sprintf("Repositories > %s > Pull Requests > #%d > Files",
+			a.currentRepo.GetName(), a.currentPR.GetNumber())
+	case StateCommits:
+		content = lipgloss.JoinVertical(lipgloss.Left,
+			a.buildPRInfo(),
+			a.commitList.View(),
+		)
+		breadcrumb = fmt.Sprintf("Repositories > %s > Pull Requests > #%d > Commits",
+			a.currentRepo.GetName(), a.currentPR.GetNumber())
+	case StateFileDiff:
+		content = a.commentViewport.View()
+		if a.composing() {
+			content = lipgloss.JoinVertical(lipgloss.Left, content, "", a.renderComposer())
+		}
+		breadcrumb = fmt.Sprintf("Repositories > %s > Pull Requests > #%d > Files > %s",
+			a.currentRepo.GetName(), a.currentPR.GetNumber(), a.currentFile.GetFilename())
 	}
 
 	// Build help text based on current state
@@ -299,24 +1058,63 @@ func (a *App) View() string {
 		if a.useSimplePrompt {
 			promptMode = "simple"
 		}
-		helpText = fmt.Sprintf("c: copy prompt (%s) • t: toggle prompt mode • ↑/↓ j/k: scroll • Esc: back • q: quit", promptMode)
+		helpText = fmt.Sprintf("c: copy prompt (%s) • C: copy everywhere • t: toggle prompt mode • e: edit • r: reply • E/d: edit/delete mine • v: resolve • +: react • w: worktree • x: addressed • i: ignored • m: bookmark • ↑/↓ j/k: scroll • Esc: back • q: quit", promptMode)
 	} else if a.state == StateComments {
 		repliesStatus := "show"
 		if a.showReplies {
 			repliesStatus = "hide"
 		}
-		helpText = fmt.Sprintf("Enter: select • r: %s replies • Esc: back • q: quit", repliesStatus)
+		botsStatus := "hide"
+		if a.hideBots {
+			botsStatus = "show"
+		}
+		resolvedStatus := "show"
+		if a.showResolved {
+			resolvedStatus = "hide"
+		}
+		helpText = fmt.Sprintf("Enter: select • D: summary • L: commits • R: refresh • a: review • e: edit • d: delete mine • v: resolve • +: react • w: worktree • x: addressed • i: ignored • r: %s replies • b: %s bots • u: %s resolved • s: sort (%s) • m: bookmark • Esc: back • q: quit",
+			repliesStatus, botsStatus, resolvedStatus, a.commentSort)
+	} else if a.state == StateStats {
+		helpText = "Esc: back • q: quit"
+	} else if a.state == StateSummary {
+		helpText = "Enter: comments • Esc: back • q: quit"
+	} else if a.state == StateFiles {
+		helpText = "Enter: diff • Esc: back • q: quit"
+	} else if a.state == StateCommits {
+		promptCommits := "include in"
+		if a.promptCommits {
+			promptCommits = "leave out of"
+		}
+		helpText = fmt.Sprintf("c: copy SHA • p: %s prompts • Esc: back • q: quit", promptCommits)
+	} else if a.state == StateFileDiff {
+		helpText = "↑/↓ j/k: move • n: comment on line • Esc: back • q: quit"
+	} else if a.state == StateRepos && len(a.cfg.Profiles) > 0 {
+		helpText = "Enter: select • R: refresh • W: workboard • I: review inbox • M: my PRs • m: bookmark • S: stats • P: switch profile • q: quit"
+	} else if a.state == StateRepos {
+		helpText = "Enter: select • R: refresh • W: workboard • I: review inbox • M: my PRs • m: bookmark • S: stats • q: quit"
+	} else if a.state == StatePRs {
+		helpText = fmt.Sprintf("Enter: select • D: summary • L: commits • R: refresh • s: state (%s) • w: worktree • m: bookmark • S: stats • Esc: back • q: quit", a.prState)
 	} else {
-		helpText = "Enter: select • Esc: back • q: quit"
+		helpText = "Enter: select • m: bookmark • S: stats • Esc: back • q: quit"
+	}
+	if a.state != StateStats && (len(a.back) > 0 || len(a.forward) > 0) {
+		helpText = "⌫/ctrl+o: previous • tab: next • " + helpText
+	}
+	if a.checkoutPR != nil && (a.state == StateRepos || a.state == StatePRs) {
+		helpText = fmt.Sprintf("o: open #%d • %s", a.checkoutPR.GetNumber(), helpText)
 	}
 
 	help := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("8")).
+		Foreground(a.palette.Muted).
 		Render(helpText)
 
-	if a.state == StateCommentDetail {
+	if a.scrolling() {
 		// Calculate viewport height
 		fixedLines := 6
+		if a.composing() {
+			// Label and lines of the composer, below a blank line
+			fixedLines += composerHeight + 2
+		}
 		viewportHeight := max(a.height-fixedLines, 1)
 
 		// Update viewport size if needed
@@ -332,7 +1130,7 @@ func (a *App) View() string {
 
 		// Build status section (below viewport)
 		statusStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("2")).
+			Foreground(a.palette.Status).
 			Bold(true)
 		statusSection := statusStyle.Render(a.copyStatus)
 
@@ -360,7 +1158,7 @@ func (a *App) View() string {
 	// Add status if present
 	if a.copyStatus != "" {
 		statusStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("2")).
+			Foreground(a.palette.Status).
 			Bold(true)
 		elements = append(elements, statusStyle.Render(a.copyStatus))
 		elements = append(elements, "")
@@ -373,6 +1171,16 @@ func (a *App) View() string {
 	return lipgloss.JoinVertical(lipgloss.Left, elements...)
 }
 
+// openRepo makes repo the current repository and applies its default filters
+func (a *App) openRepo(repo *github.Repository) {
+	a.queue.Cancel(prefetchPRs)
+	a.currentRepo = repo
+	a.fromCards = StateRepos
+	a.state = StatePRs
+	a.filters = a.cfg.RepoFilters(repo.GetOwner().GetLogin(), repo.GetName())
+	a.hideBots = a.hideBotsPref || a.filters.HideBots
+}
+
 // handleEnter handles the enter key press
 func (a *App) handleEnter() (tea.Model, tea.Cmd) {
 	switch a.state {
@@ -380,8 +1188,8 @@ func (a *App) handleEnter() (tea.Model, tea.Cmd) {
 		selected := a.repoList.SelectedItem()
 		if selected != nil {
 			item := selected.(ui.RepoItem)
-			a.currentRepo = item.Repo
-			a.state = StatePRs
+			a.visit()
+			a.openRepo(item.Repo)
 			a.loading = true
 			return a, a.fetchPRs()
 		}
@@ -389,6 +1197,7 @@ func (a *App) handleEnter() (tea.Model, tea.Cmd) {
 		selected := a.prList.SelectedItem()
 		if selected != nil {
 			item := selected.(ui.PRItem)
+			a.visit()
 			a.currentPR = item.PR
 			a.state = StateComments
 			a.loading = true
@@ -398,40 +1207,218 @@ func (a *App) handleEnter() (tea.Model, tea.Cmd) {
 		selected := a.commentList.SelectedItem()
 		if selected != nil {
 			item := selected.(ui.CommentItem)
-			a.currentComment = item.Comment
-			a.state = StateCommentDetail
-
-			// Calculate proper viewport height before setting content
-			// Use same logic as View method: fixed 6 lines for UI elements
-			fixedLines := 6
-			viewportHeight := max(a.height-fixedLines, 1)
-
-			// Set viewport dimensions
-			a.commentViewport.Width = a.width - 4
-			a.commentViewport.Height = viewportHeight
+			a.visit()
+			return a, a.openComment(item.Comment)
+		}
+	case StateSummary:
+		a.visit()
+		a.state = StateComments
+	case StateBoard, StateInbox:
+		item, ok := a.cardList(a.state).SelectedItem().(ui.CardItem)
+		if ok {
+			a.visit()
+			return a, a.openCard(item.Card)
+		}
+	case StateFiles:
+		item, ok := a.fileList.SelectedItem().(ui.FileItem)
+		if ok {
+			a.visit()
+			a.openFile(item.File)
+		}
+	}
+	return a, nil
+}
 
-			// Set up viewport content
-			content := a.buildCommentDetail()
-			a.commentViewport.SetContent(content)
+// openStartComment opens the comment preselected on startup, once the comments of its pull request are loaded
+func (a *App) openStartComment() tea.Cmd {
+	id := a.startComment
+	if id == 0 || a.state != StateComments {
+		return nil
+	}
+	a.startComment = 0
+	for _, comment := range a.comments {
+		if comment.GetID() == id {
+			a.selectComment(id)
+			a.visit()
+			return a.openComment(comment)
+		}
+	}
+	a.copyStatus = fmt.Sprintf("⚠️ Comment %d not found on #%d", id, a.currentPR.GetNumber())
+	return clearCopyStatusAfter(3 * time.Second)
+}
 
-			return a, nil
+// refreshCommentDetail shows the loaded version of the comment in view, once the comments were fetched again
+func (a *App) refreshCommentDetail() {
+	if a.state != StateCommentDetail || a.currentComment == nil {
+		return
+	}
+	for _, comment := range a.comments {
+		if comment.GetID() == a.currentComment.GetID() {
+			a.currentComment = comment
 		}
 	}
-	return a, nil
+	a.commentViewport.SetContent(a.buildCommentDetail())
 }
 
-// handleBack handles the back navigation
+// openComment shows the details of a comment of the current pull request
+func (a *App) openComment(comment *github.PullRequestComment) tea.Cmd {
+	a.currentComment = comment
+	a.state = StateCommentDetail
+	a.markSeen(comment)
+	stats.Record(stats.EventCommentsViewed, a.currentRepo.GetFullName(), 1)
+
+	// Calculate proper viewport height before setting content
+	// Use same logic as View method: fixed 6 lines for UI elements
+	fixedLines := 6
+	viewportHeight := max(a.height-fixedLines, 1)
+
+	// Set viewport dimensions
+	a.commentViewport.Width = a.width - 4
+	a.commentViewport.Height = viewportHeight
+
+	// Set up viewport content
+	content := a.buildCommentDetail()
+	a.commentViewport.SetContent(content)
+
+	return tea.Batch(a.fetchBlame(), a.fetchSymbol(), a.fetchContext())
+}
+
+// handleOpenCheckoutPR opens the comments of the open pull request of the local git checkout
+func (a *App) handleOpenCheckoutPR() (tea.Model, tea.Cmd) {
+	a.visit()
+
+	// The pull request list belongs to another repository; it is fetched again on the way back
+	if a.currentRepo.GetFullName() != a.checkoutRepo.GetFullName() {
+		a.prList.SetItems(nil)
+	}
+
+	a.openRepo(a.checkoutRepo)
+	a.currentPR = a.checkoutPR
+	a.state = StateComments
+	a.copyStatus = ""
+	a.loading = true
+	return a, a.fetchComments()
+}
+
+// openCompactCheckoutPR opens the comments of the checkout's pull request found for compact mode
+func (a *App) openCompactCheckoutPR(msg provider.CheckoutPRMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.Err != nil:
+		a.loading = false
+		a.err = msg.Err
+		return a, nil
+	case msg.PR == nil:
+		a.loading = false
+		a.err = fmt.Errorf("no open pull request for your checkout of %s/%s", a.checkout.Owner, a.checkout.Name)
+		return a, nil
+	}
+
+	a.checkoutRepo = msg.Repo
+	a.checkoutPR = msg.PR
+	return a.handleOpenCheckoutPR()
+}
+
+// resizeCompact sizes the compact layout to the terminal, within its maximum height
+func (a *App) resizeCompact(width, height int) {
+	if a.compactHeight > 0 {
+		height = min(height, a.compactHeight)
+	}
+	a.width = width
+	a.height = height
+
+	// One line each for the title and the footer
+	a.commentList.SetSize(width, max(height-2, 1))
+	a.commentViewport.Width = width
+	a.commentViewport.Height = max(height-2, 1)
+}
+
+// compactView renders the compact layout: a title line, the comments or a comment, and a footer line with the
+// status or the key help
+func (a *App) compactView() string {
+	title := fmt.Sprintf("%s#%d %s", a.currentRepo.GetFullName(), a.currentPR.GetNumber(), a.currentPR.GetTitle())
+
+	var content, help string
+	if a.state == StateCommentDetail {
+		content = a.commentViewport.View()
+		help = "c: copy prompt • C: copy everywhere • e: edit • v: resolve • x: addressed • i: ignored • j/k: scroll • Esc: back • q: quit"
+	} else {
+		content = a.commentList.View()
+		help = "Enter: view • e: edit • v: resolve • x: addressed • i: ignored • r: replies • b: bots • u: resolved • /: filter • Esc/q: quit"
+	}
+
+	footer := lipgloss.NewStyle().Foreground(a.palette.Muted).Render(help)
+	if a.copyStatus != "" {
+		footer = lipgloss.NewStyle().Foreground(a.palette.Status).Bold(true).Render(a.copyStatus)
+	}
+
+	line := lipgloss.NewStyle().MaxWidth(a.width)
+	return lipgloss.JoinVertical(lipgloss.Left,
+		line.Bold(true).Render(title),
+		content,
+		line.Render(footer),
+	)
+}
+
+// handleBack handles the back navigation
 func (a *App) handleBack() (tea.Model, tea.Cmd) {
 	switch a.state {
 	case StatePRs:
+		a.visit()
+		a.queue.Cancel(prefetchPRs)
 		a.state = StateRepos
 		a.currentRepo = nil
+
+		// Repositories are fetched on demand when the app was started on a preselected repo
+		if len(a.repoList.Items()) == 0 {
+			a.loading = true
+			return a, a.fetchRepos()
+		}
 	case StateComments:
+		if a.compact {
+			// Compact mode is bound to a single pull request
+			return a, tea.Quit
+		}
+		a.visit()
+		a.queue.Cancel(prefetchPR)
+		if a.fromCards != StateRepos {
+			a.state = a.fromCards
+			a.currentRepo, a.currentPR = nil, nil
+			return a, nil
+		}
 		a.state = StatePRs
 		a.currentPR = nil
+
+		// Pull requests are fetched on demand when the app was started on a preselected PR
+		if len(a.prList.Items()) == 0 {
+			a.loading = true
+			return a, a.fetchPRs()
+		}
 	case StateCommentDetail:
+		a.visit()
+		a.queue.Cancel(prefetchComment)
 		a.state = StateComments
 		a.currentComment = nil
+	case StateBoard, StateInbox:
+		a.visit()
+		a.state = StateRepos
+		if len(a.repoList.Items()) == 0 {
+			a.loading = true
+			return a, a.fetchRepos()
+		}
+	case StateStats:
+		a.state = a.prevState
+		a.usage = nil
+	case StateSummary, StateFiles, StateCommits:
+		a.visit()
+		a.state = a.prevState
+		if a.state == StatePRs {
+			a.queue.Cancel(prefetchPR)
+			a.currentPR = nil
+		}
+	case StateFileDiff:
+		a.visit()
+		a.state = StateFiles
+		a.currentFile = nil
 	}
 	return a, nil
 }
@@ -446,7 +1433,12 @@ func (a *App) fetchPRs() tea.Cmd {
 	if a.currentRepo == nil {
 		return nil
 	}
-	return a.client.FetchPRs(a.currentRepo)
+	return a.client.FetchPRs(a.currentRepo, a.prListOptions())
+}
+
+// prListOptions returns the options the pull requests of the current repository are listed with
+func (a *App) prListOptions() ghclient.PRListOptions {
+	return ghclient.PRListOptions{State: a.prState, Base: a.filters.Base}
 }
 
 // fetchComments fetches comments for the current pull request
@@ -454,7 +1446,287 @@ func (a *App) fetchComments() tea.Cmd {
 	if a.currentRepo == nil || a.currentPR == nil {
 		return nil
 	}
-	return a.client.FetchComments(a.currentRepo, a.currentPR)
+	fetch := a.client.FetchComments(a.currentRepo, a.currentPR)
+	if a.commentsPR == a.prKey() && !a.commentsAt.IsZero() {
+		// The comments loaded before stay in view while those updated since are merged in
+		a.loading = false
+		a.updateCommentList()
+		fetch = a.client.RefreshComments(a.currentRepo, a.currentPR, a.comments, a.commentsAt)
+	}
+	return tea.Batch(fetch, a.fetchTickets(), a.fetchLinkedIssues(), a.fetchCodeowners(), a.fetchCIStatus(a.currentPR, prefetchPR), a.fetchViewer())
+}
+
+// knownCIStatus returns the CI status of a pull request of the current repository, if fetched
+func (a *App) knownCIStatus(pr *github.PullRequest) *ghclient.CIStatus {
+	if data := a.summaries[fmt.Sprintf("%s#%d", a.currentRepo.GetFullName(), pr.GetNumber())]; data != nil {
+		return data.ci
+	}
+	return nil
+}
+
+// fetchCIStatus queues fetching the CI status of an open pull request of the current repository in a
+// prefetch group, unless fetched before
+func (a *App) fetchCIStatus(pr *github.PullRequest, group string) tea.Cmd {
+	key := fmt.Sprintf("%s#%d", a.currentRepo.GetFullName(), pr.GetNumber())
+	if pr.GetState() != "open" || a.summary(key).ciOK {
+		return nil
+	}
+	job := a.client.CIStatusJob(a.currentRepo, pr)
+	if job == nil {
+		return nil
+	}
+	return a.queue.Add(group, "ci|"+key, job)
+}
+
+// fetchListedCIStatus queues fetching the CI status of the open pull requests on the shown page of the pull
+// request list, unless fetched before
+func (a *App) fetchListedCIStatus() tea.Cmd {
+	if a.currentRepo == nil {
+		return nil
+	}
+	items := a.prList.VisibleItems()
+	start, end := a.prList.Paginator.GetSliceBounds(len(items))
+	var cmds []tea.Cmd
+	for _, item := range items[start:end] {
+		if item, ok := item.(ui.PRItem); ok {
+			cmds = append(cmds, a.fetchCIStatus(item.PR, prefetchPRs))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// prefetchComments queues fetching the comments of the pull requests at the top of a list into the cache,
+// so opening them is instant
+func (a *App) prefetchComments(prs []*github.PullRequest) tea.Cmd {
+	if a.currentRepo == nil {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	for _, pr := range prs[:min(len(prs), a.cfg.Prefetch.Comments)] {
+		job := a.client.PrefetchComments(a.currentRepo, pr)
+		if job == nil {
+			return nil
+		}
+		key := fmt.Sprintf("comments|%s#%d", a.currentRepo.GetFullName(), pr.GetNumber())
+		cmds = append(cmds, a.queue.Add(prefetchPRs, key, job))
+	}
+	return tea.Batch(cmds...)
+}
+
+// codeownersKey identifies the current pull request's base in the CODEOWNERS cache
+func (a *App) codeownersKey() string {
+	return a.currentRepo.GetFullName() + "@" + a.currentPR.GetBase().GetRef()
+}
+
+// fetchCodeowners loads the CODEOWNERS file of the current pull request's base, from the local checkout
+// when nitpick runs in one of the repository, unless it was loaded before
+func (a *App) fetchCodeowners() tea.Cmd {
+	if _, ok := a.owners[a.codeownersKey()]; ok {
+		return nil
+	}
+	root := ""
+	if checkout := a.repoCheckout(); checkout != nil {
+		root = checkout.Root
+	}
+	return a.queue.Add(prefetchPR, "codeowners|"+a.codeownersKey(), a.client.CodeownersJob(a.currentRepo, a.currentPR.GetBase().GetRef(), root))
+}
+
+// repoCheckout returns the local checkout when it is of the current repository
+func (a *App) repoCheckout() *gitrepo.Checkout {
+	if a.checkout == nil || !a.checkout.Is(a.currentRepo.GetOwner().GetLogin(), a.currentRepo.GetName()) {
+		return nil
+	}
+	return a.checkout
+}
+
+// fetchBlame fetches the commits that last changed the current comment's lines, unless fetched before
+func (a *App) fetchBlame() tea.Cmd {
+	if _, ok := a.blames[a.currentComment.GetID()]; ok {
+		return nil
+	}
+	if _, _, _, ok := provider.CommentedLines(a.currentPR, a.currentComment); !ok {
+		return nil
+	}
+	key := fmt.Sprintf("blame|%d", a.currentComment.GetID())
+	return a.queue.Add(prefetchComment, key, a.client.BlameJob(a.currentRepo, a.currentPR, a.currentComment, a.repoCheckout()))
+}
+
+// fetchSymbol fetches the declaration enclosing the current comment's lines, unless fetched before
+func (a *App) fetchSymbol() tea.Cmd {
+	if _, ok := a.symbols[a.currentComment.GetID()]; ok {
+		return nil
+	}
+	if _, _, _, ok := provider.CommentedLines(a.currentPR, a.currentComment); !ok {
+		return nil
+	}
+	key := fmt.Sprintf("symbol|%d", a.currentComment.GetID())
+	return a.queue.Add(prefetchComment, key, a.client.SymbolJob(a.currentRepo, a.currentPR, a.currentComment, a.repoCheckout()))
+}
+
+// fetchContext fetches the lines of the commented file around the current comment's lines, unless
+// fetched before or turned off
+func (a *App) fetchContext() tea.Cmd {
+	if a.cfg.ContextLines == 0 {
+		return nil
+	}
+	if _, ok := a.contexts[a.currentComment.GetID()]; ok {
+		return nil
+	}
+	if _, _, _, ok := provider.CommentedLines(a.currentPR, a.currentComment); !ok {
+		return nil
+	}
+	key := fmt.Sprintf("context|%d", a.currentComment.GetID())
+	return a.queue.Add(prefetchComment, key, a.client.ContextJob(a.currentRepo, a.currentPR, a.currentComment, a.repoCheckout(), a.cfg.ContextLines))
+}
+
+// currentCodeowners returns the CODEOWNERS file of the current pull request's base, if loaded
+func (a *App) currentCodeowners() *codeowners.File {
+	if a.currentRepo == nil || a.currentPR == nil {
+		return nil
+	}
+	return a.owners[a.codeownersKey()]
+}
+
+// repoItemsChunk is the number of repositories added to the list at once; larger lists are shown
+// incrementally, so the first repositories appear without waiting for the list to be built
+const repoItemsChunk = 500
+
+// repoItemsMsg continues adding a fetched list of repositories to the repository list
+type repoItemsMsg struct {
+	load   int // Fetch the items belong to, superseded by later fetches
+	items  []list.Item
+	loaded int // Items already in the list
+}
+
+// loadRepoItems shows the next chunk of a fetched list of repositories and returns the command adding
+// the chunk after it, if any
+func (a *App) loadRepoItems(load int, items []list.Item, loaded int) tea.Cmd {
+	if load != a.repoLoad {
+		return nil
+	}
+
+	loaded = min(loaded+repoItemsChunk, len(items))
+	// While a filter is applied, the list filters its new items in the background
+	filter := a.repoList.SetItems(items[:loaded:loaded])
+	if loaded == len(items) {
+		return filter
+	}
+	return tea.Batch(filter, func() tea.Msg {
+		return repoItemsMsg{load: load, items: items, loaded: loaded}
+	})
+}
+
+// Repositories are searched once the filter typed is at least repoSearchMin characters long and typing paused
+// for repoSearchDelay
+const (
+	repoSearchMin   = 2
+	repoSearchDelay = 400 * time.Millisecond
+)
+
+// repoSearchMsg triggers the search of the repositories matching the filter typed, unless it changed since
+type repoSearchMsg struct {
+	query string
+}
+
+// repoQuery returns the filter typed in the repository list
+func (a *App) repoQuery() string {
+	return strings.TrimSpace(a.repoList.FilterValue())
+}
+
+// handleRepoSearch adds the repositories found by a search to the repository list, where the filter shows
+// those matching it
+func (a *App) handleRepoSearch(msg provider.RepoSearchMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		slog.Warn("failed to search repositories", "query", msg.Query, "err", msg.Err)
+		a.copyStatus = fmt.Sprintf("⚠️ Repository search: %v", msg.Err)
+		return a, clearCopyStatusAfter(3 * time.Second)
+	}
+
+	items := a.repoList.Items()
+	items, added := a.addRepoItems(items[:len(items):len(items)], msg.Repos)
+	if added == 0 {
+		return a, nil
+	}
+	a.foundRepos = append(a.foundRepos, msg.Repos...)
+
+	slog.Debug("found repositories", "query", msg.Query, "count", len(msg.Repos), "new", added)
+	a.copyStatus = fmt.Sprintf("🔎 Found %d more %s matching %q", added, plural(added, "repository", "repositories"), msg.Query)
+	return a, tea.Batch(a.repoList.SetItems(items), clearCopyStatusAfter(3*time.Second))
+}
+
+// addRepoItems appends the repositories not among items yet to them, returning the items and how many were added
+func (a *App) addRepoItems(items []list.Item, repos []*github.Repository) ([]list.Item, int) {
+	listed := make(map[string]bool, len(items)+len(repos))
+	for _, item := range items {
+		if repo, ok := item.(ui.RepoItem); ok {
+			listed[strings.ToLower(repo.Repo.GetFullName())] = true
+		}
+	}
+	aliases := a.cfg.RepoAliases()
+	added := 0
+	for _, repo := range repos {
+		name := strings.ToLower(repo.GetFullName())
+		if !listed[name] {
+			listed[name] = true
+			items = append(items, ui.NewRepoItem(repo, aliases[name]))
+			added++
+		}
+	}
+	return items, added
+}
+
+// ticketsMsg is a message containing the issue tracker tickets linked to a pull request
+type ticketsMsg struct {
+	pr     string
+	linked []*tickets.Ticket
+	err    error
+}
+
+// prKey identifies the current pull request, as owner/name#N
+func (a *App) prKey() string {
+	return fmt.Sprintf("%s#%d", a.currentRepo.GetFullName(), a.currentPR.GetNumber())
+}
+
+// fetchTickets fetches the tickets the current pull request refers to, unless no issue tracker is configured,
+// it refers to none or they were fetched before
+func (a *App) fetchTickets() tea.Cmd {
+	if a.linker == nil {
+		return nil
+	}
+	key := a.prKey()
+	if _, ok := a.linked[key]; ok || len(a.linker.Keys(a.currentPR)) == 0 {
+		return nil
+	}
+
+	linker, pr, timeout := a.linker, a.currentPR, a.cfg.Timeout
+	return a.queue.Add(prefetchPR, "tickets|"+key, func(parent context.Context) tea.Msg {
+		ctx, cancel := context.WithTimeout(parent, timeout)
+		defer cancel()
+		linked, err := linker.Linked(ctx, pr)
+		return ticketsMsg{pr: key, linked: linked, err: err}
+	})
+}
+
+// fetchLinkedIssues fetches the issues the current pull request closes, unless it closes none or they were
+// fetched before
+func (a *App) fetchLinkedIssues() tea.Cmd {
+	key := a.prKey()
+	if _, ok := a.issues[key]; ok {
+		return nil
+	}
+	if len(ghclient.ClosingRefs(a.currentPR.GetBody(), a.currentRepo.GetOwner().GetLogin(), a.currentRepo.GetName())) == 0 {
+		return nil
+	}
+	return a.queue.Add(prefetchPR, "issues|"+key, a.client.LinkedIssuesJob(a.currentRepo, a.currentPR))
+}
+
+// currentTickets returns the tickets linked to the current pull request, if fetched
+func (a *App) currentTickets() []*tickets.Ticket {
+	if a.currentRepo == nil || a.currentPR == nil {
+		return nil
+	}
+	return a.linked[a.prKey()]
 }
 
 // handleCopyPrompt handles copying the prompt to clipboard based on current mode
@@ -464,19 +1736,27 @@ func (a *App) handleCopyPrompt() (tea.Model, tea.Cmd) {
 		return a, nil
 	}
 
-	// Generate prompt based on current mode
-	var promptText string
-	var promptType string
-	if a.useSimplePrompt {
-		promptText = a.promptGen.GenerateSimplePrompt(a.currentRepo, a.currentPR, a.currentComment)
-		promptType = "Simple"
-	} else {
-		promptText = a.promptGen.GenerateFullPrompt(a.currentRepo, a.currentPR, a.currentComment)
-		promptType = "Full"
+	promptText, promptType := a.generatePrompt()
+	stats.Record(stats.EventPrompt, a.currentRepo.GetFullName(), 1)
+
+	// Fall back to a file export when the prompt is too large for the clipboard
+	if a.clipboardLimit > 0 && len(promptText) > a.clipboardLimit {
+		size := clipboard.FormatSize(len(promptText))
+		limit := clipboard.FormatSize(a.clipboardLimit)
+		if path, err := clipboard.ExportToFile(promptText); err != nil {
+			a.copyStatus = fmt.Sprintf("⚠️ %s prompt is %s (limit %s) and export failed: %v", promptType, size, limit, err)
+		} else {
+			a.copyStatus = fmt.Sprintf("⚠️ %s prompt is %s (limit %s), saved to %s", promptType, size, limit, path)
+		}
+
+		// Keep the warning visible a little longer so the path can be noted
+		return a, tea.Tick(6*time.Second, func(_ time.Time) tea.Msg {
+			return clearCopyStatusMsg{}
+		})
 	}
 
 	// Copy to clipboard
-	if err := clipboard.Copy(promptText); err != nil {
+	if err := clipboard.CopyWith(a.clipboardTarget, promptText); err != nil {
 		a.copyStatus = fmt.Sprintf("Copy failed: %v", err)
 	} else {
 		a.copyStatus = fmt.Sprintf("✅ %s prompt copied to clipboard!", promptType)
@@ -488,6 +1768,68 @@ func (a *App) handleCopyPrompt() (tea.Model, tea.Cmd) {
 	})
 }
 
+// handleCopyEverywhere copies the prompt to the clipboard, the tmux buffer and a scratch file
+func (a *App) handleCopyEverywhere() (tea.Model, tea.Cmd) {
+	if a.currentRepo == nil || a.currentPR == nil || a.currentComment == nil {
+		a.copyStatus = "Error: Missing context for prompt generation"
+		return a, nil
+	}
+
+	promptText, promptType := a.generatePrompt()
+	stats.Record(stats.EventPrompt, a.currentRepo.GetFullName(), 1)
+
+	var copied, failed []string
+	for _, result := range clipboard.CopyEverywhere(a.clipboardTarget, a.clipboardLimit, a.cfg.CacheDir, promptText) {
+		if result.Err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", result.Target, result.Err))
+		} else {
+			copied = append(copied, result.Target)
+		}
+	}
+
+	switch {
+	case len(copied) == 0:
+		a.copyStatus = fmt.Sprintf("Copy failed: %s", strings.Join(failed, "; "))
+	case len(failed) == 0:
+		a.copyStatus = fmt.Sprintf("✅ %s prompt copied to %s", promptType, strings.Join(copied, ", "))
+	default:
+		a.copyStatus = fmt.Sprintf("✅ %s prompt copied to %s (skipped %s)",
+			promptType, strings.Join(copied, ", "), strings.Join(failed, "; "))
+	}
+
+	// Clear status after 3 seconds
+	return a, tea.Tick(3*time.Second, func(_ time.Time) tea.Msg {
+		return clearCopyStatusMsg{}
+	})
+}
+
+// generatePrompt generates the prompt for the current comment based on the current mode
+func (a *App) generatePrompt() (string, string) {
+	if a.linker != nil {
+		a.promptGen.SetTickets(a.linker.ForPrompt(a.currentTickets()))
+	}
+	if a.cfg.PromptOwners {
+		a.promptGen.SetCodeowners(a.currentCodeowners())
+	}
+	if a.cfg.PromptBlame {
+		a.promptGen.SetBlame(a.blames)
+	}
+	if a.cfg.PromptSymbols {
+		a.promptGen.SetSymbols(a.symbols)
+	}
+	if a.cfg.PromptContext {
+		a.promptGen.SetContexts(a.contexts)
+	}
+	if a.cfg.PromptIssues {
+		a.promptGen.SetIssues(a.currentRepo.GetFullName(), a.issues[a.prKey()])
+	}
+	a.promptGen.SetCommits(a.promptCommitList())
+	if a.useSimplePrompt {
+		return a.promptGen.GenerateSimplePrompt(a.currentRepo, a.currentPR, a.currentComment), "Simple"
+	}
+	return a.promptGen.GenerateFullPrompt(a.currentRepo, a.currentPR, a.currentComment), "Full"
+}
+
 // handleTogglePromptMode toggles between simple and full prompt modes
 func (a *App) handleTogglePromptMode() (tea.Model, tea.Cmd) {
 	a.useSimplePrompt = !a.useSimplePrompt
@@ -498,6 +1840,7 @@ func (a *App) handleTogglePromptMode() (tea.Model, tea.Cmd) {
 	}
 
 	a.copyStatus = fmt.Sprintf("🔄 Switched to %s prompt mode", mode)
+	a.saveState()
 
 	// Clear status after 2 seconds
 	return a, tea.Tick(2*time.Second, func(_ time.Time) tea.Msg {
@@ -508,10 +1851,516 @@ func (a *App) handleTogglePromptMode() (tea.Model, tea.Cmd) {
 // handleToggleReplies toggles the showReplies setting and refetches comments
 func (a *App) handleToggleReplies() (tea.Model, tea.Cmd) {
 	a.showReplies = !a.showReplies
+	a.saveState()
 	a.loading = true
 	return a, a.fetchComments()
 }
 
+// scrolling reports whether the current state shows the viewport, scrolled with the arrow and paging keys
+func (a *App) scrolling() bool {
+	return a.state == StateCommentDetail || a.state == StateFileDiff
+}
+
+// settingFilter reports whether the list of the current state is being filtered
+func (a *App) settingFilter() bool {
+	switch a.state {
+	case StateRepos:
+		return a.repoList.SettingFilter()
+	case StatePRs:
+		return a.prList.SettingFilter()
+	case StateComments:
+		return a.commentList.SettingFilter()
+	case StateBoard, StateInbox:
+		return a.cardList(a.state).SettingFilter()
+	case StateFiles:
+		return a.fileList.SettingFilter()
+	case StateCommits:
+		return a.commitList.SettingFilter()
+	}
+	return false
+}
+
+// handleToggleBookmark bookmarks the selected repository, pull request or comment, or removes its bookmark
+func (a *App) handleToggleBookmark() (tea.Model, tea.Cmd) {
+	var bookmark bookmarks.Bookmark
+	switch a.state {
+	case StateRepos:
+		item, ok := a.repoList.SelectedItem().(ui.RepoItem)
+		if !ok {
+			return a, nil
+		}
+		bookmark = bookmarks.Bookmark{
+			Kind:  bookmarks.KindRepo,
+			Repo:  item.Repo.GetFullName(),
+			Title: item.Repo.GetDescription(),
+			URL:   item.Repo.GetHTMLURL(),
+		}
+	case StatePRs:
+		item, ok := a.prList.SelectedItem().(ui.PRItem)
+		if !ok {
+			return a, nil
+		}
+		bookmark = bookmarks.Bookmark{
+			Kind:  bookmarks.KindPR,
+			Repo:  a.currentRepo.GetFullName(),
+			PR:    item.PR.GetNumber(),
+			Title: item.PR.GetTitle(),
+			URL:   item.PR.GetHTMLURL(),
+		}
+	case StateBoard, StateInbox:
+		item, ok := a.cardList(a.state).SelectedItem().(ui.CardItem)
+		if !ok {
+			return a, nil
+		}
+		bookmark = bookmarks.Bookmark{
+			Kind:  bookmarks.KindPR,
+			Repo:  item.Card.PR.GetBase().GetRepo().GetFullName(),
+			PR:    item.Card.PR.GetNumber(),
+			Title: item.Card.PR.GetTitle(),
+			URL:   item.Card.PR.GetHTMLURL(),
+		}
+	case StateComments, StateCommentDetail:
+		comment := a.currentComment
+		if a.state == StateComments {
+			item, ok := a.commentList.SelectedItem().(ui.CommentItem)
+			if !ok {
+				return a, nil
+			}
+			comment = item.Comment
+		}
+		bookmark = bookmarks.Bookmark{
+			Kind:      bookmarks.KindComment,
+			Repo:      a.currentRepo.GetFullName(),
+			PR:        a.currentPR.GetNumber(),
+			CommentID: comment.GetID(),
+			Title:     ui.CommentItem{Comment: comment}.Title(),
+			URL:       comment.GetHTMLURL(),
+		}
+	default:
+		return a, nil
+	}
+
+	store, err := bookmarks.LoadDefault()
+	if err == nil {
+		if store.Toggle(bookmark) {
+			a.copyStatus = "🔖 Bookmarked " + bookmark.URL
+		} else {
+			a.copyStatus = "Removed bookmark " + bookmark.URL
+		}
+		err = store.Save()
+	}
+	if err != nil {
+		a.copyStatus = fmt.Sprintf("⚠️ %v", err)
+	}
+
+	return a, clearCopyStatusAfter(3 * time.Second)
+}
+
+// handleToggleProgress marks the selected comment with status, or clears the mark if it already has it
+func (a *App) handleToggleProgress(status string) (tea.Model, tea.Cmd) {
+	comment := a.currentComment
+	if a.state == StateComments {
+		item, ok := a.commentList.SelectedItem().(ui.CommentItem)
+		if !ok {
+			return a, nil
+		}
+		comment = item.Comment
+	}
+
+	status = a.progress.Toggle(comment.GetID(), a.currentRepo.GetFullName(), a.currentPR.GetNumber(), status)
+	if err := a.progress.Save(); err != nil {
+		a.copyStatus = fmt.Sprintf("⚠️ %v", err)
+		return a, clearCopyStatusAfter(3 * time.Second)
+	}
+
+	if a.state == StateCommentDetail {
+		a.commentViewport.SetContent(a.buildCommentDetail())
+	} else {
+		index := a.commentList.Index()
+		a.updateCommentList()
+		a.commentList.Select(index)
+	}
+
+	if status == "" {
+		a.copyStatus = "Cleared mark"
+	} else {
+		a.copyStatus = "✅ Marked as " + status
+	}
+	return a, clearCopyStatusAfter(2 * time.Second)
+}
+
+// editorClosedMsg reports that a terminal editor opened on a commented file has exited
+type editorClosedMsg struct {
+	err error
+}
+
+// handleOpenInEditor opens the file of the selected comment at its line in the configured editor
+func (a *App) handleOpenInEditor() (tea.Model, tea.Cmd) {
+	comment := a.currentComment
+	if a.state == StateComments {
+		item, ok := a.commentList.SelectedItem().(ui.CommentItem)
+		if !ok {
+			return a, nil
+		}
+		comment = item.Comment
+	}
+
+	switch {
+	case comment.GetPath() == "":
+		a.copyStatus = "⚠️ This comment is not on a file"
+		return a, clearCopyStatusAfter(3 * time.Second)
+	case a.checkout == nil || !a.checkout.Is(a.currentRepo.GetOwner().GetLogin(), a.currentRepo.GetName()):
+		a.copyStatus = fmt.Sprintf("⚠️ Run nitpick in a checkout of %s to open its files", a.currentRepo.GetFullName())
+		return a, clearCopyStatusAfter(3 * time.Second)
+	}
+
+	line := comment.GetLine()
+	if line == 0 {
+		line = comment.GetOriginalLine()
+	}
+	path := filepath.Join(a.checkout.Root, filepath.FromSlash(comment.GetPath()))
+
+	launch, err := editor.Prepare(a.cfg.Editor, path, line)
+	if err != nil {
+		a.copyStatus = fmt.Sprintf("⚠️ %v", err)
+		return a, clearCopyStatusAfter(3 * time.Second)
+	}
+	if launch.Terminal {
+		return a, tea.ExecProcess(launch.Cmd, func(err error) tea.Msg {
+			return editorClosedMsg{err: err}
+		})
+	}
+	if err := launch.Start(); err != nil {
+		a.copyStatus = fmt.Sprintf("⚠️ %v", err)
+		return a, clearCopyStatusAfter(3 * time.Second)
+	}
+
+	a.copyStatus = fmt.Sprintf("📝 Opened %s:%d in your editor", comment.GetPath(), max(line, 1))
+	return a, clearCopyStatusAfter(2 * time.Second)
+}
+
+// worktreeMsg reports the worktree created for a pull request
+type worktreeMsg struct {
+	pr     int
+	result provider.WorktreeResult
+	err    error
+}
+
+// handleCreateWorktree creates a worktree of the local checkout for the head branch of the selected pull request
+func (a *App) handleCreateWorktree() (tea.Model, tea.Cmd) {
+	pr := a.currentPR
+	if a.state == StatePRs {
+		item, ok := a.prList.SelectedItem().(ui.PRItem)
+		if !ok {
+			return a, nil
+		}
+		pr = item.PR
+	}
+
+	if a.checkout == nil || !a.checkout.Is(a.currentRepo.GetOwner().GetLogin(), a.currentRepo.GetName()) {
+		a.copyStatus = fmt.Sprintf("⚠️ Run nitpick in a checkout of %s to create worktrees", a.currentRepo.GetFullName())
+		return a, clearCopyStatusAfter(3 * time.Second)
+	}
+
+	a.copyStatus = fmt.Sprintf("🌳 Creating a worktree for #%d...", pr.GetNumber())
+	cfg, checkout := a.cfg, a.checkout
+	return a, func() tea.Msg {
+		result, err := provider.CreateWorktree(cfg, checkout, pr)
+		return worktreeMsg{pr: pr.GetNumber(), result: result, err: err}
+	}
+}
+
+// handleWorktreeCreated reports the worktree created for a pull request and copies its path
+func (a *App) handleWorktreeCreated(msg worktreeMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		a.copyStatus = fmt.Sprintf("⚠️ Worktree for #%d failed: %v", msg.pr, msg.err)
+		return a, clearCopyStatusAfter(6 * time.Second)
+	}
+
+	verb := "Created"
+	if !msg.result.Created {
+		verb = "Found"
+	}
+	a.copyStatus = fmt.Sprintf("🌳 %s worktree of #%d at %s", verb, msg.pr, msg.result.Path)
+	if err := clipboard.CopyWith(a.clipboardTarget, msg.result.Path); err == nil {
+		a.copyStatus += " (path copied)"
+	}
+	return a, clearCopyStatusAfter(6 * time.Second)
+}
+
+// pruneResolved drops the marks of comments whose threads have been resolved upstream
+func (a *App) pruneResolved(threads []ghclient.ReviewThread) (tea.Model, tea.Cmd) {
+	var resolved []int64
+	for _, thread := range threads {
+		if thread.IsResolved {
+			resolved = append(resolved, thread.CommentIDs...)
+		}
+	}
+	if a.progress.Prune(resolved) == 0 {
+		return a, nil
+	}
+
+	if err := a.progress.Save(); err != nil {
+		a.copyStatus = fmt.Sprintf("⚠️ %v", err)
+		return a, clearCopyStatusAfter(3 * time.Second)
+	}
+	if a.state == StateComments {
+		a.updateCommentList()
+	}
+	return a, nil
+}
+
+// handleShowStats opens the usage stats screen
+func (a *App) handleShowStats() (tea.Model, tea.Cmd) {
+	usage, err := stats.Load()
+	if err != nil {
+		a.copyStatus = fmt.Sprintf("⚠️ %v", err)
+		return a, clearCopyStatusAfter(4 * time.Second)
+	}
+
+	a.usage = usage
+	a.prevState = a.state
+	a.state = StateStats
+	return a, nil
+}
+
+// buildStatsView renders the usage stats: totals, then per-repository activity
+func (a *App) buildStatsView() string {
+	if a.usage == nil || len(a.usage.Repos) == 0 {
+		return "No activity recorded yet. Generate a prompt or open a comment to start tracking."
+	}
+
+	labelStyle := lipgloss.NewStyle().Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(a.palette.Muted)
+
+	total := a.usage.Total
+	lines := []string{
+		labelStyle.Render("Since ") + a.usage.Since.Format("2006-01-02"),
+		"",
+		fmt.Sprintf("%s %d", labelStyle.Render("Prompts generated:"), total.Prompts),
+		fmt.Sprintf("%s %d", labelStyle.Render("Threads resolved: "), total.Resolved),
+		fmt.Sprintf("%s %d", labelStyle.Render("Replies posted:   "), total.Replies),
+		fmt.Sprintf("%s %d", labelStyle.Render("Comments viewed:  "), total.CommentsViewed),
+		"",
+		labelStyle.Render(fmt.Sprintf("%-40s %8s %8s %8s %8s", "Repository", "Prompts", "Resolved", "Replies", "Viewed")),
+	}
+	for _, entry := range a.usage.ByActivity() {
+		lines = append(lines, fmt.Sprintf("%-40s %8d %8d %8d %8d  %s",
+			entry.Repo, entry.Prompts, entry.Resolved, entry.Replies, entry.CommentsViewed,
+			dimStyle.Render(entry.LastActivity.Format("2006-01-02"))))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// handleSwitchProfile switches to the next configured profile and reloads the repositories
+func (a *App) handleSwitchProfile() (tea.Model, tea.Cmd) {
+	names := a.cfg.ProfileNames()
+	if len(names) == 0 {
+		a.copyStatus = "No profiles configured"
+		return a, clearCopyStatusAfter(2 * time.Second)
+	}
+
+	next := names[0]
+	for i, name := range names {
+		if name == a.cfg.Profile {
+			next = names[(i+1)%len(names)]
+			break
+		}
+	}
+
+	cfg, err := a.cfg.Reload(next)
+	if err == nil && cfg.Token == "" {
+		err = fmt.Errorf("no token for %s; run nitpick login --host %s", cfg.Host, cfg.Host)
+	}
+	var client *provider.Source
+	if err == nil {
+		client, err = provider.NewSource(cfg)
+	}
+	var markdownStyle ansi.StyleConfig
+	if err == nil {
+		markdownStyle, err = ui.MarkdownStyle(markdownTheme(cfg.Theme, a.ascii))
+	}
+	if err != nil {
+		a.copyStatus = fmt.Sprintf("⚠️ Profile %s: %v", next, err)
+		return a, clearCopyStatusAfter(4 * time.Second)
+	}
+
+	// Background fetches of the previous profile are of no use anymore
+	for _, group := range []string{prefetchPRs, prefetchPR, prefetchComment} {
+		a.queue.Cancel(group)
+	}
+	a.cfg = cfg
+	a.client = client
+	a.markdownStyle = markdownStyle
+	a.theme = cfg.Theme
+	a.restyle()
+	a.repoList.ResetFilter()
+	a.repoList.SetItems(nil)
+	a.repoLoad++
+	a.boardList.SetItems(nil)
+	a.inboxList.SetItems(nil)
+	a.back, a.forward = nil, nil
+	a.loading = true
+	a.copyStatus = fmt.Sprintf("🔄 Switched to profile %s", next)
+	return a, tea.Batch(a.fetchRepos(), clearCopyStatusAfter(2*time.Second))
+}
+
+// clearCopyStatusAfter clears the status message after the given delay
+func clearCopyStatusAfter(delay time.Duration) tea.Cmd {
+	return tea.Tick(delay, func(_ time.Time) tea.Msg {
+		return clearCopyStatusMsg{}
+	})
+}
+
+// handleToggleBots toggles hiding comments from bot accounts
+func (a *App) handleToggleBots() (tea.Model, tea.Cmd) {
+	a.hideBots = !a.hideBots
+	a.hideBotsPref = a.hideBots
+	a.saveState()
+	a.updateCommentList()
+	return a, nil
+}
+
+// handleToggleResolved shows or hides the comments of resolved threads
+func (a *App) handleToggleResolved() (tea.Model, tea.Cmd) {
+	a.showResolved = !a.showResolved
+	a.saveState()
+	a.updateCommentList()
+	return a, nil
+}
+
+// handleCyclePRState switches the pull request list to the next state, open, closed or all, and refetches it
+func (a *App) handleCyclePRState() (tea.Model, tea.Cmd) {
+	switch a.prState {
+	case "open":
+		a.prState = "closed"
+	case "closed":
+		a.prState = "all"
+	default:
+		a.prState = "open"
+	}
+	a.queue.Cancel(prefetchPRs)
+	a.prList.ResetFilter()
+	a.loading = true
+	return a, a.fetchPRs()
+}
+
+// handleCycleCommentSort switches the comment list to the next sort order
+func (a *App) handleCycleCommentSort() (tea.Model, tea.Cmd) {
+	switch a.commentSort {
+	case config.SortUpdated:
+		a.commentSort = config.SortCreated
+	case config.SortCreated:
+		a.commentSort = config.SortFile
+	default:
+		a.commentSort = config.SortUpdated
+	}
+	a.saveState()
+	a.updateCommentList()
+	return a, nil
+}
+
+// updateCommentList fills the comment list from the current PR's comments, applying the reply, author and
+// resolution filters and the selected sort order
+func (a *App) updateCommentList() {
+	filter := a.filters
+	filter.HideBots = a.hideBots
+
+	// Comments of resolved threads are hidden once the threads are known
+	resolved := make(map[int64]bool)
+	if !a.showResolved {
+		for _, thread := range a.summary(a.prKey()).threads {
+			for _, id := range thread.CommentIDs {
+				resolved[id] = thread.IsResolved
+			}
+		}
+	}
+
+	var filteredComments []*github.PullRequestComment
+	for _, comment := range a.comments {
+		user := comment.GetUser()
+		if filter.IgnoresAuthor(user.GetLogin(), user.GetType() == "Bot") || resolved[comment.GetID()] {
+			continue
+		}
+		filteredComments = append(filteredComments, comment)
+	}
+	slog.Debug("filtered review comments", "pr", a.currentPR.GetNumber(), "total", len(a.comments),
+		"shown", len(filteredComments), "show_replies", a.showReplies, "hide_bots", a.hideBots,
+		"show_resolved", a.showResolved, "ignore_authors", filter.IgnoreAuthors)
+	sortComments(filteredComments, a.commentSort)
+
+	// Without replies listed on their own, each thread is listed as its first comment
+	if !a.showReplies {
+		a.commentList.SetItems(ui.CommentThreadItems(filteredComments, a.progress.Status, func(id int64) bool { return a.fresh[id] }))
+		return
+	}
+	items := make([]list.Item, len(filteredComments))
+	for i, comment := range filteredComments {
+		items[i] = ui.CommentItem{Comment: comment, Status: a.progress.Status(comment.GetID()), Fresh: a.fresh[comment.GetID()]}
+	}
+	a.commentList.SetItems(items)
+}
+
+// selectedCommentID returns the ID of the comment selected in the comment list, or 0 if there is none
+func (a *App) selectedCommentID() int64 {
+	item, ok := a.commentList.SelectedItem().(ui.CommentItem)
+	if !ok {
+		return 0
+	}
+	return item.Comment.GetID()
+}
+
+// selectComment selects the comment with the given ID in the comment list, if it is listed
+func (a *App) selectComment(id int64) {
+	if id == 0 {
+		return
+	}
+	for i, item := range a.commentList.VisibleItems() {
+		if item.(ui.CommentItem).Comment.GetID() == id {
+			a.commentList.Select(i)
+			return
+		}
+	}
+}
+
+// sortComments sorts comments in place by the given order. Comments arrive most recently updated first,
+// so that order needs no sorting.
+func sortComments(comments []*github.PullRequestComment, order string) {
+	switch order {
+	case config.SortCreated:
+		sort.SliceStable(comments, func(i, j int) bool {
+			return comments[i].GetCreatedAt().After(comments[j].GetCreatedAt().Time)
+		})
+	case config.SortFile:
+		sort.SliceStable(comments, func(i, j int) bool {
+			if comments[i].GetPath() != comments[j].GetPath() {
+				return comments[i].GetPath() < comments[j].GetPath()
+			}
+			return comments[i].GetOriginalLine() < comments[j].GetOriginalLine()
+		})
+	}
+}
+
+// saveState persists the UI toggles for the next session
+func (a *App) saveState() {
+	promptTemplate := prompt.TemplateFull
+	if a.useSimplePrompt {
+		promptTemplate = prompt.TemplateSimple
+	}
+
+	err := config.SaveState(config.UIState{
+		ShowReplies:    a.showReplies,
+		PromptTemplate: promptTemplate,
+		CommentSort:    a.commentSort,
+		HideBots:       a.hideBotsPref,
+		ShowResolved:   a.showResolved,
+	})
+	if err != nil {
+		a.copyStatus = fmt.Sprintf("⚠️ %v", err)
+	}
+}
+
 // clearCopyStatusMsg is used to clear the copy status message
 type clearCopyStatusMsg struct{}
 
@@ -532,10 +2381,10 @@ func (a *App) buildSelectedPRInfo(pr *github.PullRequest) string {
 	// Styles
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("12"))
+		Foreground(a.palette.Accent)
 
 	metaStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("8")).
+		Foreground(a.palette.Muted).
 		MarginBottom(1)
 
 	// PR title
@@ -570,6 +2419,27 @@ func (a *App) buildSelectedPRInfo(pr *github.PullRequest) string {
 	}
 
 	meta := fmt.Sprintf("by %s • %s%s", author, created, statusStr)
+	if ci := ui.CILabel(a.knownCIStatus(pr)); ci != "" {
+		meta += " • " + ci
+	}
+	for _, part := range ui.PRPeople(pr) {
+		meta += " • " + part
+	}
+	if pr == a.currentPR {
+		for _, ticket := range a.currentTickets() {
+			meta += fmt.Sprintf(" • 🎫 %s %s", ticket.Key, ticket.Summary)
+			if ticket.Status != "" {
+				meta += fmt.Sprintf(" (%s)", ticket.Status)
+			}
+		}
+		for _, issue := range a.issues[a.prKey()] {
+			meta += fmt.Sprintf(" • 🔗 %s %s (%s)", ghclient.IssueLabel(issue, a.currentRepo.GetFullName()), issue.GetTitle(), issue.GetState())
+		}
+		if a.width > 4 {
+			// Ticket summaries must not wrap the header onto the comment list
+			metaStyle = metaStyle.MaxWidth(a.width - 4)
+		}
+	}
 
 	return lipgloss.JoinVertical(lipgloss.Left,
 		titleStyle.Render(title),
@@ -585,13 +2455,13 @@ func (a *App) buildCommentDetail() string {
 
 	// Styles
 	titleStyle := lipgloss.NewStyle().
-		Background(lipgloss.Color("62")).
-		Foreground(lipgloss.Color("230")).
+		Background(a.palette.BannerBg).
+		Foreground(a.palette.BannerFg).
 		Padding(0, 1).
 		MarginBottom(1)
 
 	metaStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("248")).
+		Foreground(a.palette.Subtle).
 		MarginBottom(1)
 
 	var sections []string
@@ -613,6 +2483,20 @@ func (a *App) buildCommentDetail() string {
 	}
 
 	commentMeta := fmt.Sprintf("By: %s\nCreated: %s%s", author, created, updated)
+	if state := ghclient.ReviewState(a.currentComment); state != "" {
+		commentMeta += "\nReview: " + ui.ReviewLabel(state)
+	} else if ghclient.IsConversation(a.currentComment) {
+		commentMeta += "\nIn: conversation"
+	}
+	if status := a.progress.Status(a.currentComment.GetID()); status != "" {
+		commentMeta += "\nStatus: " + status
+	}
+	if thread := a.threadOf(a.currentComment.GetID()); thread != nil && thread.IsResolved {
+		commentMeta += "\nThread: ✓ resolved"
+	}
+	if reactions := ui.ReactionsLabel(a.currentComment.GetReactions()); reactions != "" {
+		commentMeta += "\nReactions: " + reactions
+	}
 	sections = append(sections, metaStyle.Render(commentMeta))
 
 	// Comment body with markdown rendering
@@ -626,19 +2510,29 @@ func (a *App) buildCommentDetail() string {
 	if err != nil {
 		// Fallback to styled plain text if markdown rendering fails
 		fallbackStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("252")).
-			Background(lipgloss.Color("236")).
+			Foreground(a.palette.Text).
+			Background(a.palette.Surface).
 			Padding(1, 2).
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("242")).
+			BorderForeground(a.palette.Border).
 			MarginBottom(1)
 		sections = append(sections, fallbackStyle.Render(body))
 	} else {
 		sections = append(sections, rendered)
 	}
 
+	// Suggested changes, as the commented lines they replace and the suggested ones
+	for _, s := range suggestion.Find(a.currentComment) {
+		sections = append(sections, a.renderSuggestion(s))
+	}
+
 	sections = append(sections, "")
 
+	// Replies Section
+	if replies := a.threadReplies(a.currentComment); len(replies) > 0 {
+		sections = append(sections, a.renderReplies(replies))
+	}
+
 	// Code Context Section
 	if a.currentComment.GetPath() != "" || a.currentComment.GetDiffHunk() != "" {
 		// File and line information
@@ -656,6 +2550,16 @@ func (a *App) buildCommentDetail() string {
 		} else {
 			sections = append(sections, "")
 		}
+
+		// Lines of the file around the commented lines
+		if lines := a.contexts[a.currentComment.GetID()]; lines != "" {
+			sections = append(sections, a.renderSurroundingLines(lines))
+		}
+
+		// Commits that last changed the commented lines
+		if ranges := a.blames[a.currentComment.GetID()]; len(ranges) > 0 {
+			sections = append(sections, a.renderBlame(ranges))
+		}
 	}
 
 	// Direct Link Section
@@ -664,6 +2568,11 @@ func (a *App) buildCommentDetail() string {
 		sections = append(sections, directLink)
 	}
 
+	// Linked Tickets Section
+	for _, ticket := range a.currentTickets() {
+		sections = append(sections, a.renderTicket(ticket))
+	}
+
 	return lipgloss.JoinVertical(lipgloss.Left, sections...)
 }
 
@@ -677,9 +2586,8 @@ func (a *App) renderMarkdown(content string) (string, error) {
 
 	// Create a renderer with enhanced terminal-friendly styling
 	renderer, err := glamour.NewTermRenderer(
-		glamour.WithAutoStyle(),
+		glamour.WithStyles(a.markdownStyle),
 		glamour.WithWordWrap(wrapWidth),
-		glamour.WithStylePath("dark"),
 	)
 	if err != nil {
 		return "", err
@@ -703,11 +2611,11 @@ func (a *App) renderCodeContext(diffHunk string) string {
 
 	// Enhanced styling for code context
 	codeBlockStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("252")).
-		Background(lipgloss.Color("234")).
+		Foreground(a.palette.Text).
+		Background(a.palette.Surface).
 		Padding(1, 2).
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("240")).
+		BorderForeground(a.palette.Border).
 		MarginBottom(1)
 
 	// Try to render the diff as markdown for syntax highlighting
@@ -728,7 +2636,7 @@ func (a *App) renderFileContext(path string, line int, originalLine int) string
 	}
 
 	infoStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("248"))
+		Foreground(a.palette.Subtle)
 
 	var info []string
 	info = append(info, fmt.Sprintf("📁 %s", path))
@@ -752,10 +2660,140 @@ func (a *App) renderFileContext(path string, line int, originalLine int) string
 			info = append(info, fmt.Sprintf("📍 Original Line: L%d", originalLine))
 		}
 	}
+	if owners := a.currentCodeowners().Owners(path); len(owners) > 0 {
+		info = append(info, fmt.Sprintf("👥 Owners: %s", strings.Join(owners, " ")))
+	}
+	if sym := a.symbols[a.currentComment.GetID()]; sym != nil {
+		info = append(info, fmt.Sprintf("🧩 In %s %s", sym.Kind, sym.Name))
+	}
 
 	return infoStyle.Render(strings.Join(info, " • "))
 }
 
+// renderSurroundingLines creates a display of the lines of the commented file around the commented lines
+func (a *App) renderSurroundingLines(lines string) string {
+	headerStyle := lipgloss.NewStyle().
+		Foreground(a.palette.Subtle)
+
+	return lipgloss.NewStyle().MarginBottom(1).Render(lipgloss.JoinVertical(lipgloss.Left,
+		headerStyle.Render(fmt.Sprintf("📁 Surrounding lines (%d above and below)", a.cfg.ContextLines)),
+		a.renderCodeContext(lines),
+	))
+}
+
+// renderSuggestion creates a display of a suggested change as a diff of the commented lines it replaces
+func (a *App) renderSuggestion(s suggestion.Suggestion) string {
+	headerStyle := lipgloss.NewStyle().
+		Foreground(a.palette.Subtle)
+
+	return lipgloss.NewStyle().MarginBottom(1).Render(lipgloss.JoinVertical(lipgloss.Left,
+		headerStyle.Render(fmt.Sprintf("📝 Suggested change (%s)", s.Lines())),
+		a.renderCodeContext(s.Diff()),
+	))
+}
+
+// renderBlame creates a display of the commits that last changed the commented lines
+func (a *App) renderBlame(ranges []blame.Range) string {
+	headerStyle := lipgloss.NewStyle().
+		Foreground(a.palette.Subtle)
+
+	commitStyle := lipgloss.NewStyle().
+		Foreground(a.palette.Link)
+
+	lines := []string{headerStyle.Render("🔎 Last changed")}
+	for _, r := range ranges {
+		lines = append(lines, fmt.Sprintf("  %s %s %s %s: %s",
+			headerStyle.Render(r.Lines()), commitStyle.Render(r.ShortCommit()), r.Who(),
+			headerStyle.Render(r.Date.Format("2006-01-02")), r.Summary))
+	}
+
+	return lipgloss.NewStyle().MarginBottom(1).Render(strings.Join(lines, "\n"))
+}
+
+// threadReplies returns the replies to a comment starting a thread, oldest first
+func (a *App) threadReplies(comment *github.PullRequestComment) []*github.PullRequestComment {
+	if comment.GetInReplyTo() != 0 {
+		return nil
+	}
+	var replies []*github.PullRequestComment
+	for _, c := range a.comments {
+		if c.GetInReplyTo() == comment.GetID() {
+			replies = append(replies, c)
+		}
+	}
+	sort.SliceStable(replies, func(i, j int) bool {
+		return replies[i].GetCreatedAt().Before(replies[j].GetCreatedAt().Time)
+	})
+	return replies
+}
+
+// renderReplies creates a display of the replies of a thread: the author, date and body of each
+func (a *App) renderReplies(replies []*github.PullRequestComment) string {
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(a.palette.Accent)
+
+	metaStyle := lipgloss.NewStyle().
+		Foreground(a.palette.Subtle)
+
+	lines := []string{headerStyle.Render(fmt.Sprintf("%d %s", len(replies), plural(len(replies), "Reply", "Replies")))}
+	for _, reply := range replies {
+		lines = append(lines, metaStyle.Render(fmt.Sprintf("↳ %s • %s", reply.GetUser().GetLogin(),
+			reply.GetCreatedAt().Format("2006-01-02 15:04"))))
+		body, err := a.renderMarkdown(reply.GetBody())
+		if err != nil {
+			body = reply.GetBody()
+		}
+		lines = append(lines, body)
+	}
+
+	return lipgloss.NewStyle().MarginBottom(1).Render(strings.Join(lines, "\n"))
+}
+
+// renderTicket creates a display of a linked ticket: its key, summary and status, and its description
+func (a *App) renderTicket(ticket *tickets.Ticket) string {
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(a.palette.Accent)
+
+	infoStyle := lipgloss.NewStyle().
+		Foreground(a.palette.Subtle)
+
+	descriptionStyle := lipgloss.NewStyle().
+		Foreground(a.palette.Text).
+		Padding(0, 2).
+		MarginBottom(1)
+	if a.width > 16 {
+		descriptionStyle = descriptionStyle.Width(a.width - 8)
+	}
+
+	var info []string
+	if ticket.Type != "" {
+		info = append(info, ticket.Type)
+	}
+	if ticket.Status != "" {
+		info = append(info, ticket.Status)
+	}
+	info = append(info, ticket.URL)
+
+	description := descriptionStyle.Render("No description provided")
+	if ticket.Description != "" {
+		description = descriptionStyle.Render(ticket.Description)
+		// Linear descriptions are markdown; Jira's wiki markup is shown as is
+		if ticket.Tracker == tickets.TrackerLinear {
+			if rendered, err := a.renderMarkdown(ticket.Description); err == nil {
+				description = rendered
+			}
+		}
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		headerStyle.Render(fmt.Sprintf("🎫 %s %s", ticket.Key, ticket.Summary)),
+		infoStyle.Render(strings.Join(info, " • ")),
+		description,
+	)
+}
+
 // renderDirectLink creates an enhanced, actionable display of the direct link
 func (a *App) renderDirectLink(url string) string {
 	if url == "" {
@@ -763,14 +2801,14 @@ func (a *App) renderDirectLink(url string) string {
 	}
 
 	linkContentStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("110")).
-		Background(lipgloss.Color("235")).
+		Foreground(a.palette.Link).
+		Background(a.palette.Surface).
 		Padding(1, 2).
 		MarginBottom(1).
 		Underline(true)
 
 	linkInstructionStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("248")).
+		Foreground(a.palette.Subtle).
 		Italic(true).
 		MarginBottom(1)
 
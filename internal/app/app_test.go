@@ -0,0 +1,120 @@
+package app
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v57/github"
+	"github.com/stefrushxyz/nitpick/internal/config"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/provider"
+)
+
+// newTestApp returns an app on the built-in mock fixtures, showing a list of comments, with its state
+// files kept in a temporary directory
+func newTestApp(t *testing.T) *App {
+	t.Helper()
+	t.Setenv("NITPICK_STATE_DIR", t.TempDir())
+	t.Setenv("NITPICK_DATA_DIR", t.TempDir())
+
+	cfg := config.Default()
+	cfg.UseMock(ghclient.DemoFixtures)
+	a, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+
+	a.state = StateComments
+	a.comments = []*github.PullRequestComment{
+		{ID: github.Int64(1), Body: github.String("Please rename this"), User: &github.User{Login: github.String("jordan")}},
+		{ID: github.Int64(2), Body: github.String("Add a test"), User: &github.User{Login: github.String("riley")}},
+	}
+	a.updateCommentList()
+	return a
+}
+
+// typeKeys sends each rune of keys to the app as a key press
+func typeKeys(a *App, keys string) {
+	for _, r := range keys {
+		a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestCommentKeysWhileFiltering(t *testing.T) {
+	tests := []struct {
+		key     string
+		changed func(a *App) any
+	}{
+		{"b", func(a *App) any { return a.hideBots }},
+		{"s", func(a *App) any { return a.commentSort }},
+		{"r", func(a *App) any { return a.showReplies }},
+		{"u", func(a *App) any { return a.showResolved }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			a := newTestApp(t)
+			before := tt.changed(a)
+
+			typeKeys(a, "/"+tt.key)
+			if !a.commentList.SettingFilter() {
+				t.Fatal("comment list is not being filtered")
+			}
+			if got := a.commentList.FilterValue(); got != tt.key {
+				t.Errorf("filter = %q, want %q", got, tt.key)
+			}
+			if got := tt.changed(a); got != before {
+				t.Errorf("%q while filtering changed the setting from %v to %v", tt.key, before, got)
+			}
+
+			a.Update(tea.KeyMsg{Type: tea.KeyEsc})
+			typeKeys(a, tt.key)
+			if got := tt.changed(a); got == before {
+				t.Errorf("%q outside the filter left the setting at %v", tt.key, got)
+			}
+		})
+	}
+}
+
+func TestStalePagesDropped(t *testing.T) {
+	a := newTestApp(t)
+	a.currentRepo = &github.Repository{FullName: github.String("acme/api")}
+	a.currentPR = &github.PullRequest{Number: github.Int(42)}
+	a.prState = "open"
+	loaded := a.comments
+	more := func() tea.Msg { return nil }
+	stale := []*github.PullRequestComment{{ID: github.Int64(99), Body: github.String("Old pull request")}}
+
+	commentTests := []provider.CommentsMsg{
+		{Repo: "acme/api", PR: 41, Comments: stale, More: more},
+		{Repo: "acme/web", PR: 42, Comments: stale, More: more},
+	}
+	for _, msg := range commentTests {
+		if _, cmd := a.Update(msg); cmd != nil {
+			t.Errorf("comments of %s#%d: followed their next page", msg.Repo, msg.PR)
+		}
+		if len(a.comments) != len(loaded) || a.comments[0] != loaded[0] {
+			t.Errorf("comments of %s#%d replaced those of acme/api#42", msg.Repo, msg.PR)
+		}
+	}
+
+	a.state = StatePRs
+	prTests := []provider.PRsMsg{
+		{Repo: "acme/web", State: "open", PRs: []*github.PullRequest{{Number: github.Int(7)}}, More: more},
+		{Repo: "acme/api", State: "closed", PRs: []*github.PullRequest{{Number: github.Int(7)}}, More: more},
+	}
+	for _, msg := range prTests {
+		if _, cmd := a.Update(msg); cmd != nil {
+			t.Errorf("%s pull requests of %s: followed their next page", msg.State, msg.Repo)
+		}
+		if n := len(a.prList.Items()); n != 0 {
+			t.Errorf("%s pull requests of %s: listed %d", msg.State, msg.Repo, n)
+		}
+	}
+
+	a.Update(provider.PRsMsg{Repo: "acme/api", State: "open", PRs: []*github.PullRequest{{Number: github.Int(42)}}})
+	if n := len(a.prList.Items()); n != 1 {
+		t.Errorf("pull requests of acme/api: listed %d, want 1", n)
+	}
+}
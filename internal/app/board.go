@@ -0,0 +1,56 @@
+package app
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stefrushxyz/nitpick/internal/workboard"
+)
+
+// handleShowBoard opens the workboard of the pull requests needing the user's attention across repositories.
+// It is fetched again each time, showing the cards fetched before meanwhile.
+func (a *App) handleShowBoard() (tea.Model, tea.Cmd) {
+	a.visit()
+	a.state = StateBoard
+	a.loading = len(a.boardList.Items()) == 0
+	return a, a.client.FetchBoard()
+}
+
+// handleShowInbox opens the inbox of the pull requests of a single workboard lane across repositories, such
+// as those awaiting the user's review. It is fetched again each time, showing the cards fetched before
+// meanwhile when they are of the same lane.
+func (a *App) handleShowInbox(lane workboard.Lane) (tea.Model, tea.Cmd) {
+	a.visit()
+	a.state = StateInbox
+	if lane != a.inboxLane {
+		a.inboxLane = lane
+		a.inboxList.Title = lane.Name
+		a.inboxList.ResetFilter()
+		a.inboxList.SetItems(nil)
+	}
+	a.loading = len(a.inboxList.Items()) == 0
+	return a, a.client.FetchLane(lane)
+}
+
+// cardList returns the list of workboard cards shown in state, StateBoard or StateInbox
+func (a *App) cardList(state State) *list.Model {
+	if state == StateInbox {
+		return &a.inboxList
+	}
+	return &a.boardList
+}
+
+// openCard opens the comments of the pull request of a workboard card. The pull request is fetched first, as
+// search results lack its branches.
+func (a *App) openCard(card workboard.Card) tea.Cmd {
+	repo := card.PR.GetBase().GetRepo()
+	from := a.state
+
+	// The pull request list belongs to another repository; it is fetched again on the way back
+	a.prList.ResetFilter()
+	a.prList.SetItems(nil)
+
+	a.openRepo(repo)
+	a.fromCards = from
+	a.loading = true
+	return a.client.FetchPR(repo, card.PR.GetNumber())
+}
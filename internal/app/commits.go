@@ -0,0 +1,104 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v57/github"
+	"github.com/stefrushxyz/nitpick/internal/clipboard"
+	"github.com/stefrushxyz/nitpick/internal/provider"
+	"github.com/stefrushxyz/nitpick/internal/ui"
+)
+
+// handleShowCommits opens the commits of the selected pull request, or of the current one
+func (a *App) handleShowCommits() (tea.Model, tea.Cmd) {
+	if a.state == StatePRs {
+		item, ok := a.prList.SelectedItem().(ui.PRItem)
+		if !ok {
+			return a, nil
+		}
+		a.visit()
+		a.currentPR = item.PR
+	} else {
+		a.visit()
+	}
+
+	a.prevState = a.state
+	a.state = StateCommits
+	return a, a.fetchCommits()
+}
+
+// fetchCommits fetches the commits of the current pull request, unless they are loaded already
+func (a *App) fetchCommits() tea.Cmd {
+	if a.commitsPR == a.prKey() {
+		return nil
+	}
+	a.commitsPR = ""
+	a.commits = nil
+	a.commitList.ResetFilter()
+	a.commitList.SetItems(nil)
+	a.loading = true
+	return a.client.FetchCommits(a.currentRepo, a.currentPR)
+}
+
+// handleCommits shows the commits of a pull request, if it is still the current one
+func (a *App) handleCommits(msg provider.CommitsMsg) (tea.Model, tea.Cmd) {
+	key := fmt.Sprintf("%s#%d", msg.Repo, msg.PR)
+	if key != a.prKey() {
+		return a, nil
+	}
+	a.loading = false
+	if msg.Err != nil {
+		slog.Warn("failed to fetch the commits", "repo", msg.Repo, "pr", msg.PR, "err", msg.Err)
+		a.err = msg.Err
+		return a, nil
+	}
+
+	// Newest first, like the other lists
+	items := make([]list.Item, len(msg.Commits))
+	for i, commit := range msg.Commits {
+		items[len(items)-1-i] = ui.CommitItem{Commit: commit}
+	}
+	a.commitsPR = key
+	a.commits = msg.Commits
+	a.commitList.Title = fmt.Sprintf("Commits (%d)", len(msg.Commits))
+	return a, a.commitList.SetItems(items)
+}
+
+// handleCopySHA copies the full SHA of the selected commit to the clipboard
+func (a *App) handleCopySHA() (tea.Model, tea.Cmd) {
+	item, ok := a.commitList.SelectedItem().(ui.CommitItem)
+	if !ok {
+		return a, nil
+	}
+	sha := item.Commit.GetSHA()
+	if err := clipboard.CopyWith(a.clipboardTarget, sha); err != nil {
+		a.copyStatus = fmt.Sprintf("Copy failed: %v", err)
+		return a, clearCopyStatusAfter(3 * time.Second)
+	}
+	a.copyStatus = fmt.Sprintf("✅ Copied %s", ui.ShortSHA(sha))
+	return a, clearCopyStatusAfter(2 * time.Second)
+}
+
+// handleTogglePromptCommits toggles whether prompts list the commits of their pull request
+func (a *App) handleTogglePromptCommits() (tea.Model, tea.Cmd) {
+	a.promptCommits = !a.promptCommits
+	if a.promptCommits {
+		a.copyStatus = "🔄 Prompts list the pull request's commits"
+	} else {
+		a.copyStatus = "🔄 Prompts leave out the pull request's commits"
+	}
+	return a, clearCopyStatusAfter(2 * time.Second)
+}
+
+// promptCommitList returns the commits prompts list: those of the current pull request, when loaded and
+// included
+func (a *App) promptCommitList() []*github.RepositoryCommit {
+	if !a.promptCommits || a.commitsPR != a.prKey() {
+		return nil
+	}
+	return a.commits
+}
@@ -0,0 +1,127 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v57/github"
+	"github.com/stefrushxyz/nitpick/internal/provider"
+	"github.com/stefrushxyz/nitpick/internal/ui"
+)
+
+// fetchViewer fetches the login of the signed-in user, which tells the comments they can edit, unless
+// fetched before
+func (a *App) fetchViewer() tea.Cmd {
+	if a.viewerAsked {
+		return nil
+	}
+	a.viewerAsked = true
+	return a.client.FetchViewer()
+}
+
+// ownComment reports whether the signed-in user wrote a comment
+func (a *App) ownComment(comment *github.PullRequestComment) bool {
+	return a.viewer != "" && strings.EqualFold(comment.GetUser().GetLogin(), a.viewer)
+}
+
+// selectedComment returns the current comment, or the selected one in the list
+func (a *App) selectedComment() *github.PullRequestComment {
+	if a.state == StateComments {
+		item, ok := a.commentList.SelectedItem().(ui.CommentItem)
+		if !ok {
+			return nil
+		}
+		return item.Comment
+	}
+	return a.currentComment
+}
+
+// handleStartEdit opens the composer on the body of the current comment, if the signed-in user wrote it
+func (a *App) handleStartEdit() (tea.Model, tea.Cmd) {
+	if !a.ownComment(a.currentComment) {
+		a.copyStatus = "⚠️ Only your own comments can be edited"
+		return a, clearCopyStatusAfter(3 * time.Second)
+	}
+
+	a.editing = a.currentComment
+	a.composer.Reset()
+	a.composer.SetWidth(max(a.width-4, 10))
+	a.composer.SetValue(a.currentComment.GetBody())
+	return a, a.composer.Focus()
+}
+
+// handleStartDelete asks to confirm deleting the current comment, or the selected one in the list, if the
+// signed-in user wrote it; the next key confirms it
+func (a *App) handleStartDelete() (tea.Model, tea.Cmd) {
+	comment := a.selectedComment()
+	if comment == nil {
+		return a, nil
+	}
+	if !a.ownComment(comment) {
+		a.copyStatus = "⚠️ Only your own comments can be deleted"
+		return a, clearCopyStatusAfter(3 * time.Second)
+	}
+
+	a.deleting = comment
+	a.copyStatus = "Delete this comment? y: delete • any other key: cancel"
+	return a, nil
+}
+
+// handleDeleteKey deletes the comment asked about on y, and cancels on any other key
+func (a *App) handleDeleteKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	comment := a.deleting
+	a.deleting = nil
+	a.copyStatus = ""
+
+	if msg.String() != "y" {
+		return a, nil
+	}
+	a.copyStatus = "Deleting comment…"
+	return a, a.client.DeleteComment(a.currentRepo, comment)
+}
+
+// handleEdit shows the edited body of a comment
+func (a *App) handleEdit(msg provider.EditMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		slog.Warn("failed to edit comment", "comment", msg.Comment.GetID(), "err", msg.Err)
+		a.copyStatus = fmt.Sprintf("⚠️ Editing failed: %v", msg.Err)
+		return a, clearCopyStatusAfter(3 * time.Second)
+	}
+
+	// The loaded comment is updated in place, in the list and the comment view alike
+	msg.Comment.Body = msg.Edited.Body
+	msg.Comment.UpdatedAt = msg.Edited.UpdatedAt
+	if a.state == StateCommentDetail {
+		a.commentViewport.SetContent(a.buildCommentDetail())
+	}
+	a.copyStatus = "✅ Comment saved"
+	return a, clearCopyStatusAfter(2 * time.Second)
+}
+
+// handleDelete drops a deleted comment from the loaded comments, leaving its view if open
+func (a *App) handleDelete(msg provider.DeleteMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		slog.Warn("failed to delete comment", "comment", msg.Comment.GetID(), "err", msg.Err)
+		a.copyStatus = fmt.Sprintf("⚠️ Deleting failed: %v", msg.Err)
+		return a, clearCopyStatusAfter(3 * time.Second)
+	}
+
+	a.comments = slices.DeleteFunc(a.comments, func(c *github.PullRequestComment) bool {
+		return c.GetID() == msg.Comment.GetID()
+	})
+	if a.state == StateCommentDetail && a.currentComment.GetID() == msg.Comment.GetID() {
+		a.queue.Cancel(prefetchComment)
+		a.state = StateComments
+		a.currentComment = nil
+	}
+	index := a.commentList.Index()
+	a.updateCommentList()
+	a.commentList.Select(min(index, max(len(a.commentList.Items())-1, 0)))
+
+	a.copyStatus = "✅ Comment deleted"
+	return a, clearCopyStatusAfter(2 * time.Second)
+}
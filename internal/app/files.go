@@ -0,0 +1,211 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/go-github/v57/github"
+	"github.com/stefrushxyz/nitpick/internal/diff"
+	"github.com/stefrushxyz/nitpick/internal/provider"
+	"github.com/stefrushxyz/nitpick/internal/ui"
+)
+
+// handleShowFiles opens the files changed by the selected pull request, or by the current one
+func (a *App) handleShowFiles() (tea.Model, tea.Cmd) {
+	if a.state == StatePRs {
+		item, ok := a.prList.SelectedItem().(ui.PRItem)
+		if !ok {
+			return a, nil
+		}
+		a.visit()
+		a.currentPR = item.PR
+	} else {
+		a.visit()
+	}
+
+	a.prevState = a.state
+	a.state = StateFiles
+	return a, a.fetchFiles()
+}
+
+// fetchFiles fetches the files changed by the current pull request, unless they are loaded already
+func (a *App) fetchFiles() tea.Cmd {
+	if a.filesPR == a.prKey() {
+		return nil
+	}
+	a.filesPR = ""
+	a.fileList.ResetFilter()
+	a.fileList.SetItems(nil)
+	a.loading = true
+	return a.client.FetchFiles(a.currentRepo, a.currentPR)
+}
+
+// handleFiles shows the files changed by a pull request, if it is still the current one
+func (a *App) handleFiles(msg provider.FilesMsg) (tea.Model, tea.Cmd) {
+	key := fmt.Sprintf("%s#%d", msg.Repo, msg.PR)
+	if key != a.prKey() {
+		return a, nil
+	}
+	a.loading = false
+	if msg.Err != nil {
+		slog.Warn("failed to fetch the changed files", "repo", msg.Repo, "pr", msg.PR, "err", msg.Err)
+		a.err = msg.Err
+		return a, nil
+	}
+
+	items := make([]list.Item, len(msg.Files))
+	for i, file := range msg.Files {
+		items[i] = ui.FileItem{File: file}
+	}
+	a.filesPR = key
+	a.fileList.Title = fmt.Sprintf("Files changed (%d)", len(msg.Files))
+	return a, a.fileList.SetItems(items)
+}
+
+// openFile shows the full diff of a file changed by the current pull request, with the cursor on its first line
+func (a *App) openFile(file *github.CommitFile) {
+	a.currentFile = file
+	a.state = StateFileDiff
+	a.diffLines = diff.Lines(file.GetPatch())
+	a.diffCursor = min(1, max(len(a.diffLines)-1, 0))
+
+	a.commentViewport.Width = a.width - 4
+	a.commentViewport.Height = max(a.height-6, 1)
+	a.commentViewport.SetContent(a.buildFileDiff())
+	a.commentViewport.GotoTop()
+}
+
+// moveDiffCursor moves the cursor of the file diff by delta lines, scrolling it into view
+func (a *App) moveDiffCursor(delta int) {
+	if len(a.diffLines) == 0 {
+		return
+	}
+	a.diffCursor = min(max(a.diffCursor+delta, 0), len(a.diffLines)-1)
+	a.commentViewport.SetContent(a.buildFileDiff())
+
+	row := lipgloss.Height(a.buildFileHeader()) + a.diffCursor
+	switch {
+	case row < a.commentViewport.YOffset:
+		a.commentViewport.SetYOffset(row)
+	case row >= a.commentViewport.YOffset+a.commentViewport.Height:
+		a.commentViewport.SetYOffset(row - a.commentViewport.Height + 1)
+	}
+}
+
+// buildFileHeader renders the path and line counts of the current file
+func (a *App) buildFileHeader() string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(a.palette.Accent)
+	metaStyle := lipgloss.NewStyle().
+		Foreground(a.palette.Muted).
+		MarginBottom(1)
+
+	item := ui.FileItem{File: a.currentFile}
+	return lipgloss.JoinVertical(lipgloss.Left,
+		titleStyle.Render(item.Title()),
+		metaStyle.Render(item.Description()),
+	)
+}
+
+// buildFileDiff renders the diff of the current file below its path and line counts, numbering its lines and
+// highlighting the one under the cursor
+func (a *App) buildFileDiff() string {
+	if a.currentFile == nil {
+		return ""
+	}
+
+	if len(a.diffLines) == 0 {
+		// The API leaves out the patches of binary files and of very large diffs
+		return lipgloss.JoinVertical(lipgloss.Left,
+			a.buildFileHeader(),
+			lipgloss.NewStyle().Foreground(a.palette.Muted).Render("No diff available: the file is binary or its diff is too large to show"),
+		)
+	}
+
+	gutterStyle := lipgloss.NewStyle().Foreground(a.palette.Muted)
+	lineWidth := max(a.commentViewport.Width-12, 1)
+	lines := []string{a.buildFileHeader()}
+	for i, line := range a.diffLines {
+		style := lipgloss.NewStyle().Foreground(a.palette.Text)
+		switch {
+		case line.Old == 0 && line.New == 0:
+			style = style.Foreground(a.palette.Accent)
+		case line.New == 0:
+			style = style.Foreground(a.palette.Removed)
+		case line.Old == 0:
+			style = style.Foreground(a.palette.Added)
+		}
+		if i == a.diffCursor {
+			style = style.Reverse(true)
+		}
+
+		gutter := fmt.Sprintf("%4s %4s │ ", lineNumber(line.Old), lineNumber(line.New))
+		lines = append(lines, gutterStyle.Render(gutter)+style.MaxWidth(lineWidth).Render(line.Text))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// lineNumber formats a line number for the gutter of a diff, blank for 0
+func lineNumber(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+// handleStartComment opens the composer for a new review comment on the line under the cursor of the file diff
+func (a *App) handleStartComment() (tea.Model, tea.Cmd) {
+	if a.diffCursor >= len(a.diffLines) {
+		return a, nil
+	}
+	line := a.diffLines[a.diffCursor]
+	if line.Old == 0 && line.New == 0 {
+		a.copyStatus = "⚠️ Move the cursor to a line of code to comment on it"
+		return a, clearCopyStatusAfter(3 * time.Second)
+	}
+
+	a.commentingOn = &line
+	a.composer.Reset()
+	a.composer.Placeholder = "Write a comment…"
+	a.composer.SetWidth(max(a.width-4, 10))
+	return a, a.composer.Focus()
+}
+
+// commentDraft returns the new review comment on the line being commented on
+func (a *App) commentDraft(body string) *github.PullRequestComment {
+	draft := &github.PullRequestComment{
+		Body:     github.String(body),
+		CommitID: github.String(a.currentPR.GetHead().GetSHA()),
+		Path:     github.String(a.currentFile.GetFilename()),
+		Line:     github.Int(a.commentingOn.New),
+		Side:     github.String("RIGHT"),
+	}
+	if a.commentingOn.New == 0 {
+		// Removed lines are only in the base of the pull request
+		draft.Line, draft.Side = github.Int(a.commentingOn.Old), github.String("LEFT")
+	}
+	return draft
+}
+
+// handleComment adds a new review comment to the loaded comments of its pull request
+func (a *App) handleComment(msg provider.CommentMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		slog.Warn("failed to comment", "repo", msg.Repo, "pr", msg.PR, "err", msg.Err)
+		a.copyStatus = fmt.Sprintf("⚠️ Commenting failed: %v", msg.Err)
+		return a, clearCopyStatusAfter(3 * time.Second)
+	}
+
+	if key := fmt.Sprintf("%s#%d", msg.Repo, msg.PR); key == a.commentsPR {
+		a.comments = append(a.comments, msg.Comment)
+		a.updateCommentList()
+	}
+	a.copyStatus = fmt.Sprintf("✅ Commented on %s L%d", msg.Comment.GetPath(), msg.Comment.GetLine())
+	return a, clearCopyStatusAfter(2 * time.Second)
+}
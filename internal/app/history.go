@@ -0,0 +1,173 @@
+package app
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v57/github"
+)
+
+// historyLimit is the number of views remembered for going back
+const historyLimit = 100
+
+// location is a view visited in the TUI, with the repository, pull request and comment it shows
+type location struct {
+	state   State
+	repo    *github.Repository
+	pr      *github.PullRequest
+	comment *github.PullRequestComment
+	file    *github.CommitFile
+}
+
+// here returns the location of the current view
+func (a *App) here() location {
+	return location{state: a.state, repo: a.currentRepo, pr: a.currentPR, comment: a.currentComment, file: a.currentFile}
+}
+
+// visit records the current view in the navigation history before it is left for another one, and forgets
+// the views gone back from
+func (a *App) visit() {
+	if a.state == StateStats {
+		return
+	}
+	a.back = pushLocation(a.back, a.here())
+	a.forward = nil
+}
+
+// pushLocation adds loc on top of a history stack, unless it is on top already, keeping at most
+// historyLimit locations
+func pushLocation(stack []location, loc location) []location {
+	if n := len(stack); n > 0 && stack[n-1] == loc {
+		return stack
+	}
+	stack = append(stack, loc)
+	if len(stack) > historyLimit {
+		stack = stack[len(stack)-historyLimit:]
+	}
+	return stack
+}
+
+// handleHistoryBack returns to the view visited before the current one
+func (a *App) handleHistoryBack() (tea.Model, tea.Cmd) {
+	if len(a.back) == 0 {
+		a.copyStatus = "No earlier view"
+		return a, clearCopyStatusAfter(2 * time.Second)
+	}
+	loc := a.back[len(a.back)-1]
+	a.back = a.back[:len(a.back)-1]
+	a.forward = pushLocation(a.forward, a.here())
+	return a, a.goTo(loc)
+}
+
+// handleHistoryForward returns to the view last gone back from
+func (a *App) handleHistoryForward() (tea.Model, tea.Cmd) {
+	if len(a.forward) == 0 {
+		a.copyStatus = "No later view"
+		return a, clearCopyStatusAfter(2 * time.Second)
+	}
+	loc := a.forward[len(a.forward)-1]
+	a.forward = a.forward[:len(a.forward)-1]
+	a.back = pushLocation(a.back, a.here())
+	return a, a.goTo(loc)
+}
+
+// goTo shows the view at loc, fetching the lists it shows unless they are loaded already
+func (a *App) goTo(loc location) tea.Cmd {
+	a.copyStatus = ""
+	if loc.state != StateFileDiff {
+		a.currentFile = nil
+	}
+	if loc.comment != a.currentComment {
+		a.queue.Cancel(prefetchComment)
+	}
+	if loc.pr.GetNumber() != a.currentPR.GetNumber() || loc.repo.GetFullName() != a.currentRepo.GetFullName() {
+		a.queue.Cancel(prefetchPR)
+	}
+
+	if loc.state == StateBoard {
+		a.state = StateBoard
+		a.currentRepo, a.currentPR, a.currentComment = nil, nil, nil
+		if len(a.boardList.Items()) == 0 {
+			a.loading = true
+			return a.client.FetchBoard()
+		}
+		return nil
+	}
+
+	if loc.state == StateInbox {
+		a.state = StateInbox
+		a.currentRepo, a.currentPR, a.currentComment = nil, nil, nil
+		if len(a.inboxList.Items()) == 0 {
+			a.loading = true
+			return a.client.FetchLane(a.inboxLane)
+		}
+		return nil
+	}
+
+	if loc.state == StateRepos {
+		a.queue.Cancel(prefetchPRs)
+		a.state = StateRepos
+		a.currentRepo, a.currentPR, a.currentComment = nil, nil, nil
+		if len(a.repoList.Items()) == 0 {
+			a.loading = true
+			return a.fetchRepos()
+		}
+		return nil
+	}
+
+	fetchPRs := false
+	if loc.repo.GetFullName() != a.currentRepo.GetFullName() {
+		// The pull request list belongs to another repository
+		a.openRepo(loc.repo)
+		a.prList.ResetFilter()
+		a.prList.SetItems(nil)
+		fetchPRs = true
+	}
+
+	switch loc.state {
+	case StatePRs:
+		a.state = StatePRs
+		a.currentPR, a.currentComment = nil, nil
+		if fetchPRs || len(a.prList.Items()) == 0 {
+			a.loading = true
+			return a.fetchPRs()
+		}
+		return nil
+	case StateComments:
+		a.currentPR, a.currentComment = loc.pr, nil
+		a.state = StateComments
+		a.loading = a.commentsPR != a.prKey()
+		return a.fetchComments()
+	case StateSummary:
+		a.currentPR, a.currentComment = loc.pr, nil
+		a.prevState = StateComments
+		a.state = StateSummary
+		return a.fetchSummary()
+	case StateFiles, StateFileDiff:
+		a.currentPR, a.currentComment = loc.pr, nil
+		a.prevState = StateComments
+		a.state = StateFiles
+		fetch := a.fetchFiles()
+		if loc.state == StateFileDiff {
+			// The diff is shown right away, from the file it was opened from
+			a.loading = false
+			a.openFile(loc.file)
+		}
+		return fetch
+	case StateCommits:
+		a.currentPR, a.currentComment = loc.pr, nil
+		a.prevState = StateComments
+		a.state = StateCommits
+		return a.fetchCommits()
+	default:
+		// The comment is shown right away, while the list to go back to is loaded
+		a.currentPR = loc.pr
+		if a.commentsPR != a.prKey() {
+			a.comments = nil
+			a.updateCommentList()
+		}
+		fetch := a.fetchComments()
+		a.loading = false
+		return tea.Batch(fetch, a.openComment(loc.comment))
+	}
+}
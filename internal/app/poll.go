@@ -0,0 +1,138 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v57/github"
+	"github.com/stefrushxyz/nitpick/internal/provider"
+)
+
+// pollTickMsg is sent when the comments of the current pull request are due to be fetched again
+type pollTickMsg struct {
+	seq int // Polling the tick belongs to
+}
+
+// polledCommentsMsg carries the comments of a pull request fetched again by a poll
+type polledCommentsMsg struct {
+	seq int    // Polling the fetch belongs to
+	pr  string // Pull request polled, as owner/name#N
+	provider.CommentsMsg
+}
+
+// startPolling polls the comments of the current pull request every poll_interval, replacing the polling of
+// comments loaded before. It returns nil when polling is disabled.
+func (a *App) startPolling() tea.Cmd {
+	if a.cfg.PollInterval <= 0 {
+		return nil
+	}
+	a.pollSeq++
+	return a.pollAfter(a.pollSeq)
+}
+
+// pollAfter schedules the next tick of a polling
+func (a *App) pollAfter(seq int) tea.Cmd {
+	return tea.Tick(a.cfg.PollInterval, func(time.Time) tea.Msg {
+		return pollTickMsg{seq: seq}
+	})
+}
+
+// handlePollTick fetches the comments of the current pull request updated since they were loaded. Polling
+// pauses outside the comment views and stops once other comments are loaded.
+func (a *App) handlePollTick(msg pollTickMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != a.pollSeq || a.currentPR == nil || a.commentsPR != a.prKey() {
+		return a, nil
+	}
+	if (a.state != StateComments && a.state != StateCommentDetail) || a.loading {
+		return a, a.pollAfter(msg.seq)
+	}
+
+	pr := a.prKey()
+	refresh := a.client.RefreshComments(a.currentRepo, a.currentPR, a.comments, a.commentsAt)
+	return a, func() tea.Msg {
+		msg := polledCommentsMsg{seq: msg.seq, pr: pr}
+		msg.CommentsMsg, _ = refresh().(provider.CommentsMsg)
+		return msg
+	}
+}
+
+// handlePolledComments merges the comments fetched by a poll into the loaded ones, marking those posted or
+// edited by others since as fresh, and schedules the next poll
+func (a *App) handlePolledComments(msg polledCommentsMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != a.pollSeq || msg.pr != a.prKey() || a.commentsPR != msg.pr {
+		return a, nil
+	}
+	next := a.pollAfter(msg.seq)
+	if msg.Err != nil {
+		// A failed poll is retried at the next one
+		slog.Warn("failed to poll comments", "pr", msg.pr, "err", msg.Err)
+		return a, next
+	}
+
+	changed := a.changedComments(msg.Comments)
+	a.commentsAt = msg.FetchedAt
+	if len(changed) == 0 {
+		return a, next
+	}
+	slog.Debug("polled new comments", "pr", msg.pr, "changed", len(changed))
+	fresh := 0
+	for _, comment := range changed {
+		// The user's own comments, e.g. posted from the browser, are merged in without a mark
+		if a.viewer == "" || comment.GetUser().GetLogin() != a.viewer {
+			a.fresh[comment.GetID()] = true
+			fresh++
+		}
+	}
+
+	selected := a.selectedCommentID()
+	a.comments = msg.Comments
+	a.updateCommentList()
+	a.selectComment(selected)
+	a.refreshCommentDetail()
+
+	threads := a.client.FetchReviewThreads(a.currentRepo, a.currentPR)
+	switch fresh {
+	case 0:
+		return a, tea.Batch(next, threads)
+	case 1:
+		a.copyStatus = "🆕 1 new or edited comment"
+	default:
+		a.copyStatus = fmt.Sprintf("🆕 %d new or edited comments", fresh)
+	}
+	return a, tea.Batch(next, threads, clearCopyStatusAfter(3*time.Second))
+}
+
+// changedComments returns the comments of polled posted or edited since the loaded comments were fetched
+func (a *App) changedComments(polled []*github.PullRequestComment) []*github.PullRequestComment {
+	loaded := make(map[int64]*github.PullRequestComment, len(a.comments))
+	for _, comment := range a.comments {
+		loaded[comment.GetID()] = comment
+	}
+
+	var changed []*github.PullRequestComment
+	for _, comment := range polled {
+		before, ok := loaded[comment.GetID()]
+		if !ok || comment.GetUpdatedAt().After(before.GetUpdatedAt().Time) {
+			changed = append(changed, comment)
+		}
+	}
+	return changed
+}
+
+// markSeen drops the fresh mark of a comment and of the replies in its thread, once opened
+func (a *App) markSeen(comment *github.PullRequestComment) {
+	if len(a.fresh) == 0 {
+		return
+	}
+	delete(a.fresh, comment.GetID())
+	for _, c := range a.comments {
+		if c.GetInReplyTo() == comment.GetID() {
+			delete(a.fresh, c.GetID())
+		}
+	}
+	selected := a.selectedCommentID()
+	a.updateCommentList()
+	a.selectComment(selected)
+}
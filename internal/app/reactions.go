@@ -0,0 +1,77 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/provider"
+	"github.com/stefrushxyz/nitpick/internal/ui"
+)
+
+// handleStartReaction asks which reaction to add to the current comment, or to the selected one in the list;
+// the next key picks it
+func (a *App) handleStartReaction() (tea.Model, tea.Cmd) {
+	comment := a.currentComment
+	if a.state == StateComments {
+		item, ok := a.commentList.SelectedItem().(ui.CommentItem)
+		if !ok {
+			return a, nil
+		}
+		comment = item.Comment
+	}
+
+	choices := make([]string, len(ghclient.Reactions))
+	for i, content := range ghclient.Reactions {
+		choices[i] = fmt.Sprintf("%d %s", i+1, ui.ReactionEmoji[content])
+	}
+	a.reactingTo = comment
+	a.copyStatus = "React: " + strings.Join(choices, "  ") + "  (any other key cancels)"
+	return a, nil
+}
+
+// handleReactionKey adds the reaction picked by a key to the comment being reacted to, or cancels on any
+// key but a reaction's number
+func (a *App) handleReactionKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	comment := a.reactingTo
+	a.reactingTo = nil
+	a.copyStatus = ""
+
+	n, err := strconv.Atoi(msg.String())
+	if err != nil || n < 1 || n > len(ghclient.Reactions) {
+		return a, nil
+	}
+	content := ghclient.Reactions[n-1]
+	a.copyStatus = fmt.Sprintf("Reacting with %s…", ui.ReactionEmoji[content])
+	return a, a.client.React(a.currentRepo, comment, content)
+}
+
+// handleReact counts a reaction added to a comment and shows it in the views showing the comment
+func (a *App) handleReact(msg provider.ReactMsg) (tea.Model, tea.Cmd) {
+	emoji := ui.ReactionEmoji[msg.Content]
+	if msg.Err != nil {
+		slog.Warn("failed to react to comment", "comment", msg.Comment.GetID(), "content", msg.Content, "err", msg.Err)
+		a.copyStatus = fmt.Sprintf("⚠️ Reaction failed: %v", msg.Err)
+		return a, clearCopyStatusAfter(3 * time.Second)
+	}
+	if !msg.Added {
+		a.copyStatus = fmt.Sprintf("Already reacted with %s", emoji)
+		return a, clearCopyStatusAfter(2 * time.Second)
+	}
+
+	ghclient.AddReaction(msg.Comment, msg.Content)
+	switch a.state {
+	case StateCommentDetail:
+		a.commentViewport.SetContent(a.buildCommentDetail())
+	case StateComments:
+		index := a.commentList.Index()
+		a.updateCommentList()
+		a.commentList.Select(index)
+	}
+	a.copyStatus = fmt.Sprintf("✅ Reacted with %s", emoji)
+	return a, clearCopyStatusAfter(2 * time.Second)
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stefrushxyz/nitpick/internal/ui"
+)
+
+// handleRefresh fetches the data of the current view again, bypassing the cache, keeping what is shown in
+// view until it arrives
+func (a *App) handleRefresh() (tea.Model, tea.Cmd) {
+	var what string
+	var cmd tea.Cmd
+	switch a.state {
+	case StateRepos:
+		what = "repositories"
+		a.client.ForgetRepos()
+		cmd = a.fetchRepos()
+	case StatePRs:
+		what = "pull requests"
+		a.client.ForgetPRs(a.currentRepo, a.prListOptions())
+		// The CI status of the listed pull requests is fetched again along with them
+		for _, item := range a.prList.Items() {
+			if item, ok := item.(ui.PRItem); ok {
+				a.summary(fmt.Sprintf("%s#%d", a.currentRepo.GetFullName(), item.PR.GetNumber())).ciOK = false
+			}
+		}
+		a.queue.Cancel(prefetchPRs)
+		cmd = a.fetchPRs()
+	case StateComments, StateCommentDetail:
+		what = "comments"
+		a.summary(a.prKey()).ciOK = false
+		cmd = tea.Batch(
+			a.client.RefreshComments(a.currentRepo, a.currentPR, nil, time.Time{}),
+			a.fetchCIStatus(a.currentPR, prefetchPR),
+		)
+	case StateBoard:
+		what = "workboard"
+		cmd = a.client.FetchBoard()
+	case StateInbox:
+		what = "inbox"
+		cmd = a.client.FetchLane(a.inboxLane)
+	case StateFiles:
+		what = "changed files"
+		a.filesPR = ""
+		cmd = a.fetchFiles()
+	case StateCommits:
+		what = "commits"
+		a.commitsPR = ""
+		cmd = a.fetchCommits()
+	default:
+		a.copyStatus = "Nothing to refresh here"
+		return a, clearCopyStatusAfter(2 * time.Second)
+	}
+
+	a.err = nil
+	a.copyStatus = fmt.Sprintf("🔄 Refreshing %s…", what)
+	return a, tea.Batch(cmd, clearCopyStatusAfter(2*time.Second))
+}
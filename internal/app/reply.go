@@ -0,0 +1,144 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/go-github/v57/github"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/provider"
+	"github.com/stefrushxyz/nitpick/internal/stats"
+)
+
+// composerHeight is the number of lines of the composer replies and edits are written in
+const composerHeight = 5
+
+// newComposer creates the text area replies and edits are written in
+func newComposer() textarea.Model {
+	composer := textarea.New()
+	composer.Placeholder = "Write a reply…"
+	composer.Prompt = "│ "
+	composer.ShowLineNumbers = false
+	composer.CharLimit = 0
+	composer.SetHeight(composerHeight)
+	composer.FocusedStyle.CursorLine = lipgloss.NewStyle()
+	return composer
+}
+
+// handleStartReply opens the reply composer below the current comment
+func (a *App) handleStartReply() (tea.Model, tea.Cmd) {
+	if ghclient.IsConversation(a.currentComment) || ghclient.ReviewState(a.currentComment) != "" {
+		a.copyStatus = "⚠️ Only comments on code have threads to reply in"
+		return a, clearCopyStatusAfter(3 * time.Second)
+	}
+
+	a.replyTo = a.currentComment
+	a.composer.Reset()
+	a.composer.Placeholder = "Write a reply…"
+	a.composer.SetWidth(max(a.width-4, 10))
+	return a, a.composer.Focus()
+}
+
+// composing reports whether the composer is open, for a reply or an edit
+func (a *App) composing() bool {
+	return a.replyTo != nil || a.editing != nil || a.commentingOn != nil || a.reviewEvent != ""
+}
+
+// handleComposerKey edits the text being written, posts the reply, comment or review or saves the edit on
+// ctrl+s, and closes the composer on esc
+func (a *App) handleComposerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		a.closeComposer()
+		return a, nil
+	case "ctrl+s":
+		body := a.composer.Value()
+		if a.reviewEvent != "" {
+			return a.submitReview(body)
+		}
+		if strings.TrimSpace(body) == "" {
+			return a, nil
+		}
+		if a.commentingOn != nil {
+			draft := a.commentDraft(body)
+			a.closeComposer()
+			a.copyStatus = "Posting comment…"
+			return a, a.client.CreateComment(a.currentRepo, a.currentPR, draft)
+		}
+		if comment := a.editing; comment != nil {
+			a.closeComposer()
+			a.copyStatus = "Saving comment…"
+			return a, a.client.EditComment(a.currentRepo, a.currentPR, comment, body)
+		}
+		comment := a.replyTo
+		a.closeComposer()
+		a.copyStatus = "Posting reply…"
+		return a, a.client.Reply(a.currentRepo, a.currentPR, comment, body)
+	}
+
+	var cmd tea.Cmd
+	a.composer, cmd = a.composer.Update(msg)
+	return a, cmd
+}
+
+// closeComposer closes the composer, dropping the text being written
+func (a *App) closeComposer() {
+	a.replyTo = nil
+	a.editing = nil
+	a.commentingOn = nil
+	a.reviewEvent = ""
+	a.composer.Blur()
+}
+
+// renderComposer renders the composer with its key help
+func (a *App) renderComposer() string {
+	labelStyle := lipgloss.NewStyle().
+		Foreground(a.palette.Subtle)
+
+	var label string
+	switch {
+	case a.editing != nil:
+		label = "Edit your comment • ctrl+s: save • Esc: cancel"
+	case a.reviewEvent != "":
+		label = fmt.Sprintf("Review #%d: %s • ctrl+s: submit • Esc: cancel", a.currentPR.GetNumber(), a.reviewLabel())
+	case a.commentingOn != nil:
+		line := a.commentingOn.New
+		if line == 0 {
+			line = a.commentingOn.Old
+		}
+		label = fmt.Sprintf("Comment on %s L%d • ctrl+s: post • Esc: cancel", a.currentFile.GetFilename(), line)
+	default:
+		label = fmt.Sprintf("Reply to %s • ctrl+s: send • Esc: cancel", a.replyTo.GetUser().GetLogin())
+	}
+	return lipgloss.JoinVertical(lipgloss.Left,
+		labelStyle.Render(label),
+		a.composer.View(),
+	)
+}
+
+// handleReply adds a posted reply to the loaded comments, showing it in its thread
+func (a *App) handleReply(msg provider.ReplyMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		slog.Warn("failed to post reply", "comment", msg.Comment.GetID(), "err", msg.Err)
+		a.copyStatus = fmt.Sprintf("⚠️ Reply failed: %v", msg.Err)
+		return a, clearCopyStatusAfter(3 * time.Second)
+	}
+	stats.Record(stats.EventReply, a.currentRepo.GetFullName(), 1)
+
+	// The comments of another pull request may have been loaded since
+	if slices.ContainsFunc(a.comments, func(c *github.PullRequestComment) bool { return c.GetID() == msg.Comment.GetID() }) {
+		a.comments = append(a.comments, msg.Reply)
+		a.updateCommentList()
+		if a.state == StateCommentDetail {
+			a.commentViewport.SetContent(a.buildCommentDetail())
+		}
+	}
+	a.copyStatus = "✅ Replied to " + msg.Comment.GetUser().GetLogin()
+	return a, clearCopyStatusAfter(2 * time.Second)
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/provider"
+	"github.com/stefrushxyz/nitpick/internal/stats"
+	"github.com/stefrushxyz/nitpick/internal/ui"
+)
+
+// threadOf returns the review thread of the current pull request containing a comment, or nil when the
+// threads are not loaded or none contains it
+func (a *App) threadOf(commentID int64) *ghclient.ReviewThread {
+	threads := a.summary(a.prKey()).threads
+	for i := range threads {
+		for _, id := range threads[i].CommentIDs {
+			if id == commentID {
+				return &threads[i]
+			}
+		}
+	}
+	return nil
+}
+
+// handleToggleResolve resolves the thread of the current comment, or of the selected one in the list, or
+// unresolves it when it is resolved
+func (a *App) handleToggleResolve() (tea.Model, tea.Cmd) {
+	comment := a.currentComment
+	if a.state == StateComments {
+		item, ok := a.commentList.SelectedItem().(ui.CommentItem)
+		if !ok {
+			return a, nil
+		}
+		comment = item.Comment
+	}
+
+	if !a.summary(a.prKey()).threadsOK {
+		a.copyStatus = "⚠️ The review threads are not loaded yet"
+		return a, clearCopyStatusAfter(3 * time.Second)
+	}
+	thread := a.threadOf(comment.GetID())
+	if thread == nil {
+		a.copyStatus = "⚠️ The comment is not in a review thread"
+		return a, clearCopyStatusAfter(3 * time.Second)
+	}
+
+	a.copyStatus = "Resolving thread…"
+	if thread.IsResolved {
+		a.copyStatus = "Unresolving thread…"
+	}
+	return a, a.client.Resolve(a.currentRepo, a.currentPR, thread.ID, !thread.IsResolved)
+}
+
+// handleResolve records the new resolution of a thread, hiding or showing its comments in the list
+func (a *App) handleResolve(msg provider.ResolveMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		slog.Warn("failed to resolve thread", "repo", msg.Repo, "pr", msg.PR, "thread", msg.ThreadID, "err", msg.Err)
+		a.copyStatus = fmt.Sprintf("⚠️ Resolving failed: %v", msg.Err)
+		return a, clearCopyStatusAfter(3 * time.Second)
+	}
+
+	key := fmt.Sprintf("%s#%d", msg.Repo, msg.PR)
+	threads := a.summary(key).threads
+	for i := range threads {
+		if threads[i].ID == msg.ThreadID {
+			threads[i].IsResolved = msg.Resolved
+		}
+	}
+
+	a.copyStatus = "Unresolved thread"
+	if msg.Resolved {
+		stats.Record(stats.EventResolve, msg.Repo, 1)
+		a.copyStatus = "✅ Resolved thread"
+	}
+	if key != a.prKey() {
+		return a, clearCopyStatusAfter(2 * time.Second)
+	}
+
+	if a.commentsPR == key {
+		selected := a.selectedCommentID()
+		a.updateCommentList()
+		a.selectComment(selected)
+	}
+	if a.state == StateCommentDetail {
+		a.commentViewport.SetContent(a.buildCommentDetail())
+	}
+	if _, cmd := a.pruneResolved(threads); cmd != nil {
+		return a, cmd
+	}
+	return a, clearCopyStatusAfter(2 * time.Second)
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/provider"
+)
+
+// reviewVerdicts lists the verdicts a review can be submitted with, picked by their number
+var reviewVerdicts = []struct {
+	event string
+	label string
+}{
+	{ghclient.EventApprove, "✅ approve"},
+	{ghclient.EventRequestChanges, "🔴 request changes"},
+	{ghclient.EventComment, "💬 comment"},
+}
+
+// handleStartReview asks for the verdict of a review of the current pull request; the next key picks it
+func (a *App) handleStartReview() (tea.Model, tea.Cmd) {
+	choices := make([]string, len(reviewVerdicts))
+	for i, verdict := range reviewVerdicts {
+		choices[i] = fmt.Sprintf("%d %s", i+1, verdict.label)
+	}
+	a.choosingVerdict = true
+	a.copyStatus = "Review: " + strings.Join(choices, "  ") + "  (any other key cancels)"
+	return a, nil
+}
+
+// handleVerdictKey opens the composer for the summary of a review with the verdict picked by a key, or
+// cancels on any key but a verdict's number
+func (a *App) handleVerdictKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	a.choosingVerdict = false
+	a.copyStatus = ""
+
+	n, err := strconv.Atoi(msg.String())
+	if err != nil || n < 1 || n > len(reviewVerdicts) {
+		return a, nil
+	}
+	a.reviewEvent = reviewVerdicts[n-1].event
+	a.composer.Reset()
+	a.composer.Placeholder = "Summarize your review…"
+	if a.reviewEvent == ghclient.EventApprove {
+		a.composer.Placeholder = "Summarize your review (optional)…"
+	}
+	a.composer.SetWidth(max(a.width-4, 10))
+	return a, a.composer.Focus()
+}
+
+// reviewLabel returns the label of the verdict of the review being written
+func (a *App) reviewLabel() string {
+	for _, verdict := range reviewVerdicts {
+		if verdict.event == a.reviewEvent {
+			return verdict.label
+		}
+	}
+	return a.reviewEvent
+}
+
+// submitReview submits the review being written with its summary body, which only approvals may leave empty
+func (a *App) submitReview(body string) (tea.Model, tea.Cmd) {
+	if strings.TrimSpace(body) == "" && a.reviewEvent != ghclient.EventApprove {
+		return a, nil
+	}
+	event := a.reviewEvent
+	a.closeComposer()
+	a.copyStatus = "Submitting review…"
+	return a, a.client.SubmitReview(a.currentRepo, a.currentPR, event, body)
+}
+
+// handleReview adds the body of a submitted review to the loaded comments of its pull request
+func (a *App) handleReview(msg provider.ReviewMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		slog.Warn("failed to submit review", "repo", msg.Repo, "pr", msg.PR, "event", msg.Event, "err", msg.Err)
+		a.copyStatus = fmt.Sprintf("⚠️ Review failed: %v", msg.Err)
+		return a, clearCopyStatusAfter(3 * time.Second)
+	}
+
+	key := fmt.Sprintf("%s#%d", msg.Repo, msg.PR)
+	if key == a.commentsPR && strings.TrimSpace(msg.Review.GetBody()) != "" {
+		a.comments = append(a.comments, msg.Review)
+		a.updateCommentList()
+	}
+
+	switch msg.Event {
+	case ghclient.EventApprove:
+		a.copyStatus = fmt.Sprintf("✅ Approved #%d", msg.PR)
+	case ghclient.EventRequestChanges:
+		a.copyStatus = fmt.Sprintf("✅ Requested changes on #%d", msg.PR)
+	default:
+		a.copyStatus = fmt.Sprintf("✅ Reviewed #%d", msg.PR)
+	}
+	return a, clearCopyStatusAfter(2 * time.Second)
+}
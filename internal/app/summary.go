@@ -0,0 +1,174 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/summary"
+	"github.com/stefrushxyz/nitpick/internal/ui"
+)
+
+// summaryFiles is the number of files listed in a review summary
+const summaryFiles = 10
+
+// prSummary holds what the review summary of a pull request shows besides its comments
+type prSummary struct {
+	threads   []ghclient.ReviewThread // Comment threads with their resolution state
+	threadsOK bool                    // Whether threads were fetched
+	ci        *ghclient.CIStatus      // CI status of the head commit; nil when the provider reports none
+	ciOK      bool                    // Whether ci was fetched
+}
+
+// summary returns the summary data of the pull request with the given key, adding it when missing
+func (a *App) summary(key string) *prSummary {
+	if a.summaries[key] == nil {
+		a.summaries[key] = &prSummary{}
+	}
+	return a.summaries[key]
+}
+
+// handleShowSummary opens the review summary of the selected pull request, or of the current one
+func (a *App) handleShowSummary() (tea.Model, tea.Cmd) {
+	if a.state == StatePRs {
+		item, ok := a.prList.SelectedItem().(ui.PRItem)
+		if !ok {
+			return a, nil
+		}
+		a.visit()
+		a.currentPR = item.PR
+	} else {
+		a.visit()
+	}
+
+	a.prevState = a.state
+	a.state = StateSummary
+	return a, a.fetchSummary()
+}
+
+// fetchSummary fetches what the review summary of the current pull request shows: its comments, the
+// resolution of its threads and its CI status
+func (a *App) fetchSummary() tea.Cmd {
+	a.loading = a.commentsPR != a.prKey()
+	return tea.Batch(
+		a.fetchComments(),
+		a.client.FetchReviewThreads(a.currentRepo, a.currentPR),
+		a.client.FetchCIStatus(a.currentRepo, a.currentPR),
+	)
+}
+
+// buildSummaryView renders the review summary of the current pull request: its CI status, thread counts,
+// the comment waiting longest for an answer, its reviewers and its most commented files
+func (a *App) buildSummaryView() string {
+	data := a.summary(a.prKey())
+	threads := data.threads
+	if data.threadsOK && threads == nil {
+		// Fetched, without any threads
+		threads = []ghclient.ReviewThread{}
+	}
+	s := summary.New(a.currentPR, a.comments, threads)
+
+	labelStyle := lipgloss.NewStyle().Bold(true)
+	dimStyle := lipgloss.NewStyle().Foreground(a.palette.Muted)
+
+	counts := fmt.Sprintf("%d %s", s.Threads, plural(s.Threads, "thread", "threads"))
+	if s.Unresolved >= 0 {
+		counts += fmt.Sprintf(" • %d unresolved", s.Unresolved)
+	}
+	counts += fmt.Sprintf(" • %d outdated", s.Outdated)
+
+	waiting := "none"
+	if s.Unanswered != nil {
+		comment := s.Unanswered
+		location := "general"
+		if path := comment.GetPath(); path != "" {
+			location = path
+			if line := comment.GetLine(); line != 0 {
+				location = fmt.Sprintf("%s L%d", path, line)
+			}
+		}
+		waiting = fmt.Sprintf("%d %s; oldest by %s on %s, %s ago: %s",
+			s.Waiting, plural(s.Waiting, "thread", "threads"), comment.GetUser().GetLogin(), location,
+			formatAge(time.Since(comment.GetCreatedAt().Time)), ui.CommentItem{Comment: comment}.Title())
+	}
+
+	lines := []string{
+		a.buildPRInfo(),
+		fmt.Sprintf("%s %s", labelStyle.Render("CI:               "), renderCIStatus(data)),
+		fmt.Sprintf("%s %s", labelStyle.Render("Threads:          "), counts),
+		fmt.Sprintf("%s %s", labelStyle.Render("Awaiting author:  "), waiting),
+		"",
+		labelStyle.Render("Reviewers"),
+	}
+	if len(s.Reviewers) == 0 {
+		lines = append(lines, dimStyle.Render("  No reviewers yet"))
+	}
+	for _, reviewer := range s.Reviewers {
+		line := fmt.Sprintf("  %-30s %3d %s", reviewer.Login, reviewer.Comments, plural(reviewer.Comments, "comment", "comments"))
+		if reviewer.Requested {
+			line += dimStyle.Render("  review requested")
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "", labelStyle.Render("Threads by file"))
+	if len(s.Files) == 0 {
+		lines = append(lines, dimStyle.Render("  No comments yet"))
+	}
+	for i, file := range s.Files {
+		if i == summaryFiles {
+			lines = append(lines, dimStyle.Render(fmt.Sprintf("  … and %d more files", len(s.Files)-i)))
+			break
+		}
+		path := file.Path
+		if path == "" {
+			path = "(not on a file)"
+		}
+		lines = append(lines, fmt.Sprintf("  %3d  %s", file.Threads, path))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderCIStatus renders the CI status of a pull request
+func renderCIStatus(data *prSummary) string {
+	status := data.ci
+	switch {
+	case !data.ciOK:
+		return "…"
+	case status == nil:
+		return "unknown"
+	}
+
+	switch status.State {
+	case ghclient.CISuccess:
+		return fmt.Sprintf("✅ passing (%d %s)", status.Total, plural(status.Total, "check", "checks"))
+	case ghclient.CIFailure:
+		return fmt.Sprintf("❌ %d of %d failing: %s", len(status.Failed), status.Total, strings.Join(status.Failed, ", "))
+	case ghclient.CIPending:
+		return fmt.Sprintf("⏳ %d of %d pending", status.Pending, status.Total)
+	}
+	return "no checks"
+}
+
+// formatAge formats a duration coarsely, in minutes, hours or days
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
+
+// plural returns singular when n is 1, and otherwise pluralForm
+func plural(n int, singular, pluralForm string) string {
+	if n == 1 {
+		return singular
+	}
+	return pluralForm
+}
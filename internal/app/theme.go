@@ -0,0 +1,84 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stefrushxyz/nitpick/internal/config"
+	"github.com/stefrushxyz/nitpick/internal/ui"
+)
+
+// markdownTheme returns the theme markdown is rendered with: glamour's ASCII style in ASCII mode, and
+// otherwise the theme
+func markdownTheme(theme string, ascii bool) string {
+	if ascii {
+		return "ascii"
+	}
+	return theme
+}
+
+// handleToggleASCII switches between drawing any characters and only ASCII ones, for this session
+func (a *App) handleToggleASCII() (tea.Model, tea.Cmd) {
+	a.ascii = !a.ascii
+	if err := a.applyTheme(); err != nil {
+		a.ascii = !a.ascii
+		a.copyStatus = fmt.Sprintf("⚠️ %v", err)
+		return a, clearCopyStatusAfter(4 * time.Second)
+	}
+	a.copyStatus = "ASCII mode off"
+	if a.ascii {
+		a.copyStatus = "ASCII mode on"
+	}
+	return a, clearCopyStatusAfter(2 * time.Second)
+}
+
+// handleToggleHighContrast switches between the high-contrast theme and the configured one, for this session.
+// When the high-contrast theme is the configured one, it switches to the default theme instead.
+func (a *App) handleToggleHighContrast() (tea.Model, tea.Cmd) {
+	prev := a.theme
+	switch {
+	case a.theme != config.ThemeHighContrast:
+		a.theme = config.ThemeHighContrast
+	case a.cfg.Theme != config.ThemeHighContrast:
+		a.theme = a.cfg.Theme
+	default:
+		a.theme = config.DefaultTheme
+	}
+	if err := a.applyTheme(); err != nil {
+		a.theme = prev
+		a.copyStatus = fmt.Sprintf("⚠️ %v", err)
+		return a, clearCopyStatusAfter(4 * time.Second)
+	}
+	a.copyStatus = "High contrast off"
+	if a.theme == config.ThemeHighContrast {
+		a.copyStatus = "High contrast on"
+	}
+	return a, clearCopyStatusAfter(2 * time.Second)
+}
+
+// applyTheme restyles the TUI and its markdown for the current theme and ASCII mode
+func (a *App) applyTheme() error {
+	markdownStyle, err := ui.MarkdownStyle(markdownTheme(a.theme, a.ascii))
+	if err != nil {
+		return err
+	}
+	a.markdownStyle = markdownStyle
+	a.restyle()
+	switch a.state {
+	case StateCommentDetail:
+		a.commentViewport.SetContent(a.buildCommentDetail())
+	case StateFileDiff:
+		a.commentViewport.SetContent(a.buildFileDiff())
+	}
+	return nil
+}
+
+// restyle applies the palette of the current theme to the lists
+func (a *App) restyle() {
+	a.palette = ui.PaletteFor(a.theme)
+	for _, l := range []*list.Model{&a.repoList, &a.prList, &a.commentList, &a.boardList, &a.inboxList, &a.fileList, &a.commitList} {
+		a.palette.StyleList(l)
+	}
+}
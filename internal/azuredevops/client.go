@@ -0,0 +1,355 @@
+package azuredevops
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/stefrushxyz/nitpick/internal/diff"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/rest"
+)
+
+// DefaultHost is the host of Azure DevOps Services
+const DefaultHost = "dev.azure.com"
+
+// apiVersion is the REST API version requested, the oldest serving everything used here so that
+// Azure DevOps Server 2020 and later work as well as the hosted service
+const apiVersion = "6.0"
+
+// defaultPerPage is the page size used when Options.PageSize is unset
+const defaultPerPage = 100
+
+// contextLines is the number of lines shown above a commented line, as Azure DevOps serves no diff hunks
+const contextLines = 3
+
+// errMissingOrganization is returned when no organization URL is configured
+var errMissingOrganization = errors.New("the azuredevops provider needs base_url set to your organization URL, e.g. https://dev.azure.com/acme")
+
+// Client reads repositories, pull requests and comment threads from the Azure DevOps REST API of one
+// organization, presenting them as the GitHub repositories, pull requests and review comments the TUI and
+// prompt generator work with. Repositories are named project/repository.
+type Client struct {
+	rest    *rest.Client
+	baseURL string // Organization (or Azure DevOps Server collection) URL
+}
+
+// Options configures a Client. Token is a personal access token with the Code scope and BaseURL the
+// organization URL, e.g. https://dev.azure.com/acme; Host is not used.
+type Options = rest.Options
+
+// New creates an Azure DevOps client
+func New(opts Options) (*Client, error) {
+	if opts.BaseURL == "" {
+		return nil, errMissingOrganization
+	}
+
+	client, err := rest.New(rest.API{
+		Name:     "Azure DevOps",
+		BaseURL:  opts.BaseURL,
+		PageSize: defaultPerPage,
+		Auth: func(req *http.Request) {
+			// Personal access tokens are sent as the password of an empty user name
+			req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(":"+opts.Token)))
+		},
+		Error: apiError,
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{rest: client, baseURL: client.BaseURL()}, nil
+}
+
+// Timeout returns the timeout for the requests of a single fetch
+func (c *Client) Timeout() time.Duration {
+	return c.rest.Timeout()
+}
+
+// get fetches an API path relative to the organization URL and decodes the JSON response into out
+func (c *Client) get(ctx context.Context, path string, query url.Values, out any) error {
+	return c.request(ctx, http.MethodGet, path, query, nil, out)
+}
+
+// request sends an API request with in, if not nil, as its JSON body and decodes the JSON response into
+// out, if not nil
+func (c *Client) request(ctx context.Context, method, path string, query url.Values, in, out any) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("api-version", apiVersion)
+	resp, err := c.rest.Do(ctx, method, path, query, in)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNonAuthoritativeInfo {
+		// Rejected credentials are answered with a sign-in page rather than an error status
+		return fmt.Errorf("Azure DevOps API error: authentication failed; check the token")
+	}
+	return c.rest.Decode(resp.Body, out)
+}
+
+// apiError describes an error response of the Azure DevOps API
+func apiError(status int, body []byte) error {
+	var apiErr struct {
+		Message string `json:"message"`
+	}
+	message := rest.Message(body)
+	if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+		message = apiErr.Message
+	}
+	return fmt.Errorf("Azure DevOps API error %d: %s", status, message)
+}
+
+// list is a list response of the Azure DevOps API
+type list[T any] struct {
+	Value []T `json:"value"`
+}
+
+// repoPath returns the API path of a repository given its project and name
+func repoPath(project, name string) string {
+	return "/" + url.PathEscape(project) + "/_apis/git/repositories/" + url.PathEscape(name)
+}
+
+// ListRepos lists the repositories of every project in the organization, by project and name.
+// Azure DevOps returns them in one response; opts only limits how many are kept.
+func (c *Client) ListRepos(ctx context.Context, opts ghclient.ListOptions) ([]*github.Repository, error) {
+	var repos list[repository]
+	if err := c.get(ctx, "/_apis/git/repositories", nil, &repos); err != nil {
+		return nil, err
+	}
+
+	result := make([]*github.Repository, 0, len(repos.Value))
+	for _, r := range repos.Value {
+		if r.IsDisabled {
+			continue
+		}
+		result = append(result, r.toRepository())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return strings.ToLower(result[i].GetFullName()) < strings.ToLower(result[j].GetFullName())
+	})
+	if opts.Limit > 0 && len(result) > opts.Limit {
+		result = result[:opts.Limit]
+	}
+	return result, nil
+}
+
+// GetRepo fetches a single repository by project and name
+func (c *Client) GetRepo(ctx context.Context, project, name string) (*github.Repository, error) {
+	var r repository
+	if err := c.get(ctx, repoPath(project, name), nil, &r); err != nil {
+		return nil, err
+	}
+	return r.toRepository(), nil
+}
+
+// ListChanges lists the pull requests of a repository, highest number first
+func (c *Client) ListChanges(ctx context.Context, project, name string, opts ghclient.PRListOptions) ([]*github.PullRequest, error) {
+	status := "active"
+	switch opts.State {
+	case "closed":
+		// Completed and abandoned pull requests, filtered below
+		status = "all"
+	case "all":
+		status = "all"
+	}
+	query := url.Values{"searchCriteria.status": {status}}
+	if opts.Base != "" {
+		query.Set("searchCriteria.targetRefName", "refs/heads/"+opts.Base)
+	}
+
+	perPage := c.rest.PageSize(opts.ListOptions)
+	query.Set("$top", strconv.Itoa(perPage))
+
+	prs, err := rest.List(ctx, opts.ListOptions, func(cursor string) (rest.Page[*github.PullRequest], error) {
+		skip, _ := strconv.Atoi(cursor)
+		query.Set("$skip", strconv.Itoa(skip))
+		var page list[pullRequest]
+		if err := c.get(ctx, repoPath(project, name)+"/pullrequests", query, &page); err != nil {
+			return rest.Page[*github.PullRequest]{}, err
+		}
+		var result rest.Page[*github.PullRequest]
+		for _, pr := range page.Value {
+			if opts.State == "closed" && pr.Status == "active" {
+				continue
+			}
+			result.Items = append(result.Items, pr.toPullRequest(c.baseURL))
+		}
+		if len(page.Value) == perPage {
+			result.Next = strconv.Itoa(skip + perPage)
+		}
+		return result, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(prs, func(i, j int) bool {
+		return prs[i].GetNumber() > prs[j].GetNumber()
+	})
+	return prs, nil
+}
+
+// GetChange fetches a single pull request by ID
+func (c *Client) GetChange(ctx context.Context, project, name string, number int) (*github.PullRequest, error) {
+	var pr pullRequest
+	if err := c.get(ctx, fmt.Sprintf("%s/pullrequests/%d", repoPath(project, name), number), nil, &pr); err != nil {
+		return nil, err
+	}
+	return pr.toPullRequest(c.baseURL), nil
+}
+
+// listThreads lists every comment thread of a pull request
+func (c *Client) listThreads(ctx context.Context, project, name string, number int) ([]thread, error) {
+	var threads list[thread]
+	if err := c.get(ctx, fmt.Sprintf("%s/pullRequests/%d/threads", repoPath(project, name), number), nil, &threads); err != nil {
+		return nil, err
+	}
+	return threads.Value, nil
+}
+
+// fileContent fetches the content of a file at a commit
+func (c *Client) fileContent(ctx context.Context, project, name, path, commit string) (string, error) {
+	query := url.Values{
+		"path":                          {path},
+		"includeContent":                {"true"},
+		"versionDescriptor.version":     {commit},
+		"versionDescriptor.versionType": {"commit"},
+	}
+	var item struct {
+		Content string `json:"content"`
+	}
+	if err := c.get(ctx, repoPath(project, name)+"/items", query, &item); err != nil {
+		return "", err
+	}
+	return item.Content, nil
+}
+
+// ListComments lists the comments of a pull request's active threads, with the lines leading up to the
+// commented line as their diff context, most recently updated first. Threads are listed in full; opts only
+// limits how many comments are kept.
+func (c *Client) ListComments(ctx context.Context, project, name string, number int, opts ghclient.ListOptions) ([]*github.PullRequestComment, error) {
+	pr, err := c.GetChange(ctx, project, name, number)
+	if err != nil {
+		return nil, err
+	}
+	threads, err := c.listThreads(ctx, project, name, number)
+	if err != nil {
+		return nil, err
+	}
+
+	// Files are fetched once per side and path, and only for threads on a file
+	contents := make(map[string]string)
+	hunk := func(t thread) string {
+		path, line, side := t.location()
+		if path == "" || line == 0 {
+			return ""
+		}
+		commit := pr.GetHead().GetSHA()
+		if side == "LEFT" {
+			commit = pr.GetBase().GetSHA()
+		}
+		key := side + ":" + path
+		content, ok := contents[key]
+		if !ok {
+			var err error
+			if content, err = c.fileContent(ctx, project, name, "/"+path, commit); err != nil {
+				slog.WarnContext(ctx, "failed to fetch file content", "repo", project+"/"+name, "path", path, "err", err)
+			}
+			contents[key] = content
+		}
+		return diff.Context(content, line, contextLines)
+	}
+
+	var comments []*github.PullRequestComment
+	for _, t := range threads {
+		if t.IsDeleted || !t.resolvable() || t.resolved() {
+			continue
+		}
+		comments = append(comments, t.toComments(pr, hunk(t))...)
+	}
+	slog.DebugContext(ctx, "listed thread comments", "repo", project+"/"+name, "pr", number, "count", len(comments))
+
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].GetUpdatedAt().After(comments[j].GetUpdatedAt().Time)
+	})
+	if opts.Limit > 0 && len(comments) > opts.Limit {
+		comments = comments[:opts.Limit]
+	}
+	return comments, nil
+}
+
+// ListThreads lists the comment threads of a pull request, active and pending threads being
+// unresolved and threads of any other status resolved
+func (c *Client) ListThreads(ctx context.Context, project, name string, number int) ([]ghclient.ReviewThread, error) {
+	threads, err := c.listThreads(ctx, project, name, number)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []ghclient.ReviewThread
+	for _, t := range threads {
+		if !t.resolvable() {
+			continue
+		}
+		rt := ghclient.ReviewThread{ID: strconv.Itoa(t.ID), IsResolved: t.resolved()}
+		for _, cm := range t.Comments {
+			rt.CommentIDs = append(rt.CommentIDs, commentID(t.ID, cm.ID))
+		}
+		result = append(result, rt)
+	}
+	return result, nil
+}
+
+// threadPath returns the API path of a pull request's comment thread
+func threadPath(project, name string, number, threadID int) string {
+	return fmt.Sprintf("%s/pullRequests/%d/threads/%d", repoPath(project, name), number, threadID)
+}
+
+// Reply posts a reply to the first comment of the given comment's thread
+func (c *Client) Reply(ctx context.Context, project, name string, number int, commentID int64, body string) (*github.PullRequestComment, error) {
+	pr, err := c.GetChange(ctx, project, name, number)
+	if err != nil {
+		return nil, err
+	}
+	threadID := threadOf(commentID)
+	var t thread
+	if err := c.get(ctx, threadPath(project, name, number, threadID), nil, &t); err != nil {
+		return nil, err
+	}
+	if len(t.Comments) == 0 {
+		return nil, fmt.Errorf("%w for comment %d", ghclient.ErrThreadNotFound, commentID)
+	}
+
+	payload := map[string]any{"content": body, "parentCommentId": t.Comments[0].ID, "commentType": "text"}
+	var created threadComment
+	if err := c.request(ctx, http.MethodPost, threadPath(project, name, number, threadID)+"/comments", nil, payload, &created); err != nil {
+		return nil, err
+	}
+	t.Comments = append(t.Comments, created)
+	comments := t.toComments(pr, "")
+	return comments[len(comments)-1], nil
+}
+
+// Resolve sets the status of a thread to fixed, or back to active if resolved is false
+func (c *Client) Resolve(ctx context.Context, project, name string, number int, threadID string, resolved bool) error {
+	id, err := strconv.Atoi(threadID)
+	if err != nil {
+		return fmt.Errorf("invalid thread ID %q", threadID)
+	}
+	status := "fixed"
+	if !resolved {
+		status = "active"
+	}
+	return c.request(ctx, http.MethodPatch, threadPath(project, name, number, id), nil, map[string]string{"status": status}, nil)
+}
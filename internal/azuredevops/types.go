@@ -0,0 +1,246 @@
+package azuredevops
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// repository is an Azure DevOps Git repository
+type repository struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	DefaultBranch string `json:"defaultBranch"`
+	WebURL        string `json:"webUrl"`
+	IsDisabled    bool   `json:"isDisabled"`
+	IsFork        bool   `json:"isFork"`
+	Project       struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Visibility  string `json:"visibility"`
+	} `json:"project"`
+}
+
+// toRepository presents the repository as a GitHub repository owned by its project
+func (r repository) toRepository() *github.Repository {
+	return &github.Repository{
+		NodeID:        github.String(r.ID),
+		Name:          github.String(r.Name),
+		FullName:      github.String(r.Project.Name + "/" + r.Name),
+		Owner:         &github.User{Login: github.String(r.Project.Name)},
+		Description:   github.String(r.Project.Description),
+		HTMLURL:       github.String(r.WebURL),
+		DefaultBranch: github.String(strings.TrimPrefix(r.DefaultBranch, "refs/heads/")),
+		Private:       github.Bool(r.Project.Visibility != "public"),
+		Fork:          github.Bool(r.IsFork),
+	}
+}
+
+// identity is an Azure DevOps user or service identity
+type identity struct {
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"`
+}
+
+// toUser presents the identity as a GitHub user, logged in by its unique name (usually an email address)
+func (i identity) toUser() *github.User {
+	login := i.UniqueName
+	if login == "" {
+		login = i.DisplayName
+	}
+	return &github.User{
+		Login: github.String(login),
+		Name:  github.String(i.DisplayName),
+		Type:  github.String("User"),
+	}
+}
+
+// commit references a commit by ID
+type commit struct {
+	CommitID string `json:"commitId"`
+}
+
+// pullRequest is an Azure DevOps pull request
+type pullRequest struct {
+	PullRequestID int        `json:"pullRequestId"`
+	Title         string     `json:"title"`
+	Description   string     `json:"description"`
+	Status        string     `json:"status"` // active, completed or abandoned
+	IsDraft       bool       `json:"isDraft"`
+	CreatedBy     identity   `json:"createdBy"`
+	CreationDate  time.Time  `json:"creationDate"`
+	ClosedDate    *time.Time `json:"closedDate"`
+	SourceRefName string     `json:"sourceRefName"`
+	TargetRefName string     `json:"targetRefName"`
+	SourceCommit  *commit    `json:"lastMergeSourceCommit"`
+	TargetCommit  *commit    `json:"lastMergeTargetCommit"`
+	Repository    struct {
+		Name    string `json:"name"`
+		Project struct {
+			Name string `json:"name"`
+		} `json:"project"`
+	} `json:"repository"`
+}
+
+// toPullRequest presents the pull request as a GitHub pull request; baseURL is the organization URL
+func (pr pullRequest) toPullRequest(baseURL string) *github.PullRequest {
+	state := "open"
+	if pr.Status != "active" {
+		state = "closed"
+	}
+	updated := pr.CreationDate
+	if pr.ClosedDate != nil && !pr.ClosedDate.IsZero() {
+		updated = *pr.ClosedDate
+	}
+
+	head := &github.PullRequestBranch{Ref: github.String(strings.TrimPrefix(pr.SourceRefName, "refs/heads/"))}
+	if pr.SourceCommit != nil {
+		head.SHA = github.String(pr.SourceCommit.CommitID)
+	}
+	base := &github.PullRequestBranch{Ref: github.String(strings.TrimPrefix(pr.TargetRefName, "refs/heads/"))}
+	if pr.TargetCommit != nil {
+		base.SHA = github.String(pr.TargetCommit.CommitID)
+	}
+
+	return &github.PullRequest{
+		Number:    github.Int(pr.PullRequestID),
+		Title:     github.String(pr.Title),
+		Body:      github.String(pr.Description),
+		State:     github.String(state),
+		Draft:     github.Bool(pr.IsDraft),
+		Merged:    github.Bool(pr.Status == "completed"),
+		HTMLURL:   github.String(fmt.Sprintf("%s/%s/_git/%s/pullrequest/%d", baseURL, url.PathEscape(pr.Repository.Project.Name), url.PathEscape(pr.Repository.Name), pr.PullRequestID)),
+		User:      pr.CreatedBy.toUser(),
+		CreatedAt: &github.Timestamp{Time: pr.CreationDate},
+		UpdatedAt: &github.Timestamp{Time: updated},
+		Head:      head,
+		Base:      base,
+	}
+}
+
+// position is a line and column of a file
+type position struct {
+	Line int `json:"line"`
+}
+
+// thread is a comment thread of an Azure DevOps pull request
+type thread struct {
+	ID            int    `json:"id"`
+	Status        string `json:"status"` // active, pending, fixed, wontFix, closed or byDesign; empty for system threads
+	IsDeleted     bool   `json:"isDeleted"`
+	ThreadContext *struct {
+		FilePath       string    `json:"filePath"`
+		RightFileStart *position `json:"rightFileStart"`
+		RightFileEnd   *position `json:"rightFileEnd"`
+		LeftFileStart  *position `json:"leftFileStart"`
+		LeftFileEnd    *position `json:"leftFileEnd"`
+	} `json:"threadContext"`
+	Comments []threadComment `json:"comments"`
+}
+
+// threadComment is a comment of a thread
+type threadComment struct {
+	ID              int       `json:"id"`
+	ParentCommentID int       `json:"parentCommentId"`
+	Author          identity  `json:"author"`
+	Content         string    `json:"content"`
+	CommentType     string    `json:"commentType"` // text, codeChange or system
+	IsDeleted       bool      `json:"isDeleted"`
+	PublishedDate   time.Time `json:"publishedDate"`
+	LastUpdatedDate time.Time `json:"lastUpdatedDate"`
+}
+
+// commentID returns a comment ID unique within a pull request, as the IDs Azure DevOps gives comments
+// only number them within their thread
+func commentID(threadID, id int) int64 {
+	return int64(threadID)<<20 | int64(id)
+}
+
+// threadOf returns the ID of the thread of a comment ID made by commentID
+func threadOf(id int64) int {
+	return int(id >> 20)
+}
+
+// resolvable reports whether the thread has a status, which threads of system messages lack
+func (t thread) resolvable() bool {
+	return t.Status != "" && t.Status != "unknown"
+}
+
+// resolved reports whether the thread's status is anything but active or pending
+func (t thread) resolved() bool {
+	return t.Status != "active" && t.Status != "pending"
+}
+
+// location returns the file, line and side of the diff the thread is on, or an empty path for threads
+// on the pull request itself
+func (t thread) location() (path string, line int, side string) {
+	ctx := t.ThreadContext
+	if ctx == nil || ctx.FilePath == "" {
+		return "", 0, ""
+	}
+	path = strings.TrimPrefix(ctx.FilePath, "/")
+	switch {
+	case ctx.RightFileEnd != nil:
+		return path, ctx.RightFileEnd.Line, "RIGHT"
+	case ctx.LeftFileEnd != nil:
+		return path, ctx.LeftFileEnd.Line, "LEFT"
+	}
+	return path, 0, ""
+}
+
+// toComments presents the thread's comments as review comments; comments after the first are replies to
+// it. hunk is the diff context of the commented line.
+func (t thread) toComments(pr *github.PullRequest, hunk string) []*github.PullRequestComment {
+	path, line, side := t.location()
+	var start int
+	if ctx := t.ThreadContext; ctx != nil {
+		if side == "RIGHT" && ctx.RightFileStart != nil {
+			start = ctx.RightFileStart.Line
+		} else if side == "LEFT" && ctx.LeftFileStart != nil {
+			start = ctx.LeftFileStart.Line
+		}
+	}
+
+	var rootID int64
+	var comments []*github.PullRequestComment
+	for _, cm := range t.Comments {
+		if cm.IsDeleted || cm.CommentType == "system" {
+			continue
+		}
+		comment := &github.PullRequestComment{
+			ID:             github.Int64(commentID(t.ID, cm.ID)),
+			Body:           github.String(cm.Content),
+			User:           cm.Author.toUser(),
+			CreatedAt:      &github.Timestamp{Time: cm.PublishedDate},
+			UpdatedAt:      &github.Timestamp{Time: cm.LastUpdatedDate},
+			HTMLURL:        github.String(fmt.Sprintf("%s?discussionId=%d", pr.GetHTMLURL(), t.ID)),
+			PullRequestURL: github.String(pr.GetHTMLURL()),
+			CommitID:       github.String(pr.GetHead().GetSHA()),
+		}
+		if rootID == 0 {
+			rootID = comment.GetID()
+		} else {
+			comment.InReplyTo = github.Int64(rootID)
+		}
+		if path != "" {
+			comment.Path = github.String(path)
+		}
+		if line != 0 {
+			comment.Line = github.Int(line)
+			comment.OriginalLine = github.Int(line)
+			comment.Side = github.String(side)
+			if start != 0 && start != line {
+				comment.StartLine = github.Int(start)
+				comment.OriginalStartLine = github.Int(start)
+			}
+		}
+		if hunk != "" {
+			comment.DiffHunk = github.String(hunk)
+		}
+		comments = append(comments, comment)
+	}
+	return comments
+}
@@ -0,0 +1,303 @@
+package bitbucket
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/stefrushxyz/nitpick/internal/diff"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/rest"
+)
+
+// DefaultHost is the host of Bitbucket Cloud
+const DefaultHost = "bitbucket.org"
+
+// defaultBaseURL is the REST API base URL of Bitbucket Cloud
+const defaultBaseURL = "https://api.bitbucket.org/2.0"
+
+// defaultPerPage is the page size used when Options.PageSize is unset; Bitbucket allows at most 100
+// for most lists and 50 for pull requests
+const defaultPerPage = 50
+
+// Client reads repositories, pull requests and inline comments from the Bitbucket Cloud REST API (2.0),
+// presenting them as the GitHub types the TUI and prompt generator work with
+type Client struct {
+	rest *rest.Client
+}
+
+// Options configures a Client. Token is an access token, or username:app_password for an app password,
+// and BaseURL defaults to https://api.bitbucket.org/2.0; Host is not used.
+type Options = rest.Options
+
+// New creates a Bitbucket Cloud client
+func New(opts Options) (*Client, error) {
+	baseURL := opts.BaseURL
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+
+	client, err := rest.New(rest.API{
+		Name:        "Bitbucket",
+		BaseURL:     baseURL,
+		PageSize:    defaultPerPage,
+		MaxPageSize: defaultPerPage,
+		Auth: func(req *http.Request) {
+			// App passwords are used with basic authentication, access tokens as bearer tokens
+			if username, password, ok := strings.Cut(opts.Token, ":"); ok {
+				req.SetBasicAuth(username, password)
+			} else {
+				req.Header.Set("Authorization", "Bearer "+opts.Token)
+			}
+		},
+		Error: apiError,
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{rest: client}, nil
+}
+
+// Timeout returns the timeout for the requests of a single fetch
+func (c *Client) Timeout() time.Duration {
+	return c.rest.Timeout()
+}
+
+// apiError describes an error response of the Bitbucket API
+func apiError(status int, body []byte) error {
+	var apiErr struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	message := rest.Message(body)
+	if json.Unmarshal(body, &apiErr) == nil && apiErr.Error.Message != "" {
+		message = apiErr.Error.Message
+	}
+	return fmt.Errorf("Bitbucket API error %d: %s", status, message)
+}
+
+// get fetches an API path or absolute URL and decodes the JSON response into out
+func (c *Client) get(ctx context.Context, path string, out any) error {
+	_, err := c.rest.Request(ctx, http.MethodGet, path, nil, nil, out)
+	return err
+}
+
+// page is a page of a paginated Bitbucket list
+type page[T any] struct {
+	Values []T    `json:"values"`
+	Next   string `json:"next"`
+}
+
+// getAll fetches the pages of a list within opts, following the next links
+func getAll[T any](ctx context.Context, c *Client, path string, query url.Values, opts ghclient.ListOptions) ([]T, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("pagelen", strconv.Itoa(c.rest.PageSize(opts)))
+	first := path + "?" + query.Encode()
+
+	return rest.List(ctx, opts, func(next string) (rest.Page[T], error) {
+		var p page[T]
+		if err := c.get(ctx, cmp.Or(next, first), &p); err != nil {
+			return rest.Page[T]{}, err
+		}
+		return rest.Page[T]{Items: p.Values, Next: p.Next}, nil
+	})
+}
+
+// repoPath returns the API path of a repository given its workspace and slug
+func repoPath(workspace, slug string) string {
+	return "/repositories/" + url.PathEscape(workspace) + "/" + url.PathEscape(slug)
+}
+
+// ListRepos lists the repositories of the user's workspaces, most recently updated first
+func (c *Client) ListRepos(ctx context.Context, opts ghclient.ListOptions) ([]*github.Repository, error) {
+	query := url.Values{"role": {"member"}, "sort": {"-updated_on"}}
+	repos, err := getAll[repository](ctx, c, "/repositories", query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*github.Repository, len(repos))
+	for i, r := range repos {
+		result[i] = r.toRepository()
+	}
+	return result, nil
+}
+
+// GetRepo fetches a single repository by workspace and slug
+func (c *Client) GetRepo(ctx context.Context, workspace, slug string) (*github.Repository, error) {
+	var r repository
+	if err := c.get(ctx, repoPath(workspace, slug), &r); err != nil {
+		return nil, err
+	}
+	return r.toRepository(), nil
+}
+
+// ListChanges lists the pull requests of a repository, highest number first
+func (c *Client) ListChanges(ctx context.Context, workspace, slug string, opts ghclient.PRListOptions) ([]*github.PullRequest, error) {
+	query := url.Values{}
+	switch opts.State {
+	case "closed":
+		query["state"] = []string{"MERGED", "DECLINED", "SUPERSEDED"}
+	case "all":
+		query["state"] = []string{"OPEN", "MERGED", "DECLINED", "SUPERSEDED"}
+	default:
+		query.Set("state", "OPEN")
+	}
+	if opts.Base != "" {
+		query.Set("q", fmt.Sprintf("destination.branch.name = %q", opts.Base))
+	}
+
+	pulls, err := getAll[pullRequest](ctx, c, repoPath(workspace, slug)+"/pullrequests", query, opts.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	prs := make([]*github.PullRequest, len(pulls))
+	for i, pr := range pulls {
+		prs[i] = pr.toPullRequest()
+	}
+	sort.Slice(prs, func(i, j int) bool {
+		return prs[i].GetNumber() > prs[j].GetNumber()
+	})
+	return prs, nil
+}
+
+// GetChange fetches a single pull request by number
+func (c *Client) GetChange(ctx context.Context, workspace, slug string, number int) (*github.PullRequest, error) {
+	var pr pullRequest
+	if err := c.get(ctx, fmt.Sprintf("%s/pullrequests/%d", repoPath(workspace, slug), number), &pr); err != nil {
+		return nil, err
+	}
+	return pr.toPullRequest(), nil
+}
+
+// listComments lists every comment of a pull request
+func (c *Client) listComments(ctx context.Context, workspace, slug string, number int) ([]comment, error) {
+	path := fmt.Sprintf("%s/pullrequests/%d/comments", repoPath(workspace, slug), number)
+	return getAll[comment](ctx, c, path, nil, ghclient.ListOptions{AllPages: true})
+}
+
+// listDiffs returns the diff of each file changed by a pull request, keyed by old and new path
+func (c *Client) listDiffs(ctx context.Context, workspace, slug string, number int) (map[string]string, error) {
+	body, err := c.rest.Raw(ctx, fmt.Sprintf("%s/pullrequests/%d/diff", repoPath(workspace, slug), number), nil)
+	if err != nil {
+		return nil, err
+	}
+	return diff.SplitFiles(string(body)), nil
+}
+
+// ListComments lists the inline comments of a pull request's unresolved threads, with their diff context,
+// most recently updated first. Comments are listed in full; opts only limits how many are kept.
+func (c *Client) ListComments(ctx context.Context, workspace, slug string, number int, opts ghclient.ListOptions) ([]*github.PullRequestComment, error) {
+	pr, err := c.GetChange(ctx, workspace, slug, number)
+	if err != nil {
+		return nil, err
+	}
+	all, err := c.listComments(ctx, workspace, slug, number)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := threadRoots(all)
+	var diffs map[string]string
+	var comments []*github.PullRequestComment
+	for _, cm := range all {
+		root := roots[cm.ID]
+		if cm.Deleted || root.Inline == nil || root.Resolution != nil {
+			continue
+		}
+		if diffs == nil {
+			// The diff is only needed, and fetched once, when there are inline comments
+			if diffs, err = c.listDiffs(ctx, workspace, slug, number); err != nil {
+				slog.WarnContext(ctx, "failed to fetch pull request diff", "repo", workspace+"/"+slug, "pr", number, "err", err)
+				diffs = map[string]string{}
+			}
+		}
+		comments = append(comments, cm.toComment(pr, root, diffs))
+	}
+	slog.DebugContext(ctx, "listed inline comments", "repo", workspace+"/"+slug, "pr", number, "count", len(comments))
+
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].GetUpdatedAt().After(comments[j].GetUpdatedAt().Time)
+	})
+	if opts.Limit > 0 && len(comments) > opts.Limit {
+		comments = comments[:opts.Limit]
+	}
+	return comments, nil
+}
+
+// ListThreads lists the inline comment threads of a pull request with their resolution state
+func (c *Client) ListThreads(ctx context.Context, workspace, slug string, number int) ([]ghclient.ReviewThread, error) {
+	all, err := c.listComments(ctx, workspace, slug, number)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := threadRoots(all)
+	index := make(map[int64]int)
+	var threads []ghclient.ReviewThread
+	for _, cm := range all {
+		root := roots[cm.ID]
+		if root.Inline == nil {
+			continue
+		}
+		i, ok := index[root.ID]
+		if !ok {
+			i = len(threads)
+			index[root.ID] = i
+			threads = append(threads, ghclient.ReviewThread{ID: strconv.FormatInt(root.ID, 10), IsResolved: root.Resolution != nil})
+		}
+		threads[i].CommentIDs = append(threads[i].CommentIDs, cm.ID)
+	}
+	return threads, nil
+}
+
+// Reply posts a reply in the thread of the given comment, as a reply to the thread's top-level comment
+func (c *Client) Reply(ctx context.Context, workspace, slug string, number int, commentID int64, body string) (*github.PullRequestComment, error) {
+	pr, err := c.GetChange(ctx, workspace, slug, number)
+	if err != nil {
+		return nil, err
+	}
+	all, err := c.listComments(ctx, workspace, slug, number)
+	if err != nil {
+		return nil, err
+	}
+	root, ok := threadRoots(all)[commentID]
+	if !ok {
+		return nil, fmt.Errorf("%w for comment %d", ghclient.ErrThreadNotFound, commentID)
+	}
+
+	payload := map[string]any{
+		"content": map[string]string{"raw": body},
+		"parent":  map[string]int64{"id": root.ID},
+	}
+	path := fmt.Sprintf("%s/pullrequests/%d/comments", repoPath(workspace, slug), number)
+	var created comment
+	if _, err := c.rest.Request(ctx, http.MethodPost, path, nil, payload, &created); err != nil {
+		return nil, err
+	}
+	return created.toComment(pr, root, nil), nil
+}
+
+// Resolve resolves or reopens the thread of the given top-level comment
+func (c *Client) Resolve(ctx context.Context, workspace, slug string, number int, threadID string, resolved bool) error {
+	method := http.MethodPost
+	if !resolved {
+		method = http.MethodDelete
+	}
+	path := fmt.Sprintf("%s/pullrequests/%d/comments/%s/resolve", repoPath(workspace, slug), number, url.PathEscape(threadID))
+	_, err := c.rest.Do(ctx, method, path, nil, nil)
+	return err
+}
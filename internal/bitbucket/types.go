@@ -0,0 +1,245 @@
+package bitbucket
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/stefrushxyz/nitpick/internal/diff"
+)
+
+// link is a hypermedia link of a Bitbucket resource
+type link struct {
+	Href string `json:"href"`
+}
+
+// repository is a Bitbucket repository
+type repository struct {
+	UUID        string     `json:"uuid"`
+	Slug        string     `json:"slug"`
+	FullName    string     `json:"full_name"`
+	Description string     `json:"description"`
+	IsPrivate   bool       `json:"is_private"`
+	Language    string     `json:"language"`
+	UpdatedOn   *time.Time `json:"updated_on"`
+	Parent      *struct{}  `json:"parent"`
+	Workspace   struct {
+		Slug string `json:"slug"`
+	} `json:"workspace"`
+	MainBranch *struct {
+		Name string `json:"name"`
+	} `json:"mainbranch"`
+	Links struct {
+		HTML link `json:"html"`
+	} `json:"links"`
+}
+
+// toRepository presents the repository as a GitHub repository owned by its workspace
+func (r repository) toRepository() *github.Repository {
+	repo := &github.Repository{
+		NodeID:      github.String(r.UUID),
+		Name:        github.String(r.Slug),
+		FullName:    github.String(r.Workspace.Slug + "/" + r.Slug),
+		Owner:       &github.User{Login: github.String(r.Workspace.Slug)},
+		Description: github.String(r.Description),
+		HTMLURL:     github.String(r.Links.HTML.Href),
+		Private:     github.Bool(r.IsPrivate),
+		Fork:        github.Bool(r.Parent != nil),
+		Language:    github.String(r.Language),
+		UpdatedAt:   timestamp(r.UpdatedOn),
+	}
+	if r.MainBranch != nil {
+		repo.DefaultBranch = github.String(r.MainBranch.Name)
+	}
+	return repo
+}
+
+// user is a Bitbucket account
+type user struct {
+	Nickname    string `json:"nickname"`
+	DisplayName string `json:"display_name"`
+	Type        string `json:"type"`
+	Links       struct {
+		HTML link `json:"html"`
+	} `json:"links"`
+}
+
+// toUser presents the account as a GitHub user; app users are presented as bots
+func (u user) toUser() *github.User {
+	login := u.Nickname
+	if login == "" {
+		login = u.DisplayName
+	}
+	kind := "User"
+	if u.Type == "app_user" {
+		kind = "Bot"
+	}
+	return &github.User{
+		Login:   github.String(login),
+		Name:    github.String(u.DisplayName),
+		HTMLURL: github.String(u.Links.HTML.Href),
+		Type:    github.String(kind),
+	}
+}
+
+// endpoint is the source or destination of a pull request
+type endpoint struct {
+	Branch struct {
+		Name string `json:"name"`
+	} `json:"branch"`
+	Commit *struct {
+		Hash string `json:"hash"`
+	} `json:"commit"`
+}
+
+// pullRequest is a Bitbucket pull request
+type pullRequest struct {
+	ID          int        `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	State       string     `json:"state"`
+	Draft       bool       `json:"draft"`
+	Author      user       `json:"author"`
+	CreatedOn   *time.Time `json:"created_on"`
+	UpdatedOn   *time.Time `json:"updated_on"`
+	Source      endpoint   `json:"source"`
+	Destination endpoint   `json:"destination"`
+	Links       struct {
+		HTML link `json:"html"`
+	} `json:"links"`
+}
+
+// toPullRequest presents the pull request as a GitHub pull request
+func (pr pullRequest) toPullRequest() *github.PullRequest {
+	state := "open"
+	if pr.State != "OPEN" {
+		state = "closed"
+	}
+	head := &github.PullRequestBranch{Ref: github.String(pr.Source.Branch.Name)}
+	if pr.Source.Commit != nil {
+		head.SHA = github.String(pr.Source.Commit.Hash)
+	}
+	return &github.PullRequest{
+		Number:    github.Int(pr.ID),
+		Title:     github.String(pr.Title),
+		Body:      github.String(pr.Description),
+		State:     github.String(state),
+		Draft:     github.Bool(pr.Draft),
+		Merged:    github.Bool(pr.State == "MERGED"),
+		HTMLURL:   github.String(pr.Links.HTML.Href),
+		User:      pr.Author.toUser(),
+		CreatedAt: timestamp(pr.CreatedOn),
+		UpdatedAt: timestamp(pr.UpdatedOn),
+		Head:      head,
+		Base:      &github.PullRequestBranch{Ref: github.String(pr.Destination.Branch.Name)},
+	}
+}
+
+// comment is a comment on a Bitbucket pull request
+type comment struct {
+	ID      int64 `json:"id"`
+	Content struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+	User      user       `json:"user"`
+	Deleted   bool       `json:"deleted"`
+	CreatedOn *time.Time `json:"created_on"`
+	UpdatedOn *time.Time `json:"updated_on"`
+	Parent    *struct {
+		ID int64 `json:"id"`
+	} `json:"parent"`
+	Inline *struct {
+		Path      string `json:"path"`
+		From      *int   `json:"from"`       // Line in the old file
+		To        *int   `json:"to"`         // Line in the new file
+		StartFrom *int   `json:"start_from"` // First line of a multi-line comment in the old file
+		StartTo   *int   `json:"start_to"`   // First line of a multi-line comment in the new file
+	} `json:"inline"`
+	Resolution *struct{} `json:"resolution"` // Set once the comment's thread is resolved
+	Links      struct {
+		HTML link `json:"html"`
+	} `json:"links"`
+}
+
+// threadRoots maps the ID of every comment to the top-level comment of its thread
+func threadRoots(comments []comment) map[int64]comment {
+	byID := make(map[int64]comment, len(comments))
+	for _, cm := range comments {
+		byID[cm.ID] = cm
+	}
+
+	roots := make(map[int64]comment, len(comments))
+	for _, cm := range comments {
+		root := cm
+		// Bound the walk in case of a malformed parent chain
+		for i := 0; root.Parent != nil && i < len(comments); i++ {
+			parent, ok := byID[root.Parent.ID]
+			if !ok {
+				break
+			}
+			root = parent
+		}
+		roots[cm.ID] = root
+	}
+	return roots
+}
+
+// toComment presents the comment as a review comment located where the top-level comment of its thread is.
+// Replies point at the top-level comment. diffs holds the diff of each changed file.
+func (cm comment) toComment(pr *github.PullRequest, root comment, diffs map[string]string) *github.PullRequestComment {
+	result := &github.PullRequestComment{
+		ID:             github.Int64(cm.ID),
+		Body:           github.String(cm.Content.Raw),
+		User:           cm.User.toUser(),
+		CreatedAt:      timestamp(cm.CreatedOn),
+		UpdatedAt:      timestamp(cm.UpdatedOn),
+		HTMLURL:        github.String(cm.Links.HTML.Href),
+		PullRequestURL: github.String(pr.GetHTMLURL()),
+		CommitID:       github.String(pr.GetHead().GetSHA()),
+	}
+	if result.GetHTMLURL() == "" {
+		result.HTMLURL = github.String(pr.GetHTMLURL() + "#comment-" + strconv.FormatInt(cm.ID, 10))
+	}
+	if cm.ID != root.ID {
+		result.InReplyTo = github.Int64(root.ID)
+	}
+
+	inline := root.Inline
+	if inline == nil {
+		return result
+	}
+	line, start, side := deref(inline.To), deref(inline.StartTo), "RIGHT"
+	if line == 0 {
+		// A comment on a removed line
+		line, start, side = deref(inline.From), deref(inline.StartFrom), "LEFT"
+	}
+
+	result.Path = github.String(inline.Path)
+	result.Line = github.Int(line)
+	result.OriginalLine = github.Int(line)
+	result.Side = github.String(side)
+	if start != 0 && start != line {
+		result.StartLine = github.Int(start)
+		result.OriginalStartLine = github.Int(start)
+	}
+	if hunk := diff.Hunk(diffs[inline.Path], line, side == "LEFT"); hunk != "" {
+		result.DiffHunk = github.String(hunk)
+	}
+	return result
+}
+
+// deref returns the value of an optional line number, or 0
+func deref(n *int) int {
+	if n == nil {
+		return 0
+	}
+	return *n
+}
+
+// timestamp converts an optional time to a GitHub timestamp
+func timestamp(t *time.Time) *github.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return &github.Timestamp{Time: *t}
+}
@@ -0,0 +1,54 @@
+package blame
+
+import (
+	"fmt"
+	"time"
+)
+
+// Range is a run of lines last changed by the same commit
+type Range struct {
+	Start   int       // First line, 1-based
+	End     int       // Last line
+	Commit  string    // SHA of the commit
+	Author  string    // Name of the commit's author
+	Login   string    // Account of the author on the provider, when known
+	Date    time.Time // When the commit was authored
+	Summary string    // First line of the commit message
+}
+
+// Lines returns the line or line range of the range, e.g. L3 or L3-5
+func (r Range) Lines() string {
+	if r.Start == r.End {
+		return fmt.Sprintf("L%d", r.Start)
+	}
+	return fmt.Sprintf("L%d-%d", r.Start, r.End)
+}
+
+// ShortCommit returns the abbreviated SHA of the range's commit
+func (r Range) ShortCommit() string {
+	if len(r.Commit) > 7 {
+		return r.Commit[:7]
+	}
+	return r.Commit
+}
+
+// Who returns the author's account when known, and otherwise their name
+func (r Range) Who() string {
+	if r.Login != "" {
+		return "@" + r.Login
+	}
+	return r.Author
+}
+
+// Clip returns the parts of ranges covering lines start to end
+func Clip(ranges []Range, start, end int) []Range {
+	var clipped []Range
+	for _, r := range ranges {
+		if r.End < start || r.Start > end {
+			continue
+		}
+		r.Start, r.End = max(r.Start, start), min(r.End, end)
+		clipped = append(clipped, r)
+	}
+	return clipped
+}
@@ -0,0 +1,183 @@
+package bookmarks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/stefrushxyz/nitpick/internal/config"
+)
+
+// Kinds of bookmarked items
+const (
+	KindRepo    = "repo"
+	KindPR      = "pr"
+	KindComment = "comment"
+)
+
+// Bookmark is a saved repository, pull request or review comment, identified by its URL
+type Bookmark struct {
+	Kind      string    `json:"kind"`
+	Repo      string    `json:"repo"` // owner/name
+	PR        int       `json:"pr,omitempty"`
+	CommentID int64     `json:"comment_id,omitempty"`
+	Title     string    `json:"title"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Store is the set of bookmarks saved in a JSON file, most recent first
+type Store struct {
+	path      string
+	Bookmarks []Bookmark
+}
+
+// file is the on-disk and export format of the bookmarks
+type file struct {
+	Bookmarks []Bookmark `json:"bookmarks"`
+}
+
+// DefaultPath returns the path of the bookmarks file in the data directory
+func DefaultPath() (string, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate data directory: %w", err)
+	}
+	return filepath.Join(dir, "bookmarks.json"), nil
+}
+
+// Load reads the bookmarks stored at path, returning an empty store if the file does not exist
+func Load(path string) (*Store, error) {
+	store := &Store{path: path}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bookmarks: %w", err)
+	}
+	defer f.Close()
+
+	store.Bookmarks, err = Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bookmarks %s: %w", path, err)
+	}
+	return store, nil
+}
+
+// LoadDefault reads the bookmarks from the default path
+func LoadDefault() (*Store, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return Load(path)
+}
+
+// Save writes the bookmarks to the store's file
+func (s *Store) Save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	// Write to a temporary file first so a failed write never loses the existing bookmarks
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), "bookmarks-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write bookmarks: %w", err)
+	}
+	if err := Encode(tmp, s.Bookmarks); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write bookmarks: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write bookmarks: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write bookmarks: %w", err)
+	}
+	return nil
+}
+
+// Has reports whether the item with the given URL is bookmarked
+func (s *Store) Has(url string) bool {
+	return s.index(url) >= 0
+}
+
+// Add bookmarks b, reporting false if its URL is already bookmarked
+func (s *Store) Add(b Bookmark) bool {
+	if s.Has(b.URL) {
+		return false
+	}
+	if b.CreatedAt.IsZero() {
+		b.CreatedAt = time.Now()
+	}
+	s.Bookmarks = append([]Bookmark{b}, s.Bookmarks...)
+	return true
+}
+
+// Remove deletes the bookmark with the given URL, reporting false if there is none
+func (s *Store) Remove(url string) bool {
+	i := s.index(url)
+	if i < 0 {
+		return false
+	}
+	s.Bookmarks = slices.Delete(s.Bookmarks, i, i+1)
+	return true
+}
+
+// Toggle adds b, or removes it if already bookmarked, and reports whether it is now bookmarked
+func (s *Store) Toggle(b Bookmark) bool {
+	if s.Remove(b.URL) {
+		return false
+	}
+	return s.Add(b)
+}
+
+// Merge adds the given bookmarks that are not stored yet, keeping most recent first,
+// and returns how many were added
+func (s *Store) Merge(bookmarks []Bookmark) int {
+	added := 0
+	for _, b := range bookmarks {
+		if b.URL != "" && !s.Has(b.URL) {
+			s.Bookmarks = append(s.Bookmarks, b)
+			added++
+		}
+	}
+	slices.SortStableFunc(s.Bookmarks, func(a, b Bookmark) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return added
+}
+
+// index returns the position of the bookmark with the given URL, or -1
+func (s *Store) index(url string) int {
+	return slices.IndexFunc(s.Bookmarks, func(b Bookmark) bool { return b.URL == url })
+}
+
+// Encode writes bookmarks in the export format
+func Encode(w io.Writer, bookmarks []Bookmark) error {
+	if bookmarks == nil {
+		bookmarks = []Bookmark{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(file{Bookmarks: bookmarks})
+}
+
+// Decode reads bookmarks in the export format
+func Decode(r io.Reader) ([]Bookmark, error) {
+	var f file
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, err
+	}
+	return f.Bookmarks, nil
+}
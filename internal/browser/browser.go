@@ -0,0 +1,39 @@
+package browser
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// Open opens the given URL in the default web browser.
+// The BROWSER environment variable, when set, overrides the platform default.
+func Open(url string) error {
+	var cmd *exec.Cmd
+
+	if browser := os.Getenv("BROWSER"); browser != "" {
+		cmd = exec.Command(browser, url)
+	} else {
+		switch runtime.GOOS {
+		case "darwin": // macOS
+			cmd = exec.Command("open", url)
+		case "linux", "freebsd", "openbsd", "netbsd":
+			if _, err := exec.LookPath("xdg-open"); err != nil {
+				return fmt.Errorf("no browser launcher found (xdg-open required, or set BROWSER)")
+			}
+			cmd = exec.Command("xdg-open", url)
+		case "windows":
+			cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+		default:
+			return fmt.Errorf("unsupported operating system: %s", runtime.GOOS)
+		}
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open browser: %w", err)
+	}
+
+	// Don't wait for the browser; release the process so it outlives nitpick
+	return cmd.Process.Release()
+}
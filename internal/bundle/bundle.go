@@ -0,0 +1,232 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/stefrushxyz/nitpick/internal/bookmarks"
+	"github.com/stefrushxyz/nitpick/internal/config"
+)
+
+// Names of the entries of a bundle archive
+const (
+	configEntry    = "config.yml"
+	bookmarksEntry = "bookmarks.json"
+	templatesDir   = "templates/"
+)
+
+// maxEntrySize bounds the size of a single archive entry read on import
+const maxEntrySize = 10 << 20
+
+// Contents is the portable part of a nitpick setup: the configuration file (which holds the
+// aliases), the user prompt templates and the bookmarks
+type Contents struct {
+	Config    []byte            // Content of config.yml; nil if there is no configuration file
+	Templates map[string][]byte // User prompt templates by file name
+	Bookmarks []bookmarks.Bookmark
+}
+
+// Summary reports what Install changed
+type Summary struct {
+	Config    bool     // Whether the configuration file was written
+	Templates []string // Templates written
+	Skipped   []string // Existing files left in place
+	Bookmarks int      // Bookmarks added
+}
+
+// Collect gathers the contents to export from the configuration's files. Tokens and other secrets are
+// removed from the configuration file unless includeSecrets is set.
+func Collect(cfg *config.Config, includeSecrets bool) (*Contents, error) {
+	contents := &Contents{Templates: make(map[string][]byte)}
+
+	if cfg.File() != "" {
+		content, err := os.ReadFile(cfg.File())
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		default:
+			if !includeSecrets {
+				if content, err = config.StripSecrets(content); err != nil {
+					return nil, fmt.Errorf("failed to parse config file %s: %w", cfg.File(), err)
+				}
+			}
+			contents.Config = content
+		}
+	}
+
+	if cfg.TemplatesDir != "" {
+		entries, err := os.ReadDir(cfg.TemplatesDir)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to read templates directory: %w", err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".tmpl") {
+				continue
+			}
+			content, err := os.ReadFile(filepath.Join(cfg.TemplatesDir, entry.Name()))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read template: %w", err)
+			}
+			contents.Templates[entry.Name()] = content
+		}
+	}
+
+	store, err := bookmarks.LoadDefault()
+	if err != nil {
+		return nil, err
+	}
+	contents.Bookmarks = store.Bookmarks
+
+	return contents, nil
+}
+
+// Write writes the contents as a gzipped tar archive
+func (c *Contents) Write(w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+
+	add := func(name string, content []byte, mode int64) error {
+		header := &tar.Header{Name: name, Mode: mode, Size: int64(len(content)), ModTime: now}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err := tw.Write(content)
+		return err
+	}
+
+	if c.Config != nil {
+		// The configuration may hold tokens, so keep it private
+		if err := add(configEntry, c.Config, 0o600); err != nil {
+			return err
+		}
+	}
+	for name, content := range c.Templates {
+		if err := add(templatesDir+name, content, 0o644); err != nil {
+			return err
+		}
+	}
+	var buf bytes.Buffer
+	if err := bookmarks.Encode(&buf, c.Bookmarks); err != nil {
+		return err
+	}
+	if err := add(bookmarksEntry, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// Read reads contents from an archive written by Write
+func Read(r io.Reader) (*Contents, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a nitpick config archive: %w", err)
+	}
+	defer gz.Close()
+
+	contents := &Contents{Templates: make(map[string][]byte)}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return contents, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if header.Size > maxEntrySize {
+			return nil, fmt.Errorf("archive entry %s is too large", header.Name)
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		switch name := header.Name; {
+		case name == configEntry:
+			contents.Config = content
+		case name == bookmarksEntry:
+			if contents.Bookmarks, err = bookmarks.Decode(bytes.NewReader(content)); err != nil {
+				return nil, fmt.Errorf("failed to parse bookmarks: %w", err)
+			}
+		case strings.HasPrefix(name, templatesDir):
+			// Only plain file names, so entries cannot escape the templates directory
+			base := strings.TrimPrefix(name, templatesDir)
+			if base != path.Base(base) || !strings.HasSuffix(base, ".tmpl") {
+				return nil, fmt.Errorf("invalid template entry %s", name)
+			}
+			contents.Templates[base] = content
+		}
+	}
+}
+
+// Install writes the contents into place: the configuration file at configPath, templates into
+// templatesDir and bookmarks merged into the bookmarks store. Existing configuration and template files
+// are kept unless overwrite is set.
+func (c *Contents) Install(configPath, templatesDir string, overwrite bool) (*Summary, error) {
+	summary := &Summary{}
+
+	write := func(path string, content []byte, mode os.FileMode) (bool, error) {
+		if !overwrite {
+			if _, err := os.Stat(path); err == nil {
+				summary.Skipped = append(summary.Skipped, path)
+				return false, nil
+			}
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return false, fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(path, content, mode); err != nil {
+			return false, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		return true, nil
+	}
+
+	if c.Config != nil {
+		written, err := write(configPath, c.Config, 0o600)
+		if err != nil {
+			return nil, err
+		}
+		summary.Config = written
+	}
+
+	for name, content := range c.Templates {
+		written, err := write(filepath.Join(templatesDir, name), content, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		if written {
+			summary.Templates = append(summary.Templates, name)
+		}
+	}
+
+	if len(c.Bookmarks) > 0 {
+		store, err := bookmarks.LoadDefault()
+		if err != nil {
+			return nil, err
+		}
+		summary.Bookmarks = store.Merge(c.Bookmarks)
+		if err := store.Save(); err != nil {
+			return nil, err
+		}
+	}
+
+	return summary, nil
+}
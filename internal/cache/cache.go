@@ -0,0 +1,131 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Cache stores API responses as JSON files in a directory, each valid for a time-to-live chosen on read
+type Cache struct {
+	dir string
+}
+
+// entry is the on-disk representation of a cached value
+type entry struct {
+	Key      string          `json:"key"`
+	StoredAt time.Time       `json:"stored_at"`
+	Value    json.RawMessage `json:"value"`
+}
+
+// New creates a cache storing its entries in dir
+func New(dir string) *Cache {
+	return &Cache{dir: dir}
+}
+
+// Dir returns the directory holding the cache entries
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Get decodes the value stored under key into v. It reports false if there is no entry,
+// the entry is older than ttl, or ttl is not positive.
+func (c *Cache) Get(key string, ttl time.Duration, v any) bool {
+	if c == nil || ttl <= 0 {
+		return false
+	}
+
+	content, err := os.ReadFile(c.path(key))
+	if err != nil {
+		slog.Debug("cache miss", "key", key)
+		return false
+	}
+
+	var e entry
+	if err := json.Unmarshal(content, &e); err != nil || e.Key != key {
+		return false
+	}
+	if age := time.Since(e.StoredAt); age > ttl {
+		slog.Debug("cache expired", "key", key, "age", age)
+		return false
+	}
+
+	if err := json.Unmarshal(e.Value, v); err != nil {
+		return false
+	}
+	slog.Debug("cache hit", "key", key)
+	return true
+}
+
+// Put stores v under key
+func (c *Cache) Put(key string, v any) error {
+	if c == nil {
+		return nil
+	}
+
+	value, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(entry{Key: key, StoredAt: time.Now(), Value: value})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	// Write to a temporary file first so concurrent readers never see a partial entry
+	tmp, err := os.CreateTemp(c.dir, "entry-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the entry for key, if any
+func (c *Cache) Delete(key string) error {
+	if c == nil {
+		return nil
+	}
+	err := os.Remove(c.path(key))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete cache entry: %w", err)
+	}
+	return nil
+}
+
+// Clear removes every cache entry
+func (c *Cache) Clear() error {
+	err := os.RemoveAll(c.dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	return nil
+}
+
+// path returns the file holding the entry for key
+func (c *Cache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/provider"
+)
+
+// boardRecord is the headless representation of a workboard card
+type boardRecord struct {
+	Lane string `json:"lane"`
+	Repo string `json:"repo"`
+	prRecord
+}
+
+// newBoardCommand creates the board command
+func newBoardCommand() *cobra.Command {
+	var output outputFlags
+	var list listFlags
+
+	cmd := &cobra.Command{
+		Use:   "board",
+		Short: "List the pull requests needing your attention across repositories",
+		Long: `List the open pull requests needing your attention across repositories, most urgent first: yours with
+changes requested, those awaiting your review, then those assigned to you. Needs GitHub's search API.`,
+		Args: noArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := output.resolve()
+			if err != nil {
+				return err
+			}
+
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			listOpts, err := list.options(ghclient.ListOptionsFromLimits(cfg.Limits.PRs))
+			if err != nil {
+				return err
+			}
+			client, err := newProviderFromConfig(cfg)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := commandContext(cmd, client)
+			defer cancel()
+
+			cards, err := provider.FindBoard(ctx, client, listOpts)
+			if err != nil {
+				return err
+			}
+
+			records := make([]boardRecord, len(cards))
+			for i, card := range cards {
+				records[i] = boardRecord{
+					Lane:     card.Lane.Name,
+					Repo:     card.PR.GetBase().GetRepo().GetFullName(),
+					prRecord: newPRRecord(card.PR),
+				}
+			}
+
+			header := []string{"lane", "pull_request", "title", "author", "updated", "url"}
+			return writeList(cmd.OutOrStdout(), format, records, header, func(r boardRecord) []string {
+				return []string{r.Lane, fmt.Sprintf("%s#%d", r.Repo, r.Number), r.Title, r.Author, r.UpdatedAt, r.URL}
+			})
+		},
+	}
+
+	output.register(cmd)
+	list.register(cmd)
+
+	return cmd
+}
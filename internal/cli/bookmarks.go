@@ -0,0 +1,234 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stefrushxyz/nitpick/internal/bookmarks"
+	"github.com/stefrushxyz/nitpick/internal/ui"
+)
+
+// newBookmarksCommand creates the bookmarks command, which lists bookmarks when run without a subcommand
+func newBookmarksCommand() *cobra.Command {
+	var output outputFlags
+
+	cmd := &cobra.Command{
+		Use:   "bookmarks",
+		Short: "List and manage bookmarked repositories, pull requests and comments",
+		Long: `List bookmarked repositories, pull requests and review comments, most recent first. Bookmarks are
+added with m in the TUI or with bookmarks add, and stored in bookmarks.json in the data directory
+(NITPICK_DATA_DIR, $XDG_DATA_HOME/nitpick or ~/.local/share/nitpick). Use export and import to move them between machines.`,
+		Args: noArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := output.resolve()
+			if err != nil {
+				return err
+			}
+
+			store, err := bookmarks.LoadDefault()
+			if err != nil {
+				return err
+			}
+
+			header := []string{"kind", "repo", "pr", "comment_id", "title", "url"}
+			return writeList(cmd.OutOrStdout(), format, store.Bookmarks, header, func(b bookmarks.Bookmark) []string {
+				return []string{
+					b.Kind, b.Repo, strconv.Itoa(b.PR), strconv.FormatInt(b.CommentID, 10), b.Title, b.URL,
+				}
+			})
+		},
+	}
+
+	output.register(cmd)
+	cmd.AddCommand(
+		newBookmarksAddCommand(),
+		newBookmarksRemoveCommand(),
+		newBookmarksExportCommand(),
+		newBookmarksImportCommand(),
+	)
+
+	return cmd
+}
+
+// newBookmarksAddCommand creates the bookmarks add command
+func newBookmarksAddCommand() *cobra.Command {
+	var commentID int64
+
+	cmd := &cobra.Command{
+		Use:   "add (owner/repo | owner/repo#N | url) [--comment ID]",
+		Short: "Bookmark a repository, pull request or comment",
+		Args:  exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, err := parseTargetRef(args[0], commentID)
+			if err != nil {
+				return err
+			}
+
+			client, err := newClient(cmd)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := commandContext(cmd, client)
+			defer cancel()
+
+			bookmark := bookmarks.Bookmark{Repo: ref.repoRef.String(), PR: ref.Number, CommentID: ref.CommentID}
+			switch {
+			case ref.CommentID != 0:
+				comment, err := client.GetComment(ctx, ref.Owner, ref.Name, ref.CommentID)
+				if err != nil {
+					return err
+				}
+				bookmark.Kind = bookmarks.KindComment
+				bookmark.Title = ui.CommentItem{Comment: comment}.Title()
+				bookmark.URL = comment.GetHTMLURL()
+			case ref.Number != 0:
+				pr, err := client.GetChange(ctx, ref.Owner, ref.Name, ref.Number)
+				if err != nil {
+					return err
+				}
+				bookmark.Kind = bookmarks.KindPR
+				bookmark.Title = pr.GetTitle()
+				bookmark.URL = pr.GetHTMLURL()
+			default:
+				repo, err := client.GetRepo(ctx, ref.Owner, ref.Name)
+				if err != nil {
+					return err
+				}
+				bookmark.Kind = bookmarks.KindRepo
+				bookmark.Title = repo.GetDescription()
+				bookmark.URL = repo.GetHTMLURL()
+			}
+
+			store, err := bookmarks.LoadDefault()
+			if err != nil {
+				return err
+			}
+			if !store.Add(bookmark) {
+				fmt.Fprintf(cmd.ErrOrStderr(), "%s is already bookmarked\n", bookmark.URL)
+				return nil
+			}
+			if err := store.Save(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "Bookmarked %s\n", bookmark.URL)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&commentID, "comment", 0, "ID of a review comment to bookmark")
+
+	return cmd
+}
+
+// newBookmarksRemoveCommand creates the bookmarks remove command
+func newBookmarksRemoveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove url",
+		Short: "Remove a bookmark",
+		Args:  exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := bookmarks.LoadDefault()
+			if err != nil {
+				return err
+			}
+			if !store.Remove(args[0]) {
+				return fmt.Errorf("%s is not bookmarked", args[0])
+			}
+			if err := store.Save(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "Removed bookmark %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+// newBookmarksExportCommand creates the bookmarks export command
+func newBookmarksExportCommand() *cobra.Command {
+	var outputPath string
+
+	cmd := &cobra.Command{
+		Use:   "export [-o file]",
+		Short: "Export bookmarks as JSON",
+		Args:  noArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			store, err := bookmarks.LoadDefault()
+			if err != nil {
+				return err
+			}
+
+			if outputPath == "" || outputPath == "-" {
+				return bookmarks.Encode(cmd.OutOrStdout(), store.Bookmarks)
+			}
+
+			f, err := os.Create(outputPath)
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %w", outputPath, err)
+			}
+			if err := bookmarks.Encode(f, store.Bookmarks); err != nil {
+				f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d bookmarks to %s\n", len(store.Bookmarks), outputPath)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", `file to write ("-" or omitted for stdout)`)
+
+	return cmd
+}
+
+// newBookmarksImportCommand creates the bookmarks import command
+func newBookmarksImportCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import (file | -)",
+		Short: "Import bookmarks exported on another machine",
+		Long:  "Merge bookmarks from a file written by bookmarks export, or from stdin with -. Bookmarks already present are kept.",
+		Args:  exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var r io.Reader = cmd.InOrStdin()
+			if args[0] != "-" {
+				f, err := os.Open(args[0])
+				if err != nil {
+					return fmt.Errorf("failed to open %s: %w", args[0], err)
+				}
+				defer f.Close()
+				r = f
+			}
+
+			imported, err := bookmarks.Decode(r)
+			if err != nil {
+				return fmt.Errorf("failed to parse bookmarks: %w", err)
+			}
+			for i := range imported {
+				if imported[i].CreatedAt.IsZero() {
+					imported[i].CreatedAt = time.Now()
+				}
+			}
+
+			store, err := bookmarks.LoadDefault()
+			if err != nil {
+				return err
+			}
+			added := store.Merge(imported)
+			if err := store.Save(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "Imported %d of %d bookmarks\n", added, len(imported))
+			return nil
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stefrushxyz/nitpick/internal/cache"
+)
+
+// newCacheCommand creates the cache command
+func newCacheCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the API response cache",
+		Long: `Manage the cache of API responses used by the TUI. Entries are reused for the per-kind
+cache_ttl configured in config.yml (repos 1h, prs 5m, comments 2m by default) and stored under cache_dir.`,
+		Args: noArgs,
+	}
+
+	cmd.AddCommand(newCacheClearCommand())
+
+	return cmd
+}
+
+// newCacheClearCommand creates the cache clear command
+func newCacheClearCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "clear",
+		Short: "Remove all cached API responses",
+		Args:  noArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			dir := cfg.APICacheDir()
+			if dir == "" {
+				return errors.New("failed to locate cache directory; set cache_dir or NITPICK_CACHE_DIR")
+			}
+			if err := cache.New(dir).Clear(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "Cleared %s\n", dir)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/spf13/cobra"
+	"github.com/stefrushxyz/nitpick/internal/config"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/provider"
+	"github.com/stefrushxyz/nitpick/internal/ui"
+)
+
+// commentRecord is the headless representation of a review comment
+type commentRecord struct {
+	ID                int64  `json:"id"`
+	InReplyTo         int64  `json:"in_reply_to,omitempty"`
+	Reviewer          string `json:"reviewer"`
+	Path              string `json:"path,omitempty"`
+	Line              int    `json:"line,omitempty"`
+	StartLine         int    `json:"start_line,omitempty"`
+	OriginalLine      int    `json:"original_line,omitempty"`
+	OriginalStartLine int    `json:"original_start_line,omitempty"`
+	Summary           string `json:"summary"`
+	Body              string `json:"body"`
+	DiffHunk          string `json:"diff_hunk,omitempty"`
+	CreatedAt         string `json:"created_at,omitempty"`
+	UpdatedAt         string `json:"updated_at,omitempty"`
+	Resolved          bool   `json:"resolved"`
+	Outdated          bool   `json:"outdated"`
+	URL               string `json:"url"`
+}
+
+// newCommentRecord converts a GitHub review comment into a commentRecord
+func newCommentRecord(comment *github.PullRequestComment) commentRecord {
+	record := commentRecord{
+		ID:                comment.GetID(),
+		InReplyTo:         comment.GetInReplyTo(),
+		Reviewer:          comment.GetUser().GetLogin(),
+		Path:              comment.GetPath(),
+		Line:              comment.GetLine(),
+		StartLine:         comment.GetStartLine(),
+		OriginalLine:      comment.GetOriginalLine(),
+		OriginalStartLine: comment.GetOriginalStartLine(),
+		Summary:           ui.CommentItem{Comment: comment}.Title(),
+		Body:              comment.GetBody(),
+		DiffHunk:          comment.GetDiffHunk(),
+		URL:               comment.GetHTMLURL(),
+	}
+	if comment.CreatedAt != nil {
+		record.CreatedAt = comment.CreatedAt.Format(time.RFC3339)
+	}
+	if comment.UpdatedAt != nil {
+		record.UpdatedAt = comment.UpdatedAt.Format(time.RFC3339)
+	}
+	return record
+}
+
+// setThread records the resolution state of the comment's thread, looked up in threads by comment ID
+func (r *commentRecord) setThread(threads map[int64]ghclient.ReviewThread) {
+	thread := threads[r.ID]
+	r.Resolved = thread.IsResolved
+	r.Outdated = thread.IsOutdated
+}
+
+// threadsByComment indexes comment threads by the IDs of their comments
+func threadsByComment(threads []ghclient.ReviewThread) map[int64]ghclient.ReviewThread {
+	byComment := make(map[int64]ghclient.ReviewThread)
+	for _, thread := range threads {
+		for _, id := range thread.CommentIDs {
+			byComment[id] = thread
+		}
+	}
+	return byComment
+}
+
+// topLevelComments returns the comments that start a thread, dropping replies
+func topLevelComments(comments []*github.PullRequestComment) []*github.PullRequestComment {
+	var topLevel []*github.PullRequestComment
+	for _, comment := range comments {
+		if comment.GetInReplyTo() == 0 {
+			topLevel = append(topLevel, comment)
+		}
+	}
+	return topLevel
+}
+
+// unresolvedComments returns the top-level comments whose threads are not resolved. Comments outside the
+// threads, such as those of a provider without resolution state, count as unresolved.
+func unresolvedComments(comments []*github.PullRequestComment, threads []ghclient.ReviewThread) []*github.PullRequestComment {
+	byComment := threadsByComment(threads)
+
+	var unresolved []*github.PullRequestComment
+	for _, comment := range topLevelComments(comments) {
+		if !byComment[comment.GetID()].IsResolved {
+			unresolved = append(unresolved, comment)
+		}
+	}
+	return unresolved
+}
+
+// listUnresolvedComments fetches the top-level comments of a pull request's unresolved threads, dropping
+// those by authors the repository's filters ignore
+func listUnresolvedComments(ctx context.Context, client provider.Provider, filters config.Filters, owner, name string, number int) ([]*github.PullRequestComment, error) {
+	comments, err := client.ListComments(ctx, owner, name, number, allPages)
+	if err != nil {
+		return nil, err
+	}
+	threads, err := client.ListThreads(ctx, owner, name, number)
+	if err != nil {
+		return nil, err
+	}
+	return unresolvedComments(withoutIgnoredAuthors(filters, comments), threads), nil
+}
+
+// withoutIgnoredAuthors drops the comments whose authors the repository's filters ignore
+func withoutIgnoredAuthors(filters config.Filters, comments []*github.PullRequestComment) []*github.PullRequestComment {
+	var kept []*github.PullRequestComment
+	for _, comment := range comments {
+		user := comment.GetUser()
+		if !filters.IgnoresAuthor(user.GetLogin(), user.GetType() == "Bot") {
+			kept = append(kept, comment)
+		}
+	}
+	return kept
+}
+
+// newCommentsCommand creates the comments command
+func newCommentsCommand() *cobra.Command {
+	var output outputFlags
+	var list listFlags
+	var showReplies bool
+
+	cmd := &cobra.Command{
+		Use:   "comments owner/repo#N",
+		Short: "List review comments for a pull request",
+		Long: `List review comments for a pull request, most recently updated first. Replies are omitted unless --replies is set.
+Comments by authors ignored by the repository's filters in config.yml or .nitpick.toml are omitted too.
+With --json, each comment tells whether its thread is resolved and whether it is outdated.`,
+		Args: exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, err := parsePRRef(args[0])
+			if err != nil {
+				return err
+			}
+			format, err := output.resolve()
+			if err != nil {
+				return err
+			}
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			listOpts, err := list.options(ghclient.ListOptionsFromLimits(cfg.Limits.Comments))
+			if err != nil {
+				return err
+			}
+			client, err := newProviderFromConfig(cfg)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := commandContext(cmd, client)
+			defer cancel()
+
+			comments, err := client.ListComments(ctx, ref.Owner, ref.Name, ref.Number, listOpts)
+			if err != nil {
+				return err
+			}
+
+			threads, err := client.ListThreads(ctx, ref.Owner, ref.Name, ref.Number)
+			if err != nil {
+				return err
+			}
+
+			comments = withoutIgnoredAuthors(cfg.RepoFilters(ref.Owner, ref.Name), comments)
+			byComment := threadsByComment(threads)
+
+			records := make([]commentRecord, 0, len(comments))
+			for _, comment := range comments {
+				if showReplies || comment.GetInReplyTo() == 0 {
+					record := newCommentRecord(comment)
+					record.setThread(byComment)
+					records = append(records, record)
+				}
+			}
+
+			header := []string{"id", "reviewer", "path", "line", "summary", "url"}
+			return writeList(cmd.OutOrStdout(), format, records, header, func(r commentRecord) []string {
+				return []string{
+					strconv.FormatInt(r.ID, 10), r.Reviewer, r.Path, strconv.Itoa(r.Line), r.Summary, r.URL,
+				}
+			})
+		},
+	}
+
+	output.register(cmd)
+	list.register(cmd)
+	cmd.Flags().BoolVar(&showReplies, "replies", false, "include reply comments")
+
+	return cmd
+}
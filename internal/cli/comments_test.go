@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v57/github"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+)
+
+// testComment returns a review comment with an ID, replying to another unless inReplyTo is 0
+func testComment(id, inReplyTo int64) *github.PullRequestComment {
+	comment := &github.PullRequestComment{ID: github.Int64(id)}
+	if inReplyTo != 0 {
+		comment.InReplyTo = github.Int64(inReplyTo)
+	}
+	return comment
+}
+
+func TestUnresolvedComments(t *testing.T) {
+	comments := []*github.PullRequestComment{
+		testComment(1, 0),
+		testComment(2, 1),
+		testComment(3, 0),
+		testComment(4, 3),
+		testComment(5, 0),
+	}
+
+	tests := []struct {
+		name    string
+		threads []ghclient.ReviewThread
+		want    []int64
+	}{
+		{
+			name: "no threads",
+			want: []int64{1, 3, 5},
+		},
+		{
+			name: "all unresolved",
+			threads: []ghclient.ReviewThread{
+				{ID: "a", CommentIDs: []int64{1, 2}},
+				{ID: "b", CommentIDs: []int64{3, 4}},
+				{ID: "c", CommentIDs: []int64{5}},
+			},
+			want: []int64{1, 3, 5},
+		},
+		{
+			name: "resolved thread",
+			threads: []ghclient.ReviewThread{
+				{ID: "a", IsResolved: true, CommentIDs: []int64{1, 2}},
+				{ID: "b", CommentIDs: []int64{3, 4}},
+				{ID: "c", CommentIDs: []int64{5}},
+			},
+			want: []int64{3, 5},
+		},
+		{
+			name: "outdated thread",
+			threads: []ghclient.ReviewThread{
+				{ID: "a", IsOutdated: true, CommentIDs: []int64{1, 2}},
+			},
+			want: []int64{1, 3, 5},
+		},
+		{
+			name: "all resolved",
+			threads: []ghclient.ReviewThread{
+				{ID: "a", IsResolved: true, CommentIDs: []int64{1, 2}},
+				{ID: "b", IsResolved: true, CommentIDs: []int64{3, 4}},
+				{ID: "c", IsResolved: true, CommentIDs: []int64{5}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int64
+			for _, comment := range unresolvedComments(comments, tt.threads) {
+				got = append(got, comment.GetID())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("unresolvedComments() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
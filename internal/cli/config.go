@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/stefrushxyz/nitpick/internal/bundle"
+)
+
+// newConfigCommand creates the config command
+func newConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Check configuration and move it between machines",
+		Long: `Check the config file for mistakes, or export the portable parts of a nitpick setup, the config file
+(including its aliases), user prompt templates and bookmarks, into one archive and import them on
+another machine.`,
+		Args: noArgs,
+	}
+
+	cmd.AddCommand(newConfigValidateCommand(), newConfigExportCommand(), newConfigImportCommand())
+
+	return cmd
+}
+
+// newConfigValidateCommand creates the config validate command
+func newConfigValidateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Check the config file for unknown keys and invalid values",
+		Long: `Check the config file, the selected profile, the project's .nitpick.toml and NITPICK_ environment
+variables. Every problem is reported with its file and line. The same checks run whenever nitpick starts.`,
+		Args: noArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s is valid\n", cfg.File())
+			return nil
+		},
+	}
+}
+
+// newConfigExportCommand creates the config export command
+func newConfigExportCommand() *cobra.Command {
+	var (
+		outputPath     string
+		includeSecrets bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export [-o file]",
+		Short: "Export config, templates and bookmarks as a .tar.gz archive",
+		Long: `Export the config file, user prompt templates and bookmarks as a gzipped tar archive.
+Tokens, the webhook URL and proxy credentials are removed from the config file unless
+--include-secrets is given; tokens stored in the OS keyring are never exported.`,
+		Args: noArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			contents, err := bundle.Collect(cfg, includeSecrets)
+			if err != nil {
+				return err
+			}
+
+			if outputPath == "" || outputPath == "-" {
+				if isTerminal(os.Stdout) {
+					return usageErrorf("refusing to write an archive to a terminal; use -o file or redirect stdout")
+				}
+				return contents.Write(cmd.OutOrStdout())
+			}
+
+			// The archive may hold tokens, so keep it private
+			f, err := os.OpenFile(outputPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %w", outputPath, err)
+			}
+			if err := contents.Write(f); err != nil {
+				f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "Exported config, %d templates and %d bookmarks to %s\n",
+				len(contents.Templates), len(contents.Bookmarks), outputPath)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", `file to write ("-" or omitted for stdout)`)
+	cmd.Flags().BoolVar(&includeSecrets, "include-secrets", false, "keep tokens, the webhook URL and proxy credentials in the exported config file")
+
+	return cmd
+}
+
+// newConfigImportCommand creates the config import command
+func newConfigImportCommand() *cobra.Command {
+	var overwrite bool
+
+	cmd := &cobra.Command{
+		Use:   "import (file | -)",
+		Short: "Import an archive written by config export",
+		Long: `Install the config file and templates from an archive written by config export, and merge its
+bookmarks into the local ones. Existing config and template files are kept unless --force is given.`,
+		Args: exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var r io.Reader = cmd.InOrStdin()
+			if args[0] != "-" {
+				f, err := os.Open(args[0])
+				if err != nil {
+					return fmt.Errorf("failed to open %s: %w", args[0], err)
+				}
+				defer f.Close()
+				r = f
+			}
+
+			contents, err := bundle.Read(r)
+			if err != nil {
+				return err
+			}
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			summary, err := contents.Install(cfg.File(), cfg.TemplatesDir, overwrite)
+			if err != nil {
+				return err
+			}
+
+			stderr := cmd.ErrOrStderr()
+			if summary.Config {
+				fmt.Fprintf(stderr, "Wrote %s\n", cfg.File())
+			}
+			fmt.Fprintf(stderr, "Imported %d templates and %d bookmarks\n", len(summary.Templates), summary.Bookmarks)
+			for _, path := range summary.Skipped {
+				fmt.Fprintf(stderr, "Kept existing %s (use --force to replace it)\n", path)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&overwrite, "force", false, "replace existing config and template files")
+
+	return cmd
+}
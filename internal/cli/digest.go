@@ -0,0 +1,303 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/spf13/cobra"
+	"github.com/stefrushxyz/nitpick/internal/config"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/ui"
+)
+
+// defaultDigestWindow is how far back the first digest looks when no watermark exists
+const defaultDigestWindow = 24 * time.Hour
+
+// digestWatermarks records when the digest last covered each repository of each set of repositories
+type digestWatermarks struct {
+	Runs map[string]map[string]time.Time `json:"runs"` // Time of the last successful digest, by digestKey and repository
+}
+
+// newDigestCommand creates the digest command
+func newDigestCommand() *cobra.Command {
+	var outputPath string
+	var since time.Duration
+	var noUpdate bool
+
+	cmd := &cobra.Command{
+		Use:   "digest [owner/repo...]",
+		Short: "Print review comments added since the last digest",
+		Long: `Print a markdown digest of every review comment added since the last run, across the given
+repositories or, when none are given, every repository you watch on GitHub. The time of each run is
+stored locally as a watermark of each repository it digested, separately for each set of repositories,
+so digests of different repositories don't advance each other's; the first run covers the last 24 hours.
+Repositories whose comments cannot be fetched are skipped with a warning, keeping their watermarks.
+--timeout bounds the requests of each repository, however many are digested.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			refs := make([]repoRef, len(args))
+			for i, arg := range args {
+				ref, err := parseRepoRef(arg)
+				if err != nil {
+					return err
+				}
+				refs[i] = ref
+			}
+
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			watermarkPath, err := digestWatermarkPath()
+			if err != nil {
+				return err
+			}
+			watermarks, err := loadWatermarks(watermarkPath)
+			if err != nil {
+				return err
+			}
+			key := digestKey(cfg.Host, refs)
+			runs := watermarks.Runs[key]
+
+			// Each repository is digested from its own watermark
+			start := time.Now()
+			watermark := func(ref repoRef) time.Time {
+				if since > 0 {
+					return start.Add(-since)
+				}
+				if from, ok := runs[digestRepo(ref)]; ok {
+					return from
+				}
+				return start.Add(-defaultDigestWindow)
+			}
+
+			client, err := newClientFromConfig(cfg)
+			if err != nil {
+				return err
+			}
+
+			if len(refs) == 0 {
+				ctx, cancel := commandContext(cmd, client)
+				watched, err := client.ListWatchedRepos(ctx, allPages)
+				cancel()
+				if err != nil {
+					return err
+				}
+				for _, repo := range watched {
+					refs = append(refs, repoRef{Owner: repo.GetOwner().GetLogin(), Name: repo.GetName()})
+				}
+			}
+
+			var sections []digestSection
+			var digested []repoRef
+			var from time.Time // Earliest time digested, for the heading
+			for _, ref := range refs {
+				repoFrom := watermark(ref)
+				added, err := digestComments(cmd, client, ref, repoFrom)
+				if err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Warning: skipping %s: %v\n", ref, err)
+					continue
+				}
+				digested = append(digested, ref)
+				if from.IsZero() || repoFrom.Before(from) {
+					from = repoFrom
+				}
+				if len(added) > 0 {
+					// Group comments by pull request, keeping them oldest first within each
+					sort.SliceStable(added, func(i, j int) bool {
+						return commentPRNumber(added[i]) < commentPRNumber(added[j])
+					})
+					sections = append(sections, digestSection{repo: ref, comments: added})
+				}
+			}
+
+			if from.IsZero() {
+				from = watermark(repoRef{})
+			}
+
+			out := cmd.OutOrStdout()
+			var file *os.File
+			if outputPath != "" {
+				file, err = os.Create(outputPath)
+				if err != nil {
+					return fmt.Errorf("failed to create digest file: %w", err)
+				}
+				defer file.Close()
+				out = file
+			}
+
+			if err := writeDigest(out, from, sections); err != nil {
+				return err
+			}
+			if file != nil {
+				if err := file.Close(); err != nil {
+					return fmt.Errorf("failed to write digest file: %w", err)
+				}
+			}
+
+			if noUpdate {
+				return nil
+			}
+			// Read the watermarks again so those of a digest run meanwhile are kept
+			if watermarks, err = loadWatermarks(watermarkPath); err != nil {
+				return err
+			}
+			if watermarks.Runs[key] == nil && len(digested) > 0 {
+				watermarks.Runs[key] = make(map[string]time.Time)
+			}
+			for _, ref := range digested {
+				watermarks.Runs[key][digestRepo(ref)] = start
+			}
+			return saveWatermarks(watermarkPath, watermarks)
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "write the digest to a file instead of stdout")
+	cmd.Flags().DurationVar(&since, "since", 0, "look back this far instead of using the stored watermark")
+	cmd.Flags().BoolVar(&noUpdate, "no-update", false, "don't advance the stored watermark")
+	addTimeoutFlag(cmd)
+
+	return cmd
+}
+
+// digestComments fetches the review comments added to a repository since from, within the command's timeout
+func digestComments(cmd *cobra.Command, client *ghclient.Client, ref repoRef, from time.Time) ([]*github.PullRequestComment, error) {
+	ctx, cancel := commandContext(cmd, client)
+	defer cancel()
+
+	comments, err := client.ListRepoCommentsSince(ctx, ref.Owner, ref.Name, from, allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	// Since also matches comments updated after the watermark; keep only new ones
+	var added []*github.PullRequestComment
+	for _, comment := range comments {
+		if comment.CreatedAt != nil && comment.CreatedAt.After(from) {
+			added = append(added, comment)
+		}
+	}
+	return added, nil
+}
+
+// digestSection holds the new comments of one repository
+type digestSection struct {
+	repo     repoRef
+	comments []*github.PullRequestComment
+}
+
+// writeDigest writes the digest as markdown, grouping comments by repository and pull request
+func writeDigest(w io.Writer, from time.Time, sections []digestSection) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Review digest since %s\n", from.Local().Format("2006-01-02 15:04"))
+	if len(sections) == 0 {
+		b.WriteString("\n_No new review comments._\n")
+	}
+
+	for _, section := range sections {
+		fmt.Fprintf(&b, "\n## %s\n", section.repo)
+
+		lastPR := 0
+		for _, comment := range section.comments {
+			if number := commentPRNumber(comment); number != lastPR {
+				fmt.Fprintf(&b, "\n### #%d\n\n", number)
+				lastPR = number
+			}
+
+			location := comment.GetPath()
+			if line := comment.GetLine(); line != 0 {
+				location = fmt.Sprintf("%s:%d", location, line)
+			}
+			fmt.Fprintf(&b, "- **%s** on `%s`: %s ([link](%s))\n",
+				comment.GetUser().GetLogin(), location, ui.CommentItem{Comment: comment}.Title(), comment.GetHTMLURL())
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// commentPRNumber extracts the pull request number from a review comment's pull request URL
+func commentPRNumber(comment *github.PullRequestComment) int {
+	number, _ := strconv.Atoi(path.Base(comment.GetPullRequestURL()))
+	return number
+}
+
+// digestWatermarkPath returns the path of the digest watermark file in the state directory
+func digestWatermarkPath() (string, error) {
+	dir, err := config.StateDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate state directory: %w", err)
+	}
+	return filepath.Join(dir, "digest.json"), nil
+}
+
+// digestKey identifies the repositories a digest covers on a host: the given ones, in any order and case, or
+// the watched ones when none are given
+func digestKey(host string, refs []repoRef) string {
+	if len(refs) == 0 {
+		return host + " watched"
+	}
+
+	names := make([]string, len(refs))
+	for i, ref := range refs {
+		names[i] = digestRepo(ref)
+	}
+	sort.Strings(names)
+	return host + " " + strings.Join(slices.Compact(names), ",")
+}
+
+// digestRepo identifies a repository within the watermarks of a digest, in any case
+func digestRepo(ref repoRef) string {
+	return strings.ToLower(ref.String())
+}
+
+// loadWatermarks reads the digest watermarks, returning none if none are stored yet
+func loadWatermarks(path string) (digestWatermarks, error) {
+	watermarks := digestWatermarks{Runs: make(map[string]map[string]time.Time)}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return watermarks, nil
+	}
+	if err != nil {
+		return watermarks, fmt.Errorf("failed to read digest watermark: %w", err)
+	}
+
+	if err := json.Unmarshal(content, &watermarks); err != nil {
+		return watermarks, fmt.Errorf("failed to parse digest watermark %s: %w", path, err)
+	}
+	if watermarks.Runs == nil {
+		watermarks.Runs = make(map[string]map[string]time.Time)
+	}
+	return watermarks, nil
+}
+
+// saveWatermarks stores the digest watermarks
+func saveWatermarks(path string, watermarks digestWatermarks) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create digest watermark directory: %w", err)
+	}
+
+	content, err := json.Marshal(watermarks)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("failed to write digest watermark: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDigestKey(t *testing.T) {
+	tests := []struct {
+		name string
+		host string
+		refs []repoRef
+		want string
+	}{
+		{
+			name: "watched",
+			host: "github.com",
+			want: "github.com watched",
+		},
+		{
+			name: "one repository",
+			host: "github.com",
+			refs: []repoRef{{Owner: "acme", Name: "api"}},
+			want: "github.com acme/api",
+		},
+		{
+			name: "order and case",
+			host: "github.com",
+			refs: []repoRef{{Owner: "acme", Name: "web"}, {Owner: "Acme", Name: "API"}},
+			want: "github.com acme/api,acme/web",
+		},
+		{
+			name: "duplicates",
+			host: "github.com",
+			refs: []repoRef{{Owner: "acme", Name: "api"}, {Owner: "acme", Name: "web"}, {Owner: "acme", Name: "api"}},
+			want: "github.com acme/api,acme/web",
+		},
+		{
+			name: "another host",
+			host: "github.example.com",
+			refs: []repoRef{{Owner: "acme", Name: "api"}},
+			want: "github.example.com acme/api",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := digestKey(tt.host, tt.refs); got != tt.want {
+				t.Errorf("digestKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDigestWatermarks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "digest.json")
+
+	watermarks, err := loadWatermarks(path)
+	if err != nil {
+		t.Fatalf("loadWatermarks() error = %v", err)
+	}
+	if len(watermarks.Runs) != 0 {
+		t.Errorf("loadWatermarks() = %v without a file, want none", watermarks.Runs)
+	}
+
+	api := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	web := time.Date(2026, 10, 2, 9, 0, 0, 0, time.UTC)
+	watermarks.Runs["github.com acme/api"] = map[string]time.Time{"acme/api": api}
+	watermarks.Runs["github.com watched"] = map[string]time.Time{"acme/api": web, "acme/web": web}
+	if err := saveWatermarks(path, watermarks); err != nil {
+		t.Fatalf("saveWatermarks() error = %v", err)
+	}
+
+	watermarks, err = loadWatermarks(path)
+	if err != nil {
+		t.Fatalf("loadWatermarks() error = %v", err)
+	}
+	if got := watermarks.Runs["github.com acme/api"]["acme/api"]; !got.Equal(api) {
+		t.Errorf("acme/api watermark = %s, want %s", got, api)
+	}
+	if got := watermarks.Runs["github.com watched"]["acme/api"]; !got.Equal(web) {
+		t.Errorf("acme/api watermark of watched repositories = %s, want %s", got, web)
+	}
+}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/spf13/cobra"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+)
+
+// Exit codes returned by nitpick. They are stable so scripts can branch on the type of failure.
+const (
+	ExitOK          = 0
+	ExitError       = 1
+	ExitUsage       = 2
+	ExitAuth        = 3
+	ExitNotFound    = 4
+	ExitRateLimited = 5
+	ExitNetwork     = 6
+	ExitUnresolved  = 7
+)
+
+// Error kinds reported by --json-errors, one per exit code
+const (
+	kindError       = "error"
+	kindUsage       = "usage"
+	kindAuth        = "auth"
+	kindNotFound    = "not_found"
+	kindRateLimited = "rate_limited"
+	kindNetwork     = "network"
+	kindUnresolved  = "unresolved"
+)
+
+// errUnresolvedComments is returned by export --fail-on-unresolved while review threads remain unresolved
+var errUnresolvedComments = errors.New("unresolved review comments remain")
+
+// usageError marks an error caused by invalid command line input
+type usageError struct {
+	err error
+}
+
+func (e usageError) Error() string { return e.err.Error() }
+func (e usageError) Unwrap() error { return e.err }
+
+// usageErrorf formats a usageError
+func usageErrorf(format string, args ...any) error {
+	return usageError{err: fmt.Errorf(format, args...)}
+}
+
+// errorRecord is the machine-readable representation of a failure written by --json-errors
+type errorRecord struct {
+	Error struct {
+		Kind     string `json:"kind"`
+		Message  string `json:"message"`
+		ExitCode int    `json:"exit_code"`
+	} `json:"error"`
+}
+
+// classifyError maps an error to its exit code and kind
+func classifyError(err error) (int, string) {
+	var usageErr usageError
+	var rateLimitErr *github.RateLimitError
+	var abuseErr *github.AbuseRateLimitError
+	var responseErr *github.ErrorResponse
+	var netErr net.Error
+
+	switch {
+	case errors.As(err, &usageErr):
+		return ExitUsage, kindUsage
+	case errors.Is(err, errMissingToken):
+		return ExitAuth, kindAuth
+	case errors.Is(err, errUnresolvedComments):
+		return ExitUnresolved, kindUnresolved
+	case errors.Is(err, ghclient.ErrThreadNotFound):
+		return ExitNotFound, kindNotFound
+	case errors.As(err, &rateLimitErr), errors.As(err, &abuseErr):
+		return ExitRateLimited, kindRateLimited
+	case errors.As(err, &responseErr) && responseErr.Response != nil:
+		switch responseErr.Response.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return ExitAuth, kindAuth
+		case http.StatusNotFound:
+			return ExitNotFound, kindNotFound
+		case http.StatusTooManyRequests:
+			return ExitRateLimited, kindRateLimited
+		}
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr):
+		return ExitNetwork, kindNetwork
+	}
+
+	return ExitError, kindError
+}
+
+// exactArgs is cobra.ExactArgs reporting failures as usage errors
+func exactArgs(n int) cobra.PositionalArgs {
+	return wrapArgs(cobra.ExactArgs(n))
+}
+
+// maxArgs is cobra.MaximumNArgs reporting failures as usage errors
+func maxArgs(n int) cobra.PositionalArgs {
+	return wrapArgs(cobra.MaximumNArgs(n))
+}
+
+// noArgs is cobra.NoArgs reporting failures as usage errors
+func noArgs(cmd *cobra.Command, args []string) error {
+	return wrapArgs(cobra.NoArgs)(cmd, args)
+}
+
+// wrapArgs wraps a positional argument validator so its failures are reported as usage errors
+func wrapArgs(validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := validate(cmd, args); err != nil {
+			return usageError{err: err}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/stefrushxyz/nitpick/internal/export"
+)
+
+// Formats of the export command
+const (
+	exportMarkdown = "markdown"
+	exportQuickfix = "quickfix"
+	exportVSCode   = "vscode"
+	exportActions  = "github-actions"
+	exportSummary  = "job-summary"
+)
+
+// newExportCommand creates the export command
+func newExportCommand() *cobra.Command {
+	var outputPath string
+	var format string
+	var level string
+	var failOnUnresolved bool
+
+	cmd := &cobra.Command{
+		Use:   "export owner/repo#N",
+		Short: "Export a pull request review dossier as markdown, or its comments for editors",
+		Long: `Export a markdown document with a pull request's metadata, changed files and every review thread
+with its code context, for archiving or attaching to tickets. Writes to stdout unless --output is set.
+
+--format quickfix writes one "path:line: reviewer: summary" line per unresolved thread instead, for
+Vim's quickfix list (vim -q file, or :cfile). --format vscode writes a VS Code tasks.json whose task runs
+the quickfix export and turns its lines into entries of the Problems panel.
+
+For CI, --format github-actions writes a workflow command per unresolved thread, annotating the commented
+lines (--level notice, warning or error), and --format job-summary a markdown table of them for
+$GITHUB_STEP_SUMMARY. With --fail-on-unresolved the command exits with code 7 while any thread remains.`,
+		Args: exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, err := parsePRRef(args[0])
+			if err != nil {
+				return err
+			}
+
+			if failOnUnresolved && format != exportQuickfix && format != exportActions && format != exportSummary {
+				return usageErrorf("--fail-on-unresolved requires --format quickfix, github-actions or job-summary")
+			}
+			if level != "notice" && level != "warning" && level != "error" {
+				return usageErrorf("invalid --level %q: expected notice, warning or error", level)
+			}
+
+			var content string
+			var unresolved int
+			switch format {
+			case exportMarkdown:
+				content, err = exportDossier(cmd, ref)
+			case exportQuickfix, exportActions, exportSummary:
+				content, unresolved, err = exportComments(cmd, ref, format, level)
+			case exportVSCode:
+				content, err = export.VSCodeTasks(
+					fmt.Sprintf("nitpick: review comments of %s", ref),
+					fmt.Sprintf("nitpick export %s --format quickfix", ref),
+				)
+			default:
+				return usageErrorf("invalid --format %q: expected markdown, quickfix, vscode, github-actions or job-summary", format)
+			}
+			if err != nil {
+				return err
+			}
+
+			if outputPath == "" {
+				if _, err := io.WriteString(cmd.OutOrStdout(), content); err != nil {
+					return err
+				}
+			} else {
+				if err := os.WriteFile(outputPath, []byte(content), 0o644); err != nil {
+					return fmt.Errorf("failed to write export: %w", err)
+				}
+				fmt.Fprintf(cmd.ErrOrStderr(), "Exported %s to %s\n", ref, outputPath)
+			}
+
+			if failOnUnresolved && unresolved > 0 {
+				return fmt.Errorf("%w: %d on %s", errUnresolvedComments, unresolved, ref)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "write the export to a file instead of stdout")
+	cmd.Flags().StringVar(&format, "format", exportMarkdown, "export format: markdown, quickfix, vscode, github-actions or job-summary")
+	cmd.Flags().StringVar(&level, "level", "warning", "annotation level of --format github-actions: notice, warning or error")
+	cmd.Flags().BoolVar(&failOnUnresolved, "fail-on-unresolved", false, "exit with code 7 if any unresolved review thread remains")
+	addTimeoutFlag(cmd)
+
+	return cmd
+}
+
+// exportDossier renders the markdown dossier of a pull request
+func exportDossier(cmd *cobra.Command, ref prRef) (string, error) {
+	client, err := newClient(cmd)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := commandContext(cmd, client)
+	defer cancel()
+
+	repo, err := client.GetRepo(ctx, ref.Owner, ref.Name)
+	if err != nil {
+		return "", err
+	}
+	pr, err := client.GetChange(ctx, ref.Owner, ref.Name, ref.Number)
+	if err != nil {
+		return "", err
+	}
+	files, err := client.ListFiles(ctx, ref.Owner, ref.Name, ref.Number, allPages)
+	if err != nil {
+		return "", err
+	}
+	comments, err := client.ListComments(ctx, ref.Owner, ref.Name, ref.Number, allPages)
+	if err != nil {
+		return "", err
+	}
+
+	return export.Dossier(repo, pr, files, comments)
+}
+
+// exportComments renders the unresolved review threads of a pull request for editors or CI, and returns
+// how many there are
+func exportComments(cmd *cobra.Command, ref prRef, format, level string) (string, int, error) {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return "", 0, err
+	}
+	client, err := newProviderFromConfig(cfg)
+	if err != nil {
+		return "", 0, err
+	}
+
+	ctx, cancel := commandContext(cmd, client)
+	defer cancel()
+
+	comments, err := client.ListComments(ctx, ref.Owner, ref.Name, ref.Number, allPages)
+	if err != nil {
+		return "", 0, err
+	}
+	comments = withoutIgnoredAuthors(cfg.RepoFilters(ref.Owner, ref.Name), comments)
+	unresolved := len(topLevelComments(comments))
+
+	switch format {
+	case exportActions:
+		return export.Annotations(comments, level), unresolved, nil
+	case exportSummary:
+		pr, err := client.GetChange(ctx, ref.Owner, ref.Name, ref.Number)
+		if err != nil {
+			return "", 0, err
+		}
+		return export.JobSummary(ref.repoRef.String(), pr, comments), unresolved, nil
+	default:
+		return export.Quickfix(comments), unresolved, nil
+	}
+}
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stefrushxyz/nitpick/internal/config"
+	"golang.org/x/term"
+)
+
+// newLoginCommand creates the login command
+func newLoginCommand() *cobra.Command {
+	var host string
+
+	cmd := &cobra.Command{
+		Use:   "login [--host host]",
+		Short: "Store a GitHub token in the OS keyring",
+		Long: `Verify a GitHub personal access token and store it in the OS keyring (macOS keychain, the Secret
+Service on Linux, or the Windows credential manager), so it does not have to live in a plaintext .env file.
+
+The token is prompted for without echo, or read from stdin when it is not a terminal. A token set with
+GITHUB_TOKEN, NITPICK_TOKEN or the config file still takes precedence over the stored one.`,
+		Args: noArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("host") {
+				cfg.Host = host
+			}
+
+			token, err := readToken(cmd)
+			if err != nil {
+				return err
+			}
+			cfg.Token = token
+
+			client, err := newClientFromConfig(cfg)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := commandContext(cmd, client)
+			defer cancel()
+
+			user, err := client.CurrentUser(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to verify token: %w", err)
+			}
+
+			if err := config.StoreToken(config.ProviderGitHub, cfg.Host, token); err != nil {
+				return fmt.Errorf("failed to store token in the keyring: %w", err)
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "Logged in to %s as %s\n", cfg.Host, user.GetLogin())
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&host, "host", config.DefaultHost, "GitHub host to log in to")
+	addTimeoutFlag(cmd)
+
+	return cmd
+}
+
+// newLogoutCommand creates the logout command
+func newLogoutCommand() *cobra.Command {
+	var host string
+
+	cmd := &cobra.Command{
+		Use:   "logout [--host host]",
+		Short: "Remove the GitHub token from the OS keyring",
+		Args:  noArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if !cmd.Flags().Changed("host") {
+				cfg, err := loadConfig(cmd)
+				if err != nil {
+					return err
+				}
+				host = cfg.Host
+			}
+
+			err := config.DeleteToken(config.ProviderGitHub, host)
+			if errors.Is(err, config.ErrNoKeyringToken) {
+				return fmt.Errorf("not logged in to %s", host)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to remove token from the keyring: %w", err)
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "Logged out of %s\n", host)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&host, "host", config.DefaultHost, "GitHub host to log out of")
+
+	return cmd
+}
+
+// readToken prompts for a token without echo, or reads it from stdin when stdin is not a terminal
+func readToken(cmd *cobra.Command) (string, error) {
+	var token string
+	if isTerminal(os.Stdin) {
+		fmt.Fprint(cmd.ErrOrStderr(), "Paste your GitHub token: ")
+		input, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(cmd.ErrOrStderr())
+		if err != nil {
+			return "", fmt.Errorf("failed to read token: %w", err)
+		}
+		token = string(input)
+	} else {
+		input, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return "", fmt.Errorf("failed to read token from stdin: %w", err)
+		}
+		token = string(input)
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", usageErrorf("token is empty")
+	}
+	return token, nil
+}
@@ -0,0 +1,343 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/spf13/cobra"
+	"github.com/stefrushxyz/nitpick/internal/config"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/mcp"
+	"github.com/stefrushxyz/nitpick/internal/prompt"
+	"github.com/stefrushxyz/nitpick/internal/provider"
+	"github.com/stefrushxyz/nitpick/internal/stats"
+)
+
+// commentContextRecord is the result of the get_comment_context tool
+type commentContextRecord struct {
+	PullRequest prRecord        `json:"pull_request"`
+	Comment     commentRecord   `json:"comment"`
+	Replies     []commentRecord `json:"replies"`
+}
+
+// newMCPCommand creates the mcp command
+func newMCPCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mcp",
+		Short: "Serve review comments to AI agents over the Model Context Protocol",
+		Long: `Run a Model Context Protocol server on stdin and stdout, exposing the tools list_prs,
+list_review_comments, get_comment_context and generate_fix_prompt, so that agents such as Claude
+Desktop or IDE assistants can read review feedback directly. Register it with your client as the
+command "nitpick mcp"; logs go to --log-file, as stdout carries the protocol.`,
+		Args: noArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			client, err := newProviderFromConfig(cfg)
+			if err != nil {
+				return err
+			}
+
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+			if timeout <= 0 {
+				timeout = client.Timeout()
+			}
+
+			server := mcp.NewServer("nitpick", buildVersion())
+			registerMCPTools(server, &mcpTools{cfg: cfg, client: client, timeout: timeout})
+			return server.Serve(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().Duration("timeout", 0, "timeout for the API requests of each tool call (default from config, 30s)")
+
+	return cmd
+}
+
+// buildVersion returns the module version nitpick was built from
+func buildVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "(devel)"
+}
+
+// mcpTools implements the tools of the mcp command
+type mcpTools struct {
+	cfg     *config.Config
+	client  provider.Provider
+	timeout time.Duration
+}
+
+// registerMCPTools adds the tools of the mcp command to server
+func registerMCPTools(server *mcp.Server, t *mcpTools) {
+	repoProperty := map[string]any{"type": "string", "description": "Repository as owner/name or a configured alias"}
+	prProperty := map[string]any{"type": "integer", "description": "Pull request number"}
+	commentProperty := map[string]any{"type": "integer", "description": "ID of a review comment, as listed by list_review_comments"}
+
+	server.AddTool(mcp.Tool{
+		Name:        "list_prs",
+		Description: "List the pull requests of a repository with their number of unresolved review comments.",
+		InputSchema: objectSchema(map[string]any{
+			"repo":  repoProperty,
+			"state": map[string]any{"type": "string", "enum": []string{"open", "closed", "all"}, "description": "Pull request state (default open)"},
+			"limit": map[string]any{"type": "integer", "description": "Maximum number of pull requests (default from the configured limits)"},
+		}, "repo"),
+		Handler: withArgs(t.listPRs),
+	})
+	server.AddTool(mcp.Tool{
+		Name:        "list_review_comments",
+		Description: "List the review comments of unresolved threads on a pull request, with their file, line and diff hunk.",
+		InputSchema: objectSchema(map[string]any{
+			"repo":             repoProperty,
+			"pr":               prProperty,
+			"include_replies":  map[string]any{"type": "boolean", "description": "Include replies to the comments that start threads"},
+			"include_resolved": map[string]any{"type": "boolean", "description": "Include the comments of resolved threads, marked resolved"},
+		}, "repo", "pr"),
+		Handler: withArgs(t.listReviewComments),
+	})
+	server.AddTool(mcp.Tool{
+		Name:        "get_comment_context",
+		Description: "Get a review comment with its diff hunk, the replies in its thread and the pull request it belongs to.",
+		InputSchema: objectSchema(map[string]any{
+			"repo":       repoProperty,
+			"pr":         prProperty,
+			"comment_id": commentProperty,
+		}, "repo", "pr", "comment_id"),
+		Handler: withArgs(t.getCommentContext),
+	})
+	server.AddTool(mcp.Tool{
+		Name: "generate_fix_prompt",
+		Description: "Generate a prompt for addressing a review comment, or, without comment_id, one combined prompt " +
+			"for every unresolved comment on the pull request.",
+		InputSchema: objectSchema(map[string]any{
+			"repo":       repoProperty,
+			"pr":         prProperty,
+			"comment_id": commentProperty,
+			"template":   map[string]any{"type": "string", "description": "Built-in template (full, simple, aggregate) or user template name"},
+		}, "repo", "pr"),
+		Handler: withArgs(t.generateFixPrompt),
+	})
+}
+
+// objectSchema returns the JSON schema of an object with the given properties
+func objectSchema(properties map[string]any, required ...string) map[string]any {
+	return map[string]any{"type": "object", "properties": properties, "required": required}
+}
+
+// mcpArgs holds the arguments of any of the mcp command's tools
+type mcpArgs struct {
+	Repo            string `json:"repo"`
+	PR              int    `json:"pr"`
+	CommentID       int64  `json:"comment_id"`
+	State           string `json:"state"`
+	Limit           int    `json:"limit"`
+	IncludeReplies  bool   `json:"include_replies"`
+	IncludeResolved bool   `json:"include_resolved"`
+	Template        string `json:"template"`
+}
+
+// withArgs adapts a tool implementation to a handler that decodes its arguments
+func withArgs(fn func(context.Context, mcpArgs) (any, error)) func(context.Context, json.RawMessage) (any, error) {
+	return func(ctx context.Context, raw json.RawMessage) (any, error) {
+		var args mcpArgs
+		if err := json.Unmarshal(raw, &args); err != nil {
+			return nil, fmt.Errorf("invalid arguments: %w", err)
+		}
+		return fn(ctx, args)
+	}
+}
+
+// pr parses the repository and pull request arguments
+func (args mcpArgs) pr() (prRef, error) {
+	repo, err := parseRepoRef(args.Repo)
+	if err != nil {
+		return prRef{}, err
+	}
+	if args.PR <= 0 {
+		return prRef{}, fmt.Errorf("pr must be a positive pull request number")
+	}
+	return prRef{repoRef: repo, Number: args.PR}, nil
+}
+
+// listPRs implements the list_prs tool
+func (t *mcpTools) listPRs(ctx context.Context, args mcpArgs) (any, error) {
+	ref, err := parseRepoRef(args.Repo)
+	if err != nil {
+		return nil, err
+	}
+	state := args.State
+	if state == "" {
+		state = "open"
+	}
+	listOpts := ghclient.ListOptionsFromLimits(t.cfg.Limits.PRs)
+	if args.Limit > 0 {
+		listOpts = ghclient.ListOptions{Limit: args.Limit, AllPages: true}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	filters := t.cfg.RepoFilters(ref.Owner, ref.Name)
+	prs, err := t.client.ListChanges(ctx, ref.Owner, ref.Name, ghclient.PRListOptions{
+		State:       state,
+		Base:        filters.Base,
+		ListOptions: listOpts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newPRRecords(ctx, t.client, filters, ref.Owner, ref.Name, prs)
+}
+
+// listReviewComments implements the list_review_comments tool
+func (t *mcpTools) listReviewComments(ctx context.Context, args mcpArgs) (any, error) {
+	ref, err := args.pr()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	comments, err := t.client.ListComments(ctx, ref.Owner, ref.Name, ref.Number, allPages)
+	if err != nil {
+		return nil, err
+	}
+	threads, err := t.client.ListThreads(ctx, ref.Owner, ref.Name, ref.Number)
+	if err != nil {
+		return nil, err
+	}
+	comments = withoutIgnoredAuthors(t.cfg.RepoFilters(ref.Owner, ref.Name), comments)
+	byComment := threadsByComment(threads)
+
+	records := make([]commentRecord, 0, len(comments))
+	for _, comment := range comments {
+		record := newCommentRecord(comment)
+		record.setThread(byComment)
+		if (args.IncludeResolved || !record.Resolved) && (args.IncludeReplies || comment.GetInReplyTo() == 0) {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+// getCommentContext implements the get_comment_context tool
+func (t *mcpTools) getCommentContext(ctx context.Context, args mcpArgs) (any, error) {
+	ref, err := args.pr()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	pr, err := t.client.GetChange(ctx, ref.Owner, ref.Name, ref.Number)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := t.client.ListComments(ctx, ref.Owner, ref.Name, ref.Number, allPages)
+	if err != nil {
+		return nil, err
+	}
+	threads, err := t.client.ListThreads(ctx, ref.Owner, ref.Name, ref.Number)
+	if err != nil {
+		return nil, err
+	}
+	byComment := threadsByComment(threads)
+
+	result := commentContextRecord{PullRequest: newPRRecord(pr), Replies: []commentRecord{}}
+	found := false
+	for _, comment := range comments {
+		record := newCommentRecord(comment)
+		record.setThread(byComment)
+		switch {
+		case comment.GetID() == args.CommentID:
+			result.Comment = record
+			found = true
+		case comment.GetInReplyTo() == args.CommentID:
+			result.Replies = append(result.Replies, record)
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("comment %d not found among the comments of %s", args.CommentID, ref)
+	}
+	return result, nil
+}
+
+// generateFixPrompt implements the generate_fix_prompt tool
+func (t *mcpTools) generateFixPrompt(ctx context.Context, args mcpArgs) (any, error) {
+	ref, err := args.pr()
+	if err != nil {
+		return nil, err
+	}
+
+	promptGen := prompt.New()
+	promptGen.SetTemplateDir(t.cfg.TemplatesDir)
+	templateName := args.Template
+	if templateName == "" {
+		templateName = t.cfg.PromptTemplate
+		if args.CommentID == 0 {
+			templateName = prompt.TemplateAggregate
+		}
+	}
+	tmpl, err := promptGen.Template(templateName)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	repo, err := t.client.GetRepo(ctx, ref.Owner, ref.Name)
+	if err != nil {
+		return nil, err
+	}
+	pr, err := t.client.GetChange(ctx, ref.Owner, ref.Name, ref.Number)
+	if err != nil {
+		return nil, err
+	}
+	promptGen.SetTickets(linkedTickets(ctx, t.cfg, pr))
+	promptGen.SetIssues(ref.repoRef.String(), promptIssues(ctx, t.cfg, t.client, ref.repoRef, pr))
+	promptGen.SetCodeowners(promptCodeowners(ctx, t.cfg, t.client, ref.repoRef, pr))
+
+	var promptText string
+	if args.CommentID == 0 {
+		unresolved, err := listUnresolvedComments(ctx, t.client, t.cfg.RepoFilters(ref.Owner, ref.Name), ref.Owner, ref.Name, ref.Number)
+		if err != nil {
+			return nil, err
+		}
+		if len(unresolved) == 0 {
+			return nil, fmt.Errorf("no unresolved comments on %s", ref)
+		}
+		promptGen.SetBlame(promptBlame(ctx, t.cfg, t.client, ref.repoRef, pr, unresolved))
+		promptGen.SetSymbols(promptSymbols(ctx, t.cfg, t.client, ref.repoRef, pr, unresolved))
+		promptGen.SetContexts(promptContexts(ctx, t.cfg, t.client, ref.repoRef, pr, unresolved))
+		promptText, err = promptGen.GenerateAggregate(tmpl, repo, pr, unresolved)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		comment, err := provider.FindComment(ctx, t.client, ref.Owner, ref.Name, ref.Number, args.CommentID)
+		if err != nil {
+			return nil, err
+		}
+		promptGen.SetBlame(promptBlame(ctx, t.cfg, t.client, ref.repoRef, pr, []*github.PullRequestComment{comment}))
+		promptGen.SetSymbols(promptSymbols(ctx, t.cfg, t.client, ref.repoRef, pr, []*github.PullRequestComment{comment}))
+		promptGen.SetContexts(promptContexts(ctx, t.cfg, t.client, ref.repoRef, pr, []*github.PullRequestComment{comment}))
+		promptText, err = promptGen.Generate(tmpl, repo, pr, comment)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	stats.Record(stats.EventPrompt, ref.repoRef.String(), 1)
+	return promptText, nil
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stefrushxyz/nitpick/internal/browser"
+)
+
+// newOpenCommand creates the open command
+func newOpenCommand() *cobra.Command {
+	var commentID int64
+	var printOnly bool
+
+	cmd := &cobra.Command{
+		Use:   "open (owner/repo | owner/repo#N | url) [--comment ID]",
+		Short: "Open a repository, pull request or comment in the browser",
+		Long: `Resolve a repository, pull request or review comment through the API and open its GitHub page
+in the browser. Use --print to write the resolved URL to stdout instead.`,
+		Args: exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, err := parseTargetRef(args[0], commentID)
+			if err != nil {
+				return err
+			}
+
+			client, err := newClient(cmd)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := commandContext(cmd, client)
+			defer cancel()
+
+			var url string
+			switch {
+			case ref.CommentID != 0:
+				comment, err := client.GetComment(ctx, ref.Owner, ref.Name, ref.CommentID)
+				if err != nil {
+					return err
+				}
+				url = comment.GetHTMLURL()
+			case ref.Number != 0:
+				pr, err := client.GetChange(ctx, ref.Owner, ref.Name, ref.Number)
+				if err != nil {
+					return err
+				}
+				url = pr.GetHTMLURL()
+			default:
+				repo, err := client.GetRepo(ctx, ref.Owner, ref.Name)
+				if err != nil {
+					return err
+				}
+				url = repo.GetHTMLURL()
+			}
+
+			if printOnly {
+				_, err := fmt.Fprintln(cmd.OutOrStdout(), url)
+				return err
+			}
+
+			return browser.Open(url)
+		},
+	}
+
+	cmd.Flags().Int64Var(&commentID, "comment", 0, "ID of a review comment to open")
+	cmd.Flags().BoolVar(&printOnly, "print", false, "print the URL instead of opening it")
+	addTimeoutFlag(cmd)
+
+	return cmd
+}
@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Output formats supported by list commands
+const (
+	formatTSV      = "tsv"
+	formatJSON     = "json"
+	formatNDJSON   = "ndjson"
+	formatMarkdown = "markdown"
+	formatCSV      = "csv"
+)
+
+// outputFormats lists the supported output formats in the order shown in help text
+var outputFormats = []string{formatTSV, formatJSON, formatNDJSON, formatMarkdown, formatCSV}
+
+// outputFlags holds the output format flags shared by list commands
+type outputFlags struct {
+	format string
+	json   bool
+}
+
+// register adds the output format flags to cmd
+func (f *outputFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&f.format, "format", "f", formatTSV,
+		fmt.Sprintf("output format (%s)", strings.Join(outputFormats, ", ")))
+	cmd.Flags().BoolVar(&f.json, "json", false, "output as JSON (shorthand for --format json)")
+	cmd.MarkFlagsMutuallyExclusive("format", "json")
+}
+
+// resolve returns the selected output format
+func (f *outputFlags) resolve() (string, error) {
+	if f.json {
+		return formatJSON, nil
+	}
+	for _, format := range outputFormats {
+		if f.format == format {
+			return format, nil
+		}
+	}
+	return "", usageErrorf("unknown format %q: expected one of %s", f.format, strings.Join(outputFormats, ", "))
+}
+
+// writeList writes records to w in the given format.
+// Tabular formats use header and the row function; JSON formats encode the records themselves.
+func writeList[T any](w io.Writer, format string, records []T, header []string, row func(T) []string) error {
+	switch format {
+	case formatJSON:
+		return writeJSON(w, records)
+	case formatNDJSON:
+		encoder := json.NewEncoder(w)
+		for _, record := range records {
+			if err := encoder.Encode(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	rows := make([][]string, len(records))
+	for i, record := range records {
+		rows[i] = row(record)
+	}
+
+	switch format {
+	case formatMarkdown:
+		return writeMarkdownTable(w, header, rows)
+	case formatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(header); err != nil {
+			return err
+		}
+		if err := writer.WriteAll(rows); err != nil {
+			return err
+		}
+		return writer.Error()
+	default:
+		return writeTSV(w, rows)
+	}
+}
+
+// writeJSON writes v to w as indented JSON
+func writeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// writeTSV writes rows to w as tab-separated values, one row per line
+func writeTSV(w io.Writer, rows [][]string) error {
+	for _, row := range rows {
+		fields := make([]string, len(row))
+		for i, field := range row {
+			fields[i] = tsvEscaper.Replace(field)
+		}
+		if _, err := io.WriteString(w, strings.Join(fields, "\t")+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeMarkdownTable writes rows to w as a GitHub-flavored markdown table
+func writeMarkdownTable(w io.Writer, header []string, rows [][]string) error {
+	writeRow := func(fields []string) error {
+		cells := make([]string, len(fields))
+		for i, field := range fields {
+			cells[i] = markdownEscaper.Replace(field)
+		}
+		_, err := io.WriteString(w, "| "+strings.Join(cells, " | ")+" |\n")
+		return err
+	}
+
+	if err := writeRow(header); err != nil {
+		return err
+	}
+	separator := make([]string, len(header))
+	for i := range separator {
+		separator[i] = "---"
+	}
+	if err := writeRow(separator); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := writeRow(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tsvEscaper keeps multi-line and tab-containing fields on a single TSV cell
+var tsvEscaper = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+// markdownEscaper keeps fields inside a single markdown table cell
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>", "\r", "<br>")
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+)
+
+// allPages fetches every result of a list call
+var allPages = ghclient.ListOptions{AllPages: true}
+
+// listFlags holds the pagination and timeout flags shared by headless list commands
+type listFlags struct {
+	limit    int
+	allPages bool
+	pageSize int
+}
+
+// register adds the pagination and timeout flags to cmd
+func (f *listFlags) register(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&f.limit, "limit", 0, "maximum number of results (default from config limits)")
+	cmd.Flags().BoolVar(&f.allPages, "all-pages", false, "follow pagination to the last page")
+	cmd.Flags().IntVar(&f.pageSize, "page-size", 0, "results requested per API page, 1-100 (default from config)")
+	addTimeoutFlag(cmd)
+}
+
+// addTimeoutFlag adds the --timeout flag read by commandContext to cmd
+func addTimeoutFlag(cmd *cobra.Command) {
+	cmd.Flags().Duration("timeout", 0, "timeout for the API requests of this command (default from config, 30s)")
+}
+
+// options returns the client list options selected by the flags, or defaults if neither is set
+func (f *listFlags) options(defaults ghclient.ListOptions) (ghclient.ListOptions, error) {
+	if f.limit < 0 {
+		return ghclient.ListOptions{}, usageErrorf("--limit must not be negative")
+	}
+	if f.pageSize < 0 || f.pageSize > 100 {
+		return ghclient.ListOptions{}, usageErrorf("--page-size must be between 1 and 100")
+	}
+	if f.limit == 0 && !f.allPages {
+		if f.pageSize > 0 {
+			defaults.PerPage = f.pageSize
+		}
+		return defaults, nil
+	}
+
+	// A limit above one page implies following pagination
+	return ghclient.ListOptions{
+		PerPage:  f.pageSize,
+		Limit:    f.limit,
+		AllPages: f.allPages || f.limit > 0,
+	}, nil
+}
@@ -0,0 +1,293 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"text/template"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/spf13/cobra"
+	"github.com/stefrushxyz/nitpick/internal/blame"
+	"github.com/stefrushxyz/nitpick/internal/clipboard"
+	"github.com/stefrushxyz/nitpick/internal/codeowners"
+	"github.com/stefrushxyz/nitpick/internal/config"
+	"github.com/stefrushxyz/nitpick/internal/gitrepo"
+	"github.com/stefrushxyz/nitpick/internal/prompt"
+	"github.com/stefrushxyz/nitpick/internal/provider"
+	"github.com/stefrushxyz/nitpick/internal/stats"
+	"github.com/stefrushxyz/nitpick/internal/symbol"
+	"github.com/stefrushxyz/nitpick/internal/tickets"
+)
+
+// newPromptCommand creates the prompt command
+func newPromptCommand() *cobra.Command {
+	var commentID int64
+	var simple bool
+	var copyPrompt bool
+	var all bool
+	var templateName string
+
+	cmd := &cobra.Command{
+		Use:   "prompt owner/repo#N (--comment ID | --all)",
+		Short: "Generate an AI prompt for review comments",
+		Long: `Generate the full or simple AI prompt for a review comment and print it to stdout, or copy it to the clipboard with --copy.
+With --all, a single combined prompt covering every unresolved comment on the pull request is generated instead.
+
+--template selects a built-in template (full, simple, aggregate), a user template by name from the
+configured templates directory (~/.config/nitpick/templates by default), or a path to a .tmpl file.
+Use --template - to read a one-off template from stdin.`,
+		Args: exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, err := parsePRRef(args[0])
+			if err != nil {
+				return err
+			}
+			if commentID == 0 && !all {
+				return usageErrorf("either --comment or --all is required")
+			}
+
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			client, err := newProviderFromConfig(cfg)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := commandContext(cmd, client)
+			defer cancel()
+
+			repo, err := client.GetRepo(ctx, ref.Owner, ref.Name)
+			if err != nil {
+				return err
+			}
+			pr, err := client.GetChange(ctx, ref.Owner, ref.Name, ref.Number)
+			if err != nil {
+				return err
+			}
+
+			promptGen := prompt.New()
+			promptGen.SetTemplateDir(cfg.TemplatesDir)
+			promptGen.SetTickets(linkedTickets(ctx, cfg, pr))
+			promptGen.SetIssues(ref.repoRef.String(), promptIssues(ctx, cfg, client, ref.repoRef, pr))
+			promptGen.SetCodeowners(promptCodeowners(ctx, cfg, client, ref.repoRef, pr))
+			if templateName == "" {
+				switch {
+				case all:
+					templateName = prompt.TemplateAggregate
+				case simple:
+					templateName = prompt.TemplateSimple
+				default:
+					templateName = cfg.PromptTemplate
+				}
+			}
+			tmpl, err := loadTemplate(cmd, promptGen, templateName)
+			if err != nil {
+				return err
+			}
+
+			var promptText string
+			if all {
+				unresolved, err := listUnresolvedComments(ctx, client, cfg.RepoFilters(ref.Owner, ref.Name), ref.Owner, ref.Name, ref.Number)
+				if err != nil {
+					return err
+				}
+				if len(unresolved) == 0 {
+					return fmt.Errorf("no unresolved comments on %s", ref)
+				}
+
+				promptGen.SetBlame(promptBlame(ctx, cfg, client, ref.repoRef, pr, unresolved))
+				promptGen.SetSymbols(promptSymbols(ctx, cfg, client, ref.repoRef, pr, unresolved))
+				promptGen.SetContexts(promptContexts(ctx, cfg, client, ref.repoRef, pr, unresolved))
+				promptText, err = promptGen.GenerateAggregate(tmpl, repo, pr, unresolved)
+				if err != nil {
+					return err
+				}
+			} else {
+				comment, err := provider.FindComment(ctx, client, ref.Owner, ref.Name, ref.Number, commentID)
+				if err != nil {
+					return err
+				}
+
+				promptGen.SetBlame(promptBlame(ctx, cfg, client, ref.repoRef, pr, []*github.PullRequestComment{comment}))
+				promptGen.SetSymbols(promptSymbols(ctx, cfg, client, ref.repoRef, pr, []*github.PullRequestComment{comment}))
+				promptGen.SetContexts(promptContexts(ctx, cfg, client, ref.repoRef, pr, []*github.PullRequestComment{comment}))
+				promptText, err = promptGen.Generate(tmpl, repo, pr, comment)
+				if err != nil {
+					return err
+				}
+			}
+
+			stats.Record(stats.EventPrompt, ref.repoRef.String(), 1)
+
+			if copyPrompt {
+				if err := clipboard.CopyWith(cfg.Clipboard.Backend, promptText); err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.ErrOrStderr(), "Prompt copied to clipboard")
+				return nil
+			}
+
+			_, err = io.WriteString(cmd.OutOrStdout(), promptText+"\n")
+			return err
+		},
+	}
+
+	cmd.Flags().Int64Var(&commentID, "comment", 0, "ID of the review comment")
+	cmd.Flags().BoolVar(&simple, "simple", false, "use the simple prompt template")
+	cmd.Flags().StringVar(&templateName, "template", "", "built-in template name, user template name, path to a .tmpl file, or - for stdin")
+	cmd.MarkFlagsMutuallyExclusive("simple", "template")
+	cmd.Flags().BoolVar(&all, "all", false, "generate one combined prompt for all unresolved comments")
+	cmd.MarkFlagsMutuallyExclusive("comment", "all")
+	cmd.Flags().BoolVar(&copyPrompt, "copy", false, "copy the prompt to the clipboard instead of printing it")
+	addTimeoutFlag(cmd)
+
+	return cmd
+}
+
+// linkedTickets fetches the issue tracker tickets a pull request refers to, for the trackers whose tickets
+// prompts include. Tickets only add context, so failures are logged and skipped.
+func linkedTickets(ctx context.Context, cfg *config.Config, pr *github.PullRequest) []*tickets.Ticket {
+	if !cfg.Jira.Prompt && !cfg.Linear.Prompt {
+		return nil
+	}
+	linker, err := tickets.New(cfg)
+	if err != nil {
+		slog.Warn("skipping linked tickets", "err", err)
+		return nil
+	}
+	if linker == nil {
+		return nil
+	}
+
+	linked, err := linker.Linked(ctx, pr)
+	if err != nil {
+		slog.Warn("failed to fetch the linked tickets", "pr", pr.GetNumber(), "err", err)
+	}
+	return linker.ForPrompt(linked)
+}
+
+// promptIssues fetches the issues a pull request closes when prompts quote them. Issues only add context, so
+// failures are logged and skipped.
+func promptIssues(ctx context.Context, cfg *config.Config, client provider.Provider, ref repoRef, pr *github.PullRequest) []*github.Issue {
+	if !cfg.PromptIssues {
+		return nil
+	}
+	issues, err := provider.FindLinkedIssues(ctx, client, ref.Owner, ref.Name, pr)
+	if err != nil {
+		slog.Warn("failed to fetch the linked issues", "pr", pr.GetNumber(), "err", err)
+	}
+	return issues
+}
+
+// promptCodeowners loads the CODEOWNERS file of a pull request's base when prompts name the owners of
+// commented files, from the current directory's checkout when it is of the repository. Owners only add
+// context, so failures are logged and skipped.
+func promptCodeowners(ctx context.Context, cfg *config.Config, client provider.Provider, ref repoRef, pr *github.PullRequest) *codeowners.File {
+	if !cfg.PromptOwners {
+		return nil
+	}
+	root := ""
+	if checkout := repoCheckout(cfg, ref); checkout != nil {
+		root = checkout.Root
+	}
+
+	owners, err := provider.FindCodeowners(ctx, client, ref.Owner, ref.Name, pr.GetBase().GetRef(), root)
+	if err != nil {
+		slog.Warn("failed to load CODEOWNERS", "repo", ref.String(), "err", err)
+	}
+	return owners
+}
+
+// promptBlame blames the lines of review comments, by comment ID, when prompts name the commits that last
+// changed them, from the current directory's checkout when it is of the repository. Blame only adds
+// context, so failures are logged and skipped.
+func promptBlame(ctx context.Context, cfg *config.Config, client provider.Provider, ref repoRef, pr *github.PullRequest, comments []*github.PullRequestComment) map[int64][]blame.Range {
+	if !cfg.PromptBlame {
+		return nil
+	}
+	checkout := repoCheckout(cfg, ref)
+
+	blames := make(map[int64][]blame.Range)
+	for _, comment := range comments {
+		ranges, err := provider.FindBlame(ctx, client, ref.Owner, ref.Name, pr, comment, checkout)
+		if err != nil {
+			slog.Warn("failed to blame the commented lines", "comment", comment.GetID(), "err", err)
+			continue
+		}
+		blames[comment.GetID()] = ranges
+	}
+	return blames
+}
+
+// promptSymbols finds the declarations enclosing the lines of review comments, by comment ID, when prompts
+// quote them, reading files from the current directory's checkout when it is of the repository. Failures
+// are logged and skipped.
+func promptSymbols(ctx context.Context, cfg *config.Config, client provider.Provider, ref repoRef, pr *github.PullRequest, comments []*github.PullRequestComment) map[int64]*symbol.Symbol {
+	if !cfg.PromptSymbols {
+		return nil
+	}
+	checkout := repoCheckout(cfg, ref)
+
+	symbols := make(map[int64]*symbol.Symbol)
+	for _, comment := range comments {
+		sym, err := provider.FindSymbol(ctx, client, ref.Owner, ref.Name, pr, comment, checkout)
+		if err != nil {
+			slog.Warn("failed to find the function enclosing the commented lines", "comment", comment.GetID(), "err", err)
+			continue
+		}
+		symbols[comment.GetID()] = sym
+	}
+	return symbols
+}
+
+// promptContexts fetches the lines of the commented files around the lines of review comments, by comment
+// ID, when prompts quote them, reading files from the current directory's checkout when it is of the
+// repository. Failures are logged and skipped.
+func promptContexts(ctx context.Context, cfg *config.Config, client provider.Provider, ref repoRef, pr *github.PullRequest, comments []*github.PullRequestComment) map[int64]string {
+	if !cfg.PromptContext || cfg.ContextLines == 0 {
+		return nil
+	}
+	checkout := repoCheckout(cfg, ref)
+
+	contexts := make(map[int64]string)
+	for _, comment := range comments {
+		lines, err := provider.FindContext(ctx, client, ref.Owner, ref.Name, pr, comment, checkout, cfg.ContextLines)
+		if err != nil {
+			slog.Warn("failed to fetch the lines around the commented lines", "comment", comment.GetID(), "err", err)
+			continue
+		}
+		contexts[comment.GetID()] = lines
+	}
+	return contexts
+}
+
+// repoCheckout returns the current directory's checkout when it is of the repository
+func repoCheckout(cfg *config.Config, ref repoRef) *gitrepo.Checkout {
+	checkout := detectCheckout(cfg)
+	if checkout == nil || !checkout.Is(ref.Owner, ref.Name) {
+		return nil
+	}
+	return checkout
+}
+
+// loadTemplate resolves the --template flag, reading the template from stdin when it is "-"
+func loadTemplate(cmd *cobra.Command, promptGen *prompt.Generator, name string) (*template.Template, error) {
+	if name != "-" {
+		return promptGen.Template(name)
+	}
+
+	text, err := io.ReadAll(cmd.InOrStdin())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template from stdin: %w", err)
+	}
+	if len(bytes.TrimSpace(text)) == 0 {
+		return nil, usageErrorf("template read from stdin is empty")
+	}
+
+	return prompt.ParseTemplate("stdin", string(text))
+}
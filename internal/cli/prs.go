@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/spf13/cobra"
+	"github.com/stefrushxyz/nitpick/internal/config"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/provider"
+)
+
+// prRecord is the headless representation of a pull request
+type prRecord struct {
+	Number             int    `json:"number"`
+	Title              string `json:"title"`
+	Author             string `json:"author"`
+	State              string `json:"state"`
+	Draft              bool   `json:"draft"`
+	SourceBranch       string `json:"source_branch"`
+	TargetBranch       string `json:"target_branch"`
+	CreatedAt          string `json:"created_at,omitempty"`
+	UpdatedAt          string `json:"updated_at,omitempty"`
+	UnresolvedComments int    `json:"unresolved_comments"`
+	URL                string `json:"url"`
+}
+
+// newPRRecord converts a GitHub pull request into a prRecord
+func newPRRecord(pr *github.PullRequest) prRecord {
+	record := prRecord{
+		Number:       pr.GetNumber(),
+		Title:        pr.GetTitle(),
+		Author:       pr.GetUser().GetLogin(),
+		State:        pr.GetState(),
+		Draft:        pr.GetDraft(),
+		SourceBranch: pr.GetHead().GetRef(),
+		TargetBranch: pr.GetBase().GetRef(),
+		URL:          pr.GetHTMLURL(),
+	}
+	if pr.CreatedAt != nil {
+		record.CreatedAt = pr.CreatedAt.Format(time.RFC3339)
+	}
+	if pr.UpdatedAt != nil {
+		record.UpdatedAt = pr.UpdatedAt.Format(time.RFC3339)
+	}
+	return record
+}
+
+// unresolvedConcurrency bounds the pull requests whose unresolved comments are counted at once
+const unresolvedConcurrency = 4
+
+// newPRRecords converts pull requests into prRecords with their numbers of unresolved comments, fetching
+// the comments of several pull requests at once. It returns the first error, cancelling the other fetches.
+func newPRRecords(ctx context.Context, client provider.Provider, filters config.Filters, owner, name string, prs []*github.PullRequest) ([]prRecord, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		once     sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+	records := make([]prRecord, len(prs))
+	sem := make(chan struct{}, unresolvedConcurrency)
+	var wg sync.WaitGroup
+	for i, pr := range prs {
+		records[i] = newPRRecord(pr)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if err := ctx.Err(); err != nil {
+				fail(err)
+				return
+			}
+
+			unresolved, err := listUnresolvedComments(ctx, client, filters, owner, name, pr.GetNumber())
+			if err != nil {
+				fail(err)
+				return
+			}
+			records[i].UnresolvedComments = len(unresolved)
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return records, nil
+}
+
+// newPRsCommand creates the prs command
+func newPRsCommand() *cobra.Command {
+	var output outputFlags
+	var list listFlags
+	var state string
+	var base string
+
+	cmd := &cobra.Command{
+		Use:   "prs owner/repo",
+		Short: "List pull requests for a repository",
+		Long:  "List pull requests for a repository (open by default), including the number of unresolved review comments on each.",
+		Args:  exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, err := parseRepoRef(args[0])
+			if err != nil {
+				return err
+			}
+			format, err := output.resolve()
+			if err != nil {
+				return err
+			}
+			if state != "open" && state != "closed" && state != "all" {
+				return usageErrorf("invalid --state %q: expected open, closed or all", state)
+			}
+
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			listOpts, err := list.options(ghclient.ListOptionsFromLimits(cfg.Limits.PRs))
+			if err != nil {
+				return err
+			}
+			filters := cfg.RepoFilters(ref.Owner, ref.Name)
+			if !cmd.Flags().Changed("base") {
+				base = filters.Base
+			}
+			client, err := newProviderFromConfig(cfg)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := commandContext(cmd, client)
+			defer cancel()
+
+			prs, err := client.ListChanges(ctx, ref.Owner, ref.Name, ghclient.PRListOptions{
+				State:       state,
+				Base:        base,
+				ListOptions: listOpts,
+			})
+			if err != nil {
+				return err
+			}
+
+			records, err := newPRRecords(ctx, client, filters, ref.Owner, ref.Name, prs)
+			if err != nil {
+				return err
+			}
+
+			header := []string{"number", "title", "author", "state", "unresolved_comments", "url"}
+			return writeList(cmd.OutOrStdout(), format, records, header, func(r prRecord) []string {
+				return []string{
+					strconv.Itoa(r.Number), r.Title, r.Author, r.State,
+					strconv.Itoa(r.UnresolvedComments), r.URL,
+				}
+			})
+		},
+	}
+
+	output.register(cmd)
+	list.register(cmd)
+	cmd.Flags().StringVar(&state, "state", "open", "pull request state (open, closed, all)")
+	cmd.Flags().StringVar(&base, "base", "", "only list pull requests targeting this base branch (default from the repository's filters)")
+
+	return cmd
+}
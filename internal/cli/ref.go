@@ -0,0 +1,202 @@
+package cli
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// repoRef identifies a repository by owner and name
+type repoRef struct {
+	Owner string
+	Name  string
+}
+
+// String returns the reference in owner/name form
+func (r repoRef) String() string {
+	return r.Owner + "/" + r.Name
+}
+
+// prRef identifies a pull request within a repository
+type prRef struct {
+	repoRef
+	Number int
+}
+
+// String returns the reference in owner/name#number form
+func (r prRef) String() string {
+	return fmt.Sprintf("%s#%d", r.repoRef, r.Number)
+}
+
+// lookupAlias resolves a repository alias from the configuration. The root command installs a
+// resolver that loads the configuration on first use.
+var lookupAlias = func(string) (string, bool) { return "", false }
+
+// parseRepoRef parses an owner/name repository reference or a configured repository alias
+func parseRepoRef(s string) (repoRef, error) {
+	if !strings.Contains(s, "/") {
+		if repo, ok := lookupAlias(s); ok {
+			s = repo
+		}
+	}
+
+	owner, name, ok := strings.Cut(s, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return repoRef{}, usageErrorf("invalid repository %q: expected owner/name or a configured alias", s)
+	}
+	return repoRef{Owner: owner, Name: name}, nil
+}
+
+// parsePRRef parses an owner/name#number pull request reference, where owner/name may be an alias
+func parsePRRef(s string) (prRef, error) {
+	repo, number, ok := strings.Cut(s, "#")
+	if !ok {
+		return prRef{}, usageErrorf("invalid pull request %q: expected owner/name#number", s)
+	}
+
+	ref, err := parseRepoRef(repo)
+	if err != nil {
+		return prRef{}, err
+	}
+
+	n, err := strconv.Atoi(number)
+	if err != nil || n <= 0 {
+		return prRef{}, usageErrorf("invalid pull request number %q", number)
+	}
+
+	return prRef{repoRef: ref, Number: n}, nil
+}
+
+// commentRef identifies a review comment on a pull request
+type commentRef struct {
+	prRef
+	ID int64
+}
+
+// parseCommentRef parses a review comment reference, either a comment URL such as
+// https://github.com/owner/name/pull/1#discussion_r123, or an owner/name#number reference
+// combined with the comment ID given by the --comment flag
+func parseCommentRef(s string, commentID int64) (commentRef, error) {
+	if strings.Contains(s, "://") {
+		if commentID != 0 {
+			return commentRef{}, usageErrorf("--comment cannot be combined with a comment URL")
+		}
+		return parseCommentURL(s)
+	}
+
+	ref, err := parsePRRef(s)
+	if err != nil {
+		return commentRef{}, err
+	}
+	if commentID == 0 {
+		return commentRef{}, usageErrorf("--comment is required unless a comment URL is given")
+	}
+
+	return commentRef{prRef: ref, ID: commentID}, nil
+}
+
+// parseCommentURL parses a review comment URL such as https://github.com/owner/name/pull/1#discussion_r123
+func parseCommentURL(s string) (commentRef, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return commentRef{}, usageErrorf("invalid comment URL %q: %v", s, err)
+	}
+
+	// Path is /owner/name/pull/number, optionally followed by /files or /changes
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 4 || parts[2] != "pull" {
+		return commentRef{}, usageErrorf("invalid comment URL %q: expected a pull request URL", s)
+	}
+	number, err := strconv.Atoi(parts[3])
+	if err != nil || number <= 0 {
+		return commentRef{}, usageErrorf("invalid comment URL %q: bad pull request number", s)
+	}
+
+	id, ok := commentFragment(u.Fragment)
+	if !ok {
+		return commentRef{}, usageErrorf("invalid comment URL %q: missing #discussion_r<id> fragment", s)
+	}
+
+	return commentRef{
+		prRef: prRef{repoRef: repoRef{Owner: parts[0], Name: parts[1]}, Number: number},
+		ID:    id,
+	}, nil
+}
+
+// commentFragment returns the review comment ID of a URL fragment, which is discussion_r<id> on the
+// conversation tab and r<id> on the files tab
+func commentFragment(fragment string) (int64, bool) {
+	fragment = strings.TrimPrefix(fragment, "discussion_")
+	if !strings.HasPrefix(fragment, "r") {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(strings.TrimPrefix(fragment, "r"), 10, 64)
+	return id, err == nil && id > 0
+}
+
+// targetRef identifies a repository, pull request or review comment.
+// Number and CommentID are zero when not part of the reference.
+type targetRef struct {
+	repoRef
+	Number    int
+	CommentID int64
+}
+
+// parseTargetRef parses a repository, pull request or comment reference in any of the forms
+// owner/name, owner/name#number, or a GitHub URL of a repository, pull request or comment. URLs with
+// other fragments, e.g. #issuecomment-<id>, refer to their pull request. A non-zero commentID from the
+// --comment flag narrows the reference to that comment.
+func parseTargetRef(s string, commentID int64) (targetRef, error) {
+	var ref targetRef
+
+	switch {
+	case strings.Contains(s, "://"):
+		u, err := url.Parse(s)
+		if err != nil {
+			return targetRef{}, usageErrorf("invalid URL %q: %v", s, err)
+		}
+		if _, ok := commentFragment(u.Fragment); ok {
+			comment, err := parseCommentURL(s)
+			if err != nil {
+				return targetRef{}, err
+			}
+			ref = targetRef{repoRef: comment.repoRef, Number: comment.Number, CommentID: comment.ID}
+			break
+		}
+
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if len(parts) < 2 {
+			return targetRef{}, usageErrorf("invalid URL %q: expected a repository URL", s)
+		}
+		ref.repoRef = repoRef{Owner: parts[0], Name: parts[1]}
+		if len(parts) >= 4 && parts[2] == "pull" {
+			number, err := strconv.Atoi(parts[3])
+			if err != nil || number <= 0 {
+				return targetRef{}, usageErrorf("invalid URL %q: bad pull request number", s)
+			}
+			ref.Number = number
+		}
+	case strings.Contains(s, "#"):
+		pr, err := parsePRRef(s)
+		if err != nil {
+			return targetRef{}, err
+		}
+		ref = targetRef{repoRef: pr.repoRef, Number: pr.Number}
+	default:
+		repo, err := parseRepoRef(s)
+		if err != nil {
+			return targetRef{}, err
+		}
+		ref.repoRef = repo
+	}
+
+	if commentID != 0 {
+		if ref.CommentID != 0 {
+			return targetRef{}, usageErrorf("--comment cannot be combined with a comment URL")
+		}
+		ref.CommentID = commentID
+	}
+
+	return ref, nil
+}
@@ -0,0 +1,36 @@
+package cli
+
+import "testing"
+
+func TestParseTargetRef(t *testing.T) {
+	tests := []struct {
+		ref     string
+		want    targetRef
+		wantErr bool
+	}{
+		{ref: "acme/api", want: targetRef{repoRef: repoRef{Owner: "acme", Name: "api"}}},
+		{ref: "acme/api#42", want: targetRef{repoRef: repoRef{Owner: "acme", Name: "api"}, Number: 42}},
+		{ref: "https://github.com/acme/api", want: targetRef{repoRef: repoRef{Owner: "acme", Name: "api"}}},
+		{ref: "https://github.com/acme/api/pull/42", want: targetRef{repoRef: repoRef{Owner: "acme", Name: "api"}, Number: 42}},
+		{ref: "https://github.com/acme/api/pull/42#discussion_r123", want: targetRef{repoRef: repoRef{Owner: "acme", Name: "api"}, Number: 42, CommentID: 123}},
+		{ref: "https://github.com/acme/api/pull/42/files#r123", want: targetRef{repoRef: repoRef{Owner: "acme", Name: "api"}, Number: 42, CommentID: 123}},
+		{ref: "https://github.com/acme/api/pull/42#issuecomment-34", want: targetRef{repoRef: repoRef{Owner: "acme", Name: "api"}, Number: 42}},
+		{ref: "https://github.com/acme/api/pull/42#pullrequestreview-56", want: targetRef{repoRef: repoRef{Owner: "acme", Name: "api"}, Number: 42}},
+		{ref: "https://github.com/acme/api/pull/42#event-78", want: targetRef{repoRef: repoRef{Owner: "acme", Name: "api"}, Number: 42}},
+		{ref: "https://github.com/acme/api#readme", want: targetRef{repoRef: repoRef{Owner: "acme", Name: "api"}}},
+		{ref: "https://github.com/acme/api#discussion_r123", wantErr: true},
+		{ref: "https://github.com/acme/api/pull/none#event-78", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := parseTargetRef(tt.ref, 0)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTargetRef() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseTargetRef() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
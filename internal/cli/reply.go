@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stefrushxyz/nitpick/internal/stats"
+)
+
+// newReplyCommand creates the reply command
+func newReplyCommand() *cobra.Command {
+	var commentID int64
+	var message string
+
+	cmd := &cobra.Command{
+		Use:   "reply (comment-url | owner/repo#N --comment ID) [-m message]",
+		Short: "Reply to a review comment",
+		Long: `Post a reply in the thread of a review comment, identified by its URL or by pull request and --comment ID.
+The reply body is read from stdin when -m is omitted or set to "-". The URL of the new reply is printed to stdout.`,
+		Args: exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, err := parseCommentRef(args[0], commentID)
+			if err != nil {
+				return err
+			}
+
+			body := message
+			if body == "" || body == "-" {
+				input, err := io.ReadAll(cmd.InOrStdin())
+				if err != nil {
+					return fmt.Errorf("failed to read reply from stdin: %w", err)
+				}
+				body = string(input)
+			}
+			if strings.TrimSpace(body) == "" {
+				return usageErrorf("reply body is empty")
+			}
+
+			client, err := newProvider(cmd)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := commandContext(cmd, client)
+			defer cancel()
+
+			reply, err := client.Reply(ctx, ref.Owner, ref.Name, ref.Number, ref.ID, body)
+			if err != nil {
+				return err
+			}
+			stats.Record(stats.EventReply, ref.repoRef.String(), 1)
+
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), reply.GetHTMLURL())
+			return err
+		},
+	}
+
+	cmd.Flags().Int64Var(&commentID, "comment", 0, "ID of the review comment to reply to")
+	cmd.Flags().StringVarP(&message, "message", "m", "", `reply body ("-" or omitted to read from stdin)`)
+	addTimeoutFlag(cmd)
+
+	return cmd
+}
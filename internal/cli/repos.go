@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/spf13/cobra"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/provider"
+)
+
+// repoRecord is the headless representation of a repository
+type repoRecord struct {
+	FullName    string `json:"full_name"`
+	Owner       string `json:"owner"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Language    string `json:"language"`
+	Private     bool   `json:"private"`
+	Fork        bool   `json:"fork"`
+	UpdatedAt   string `json:"updated_at,omitempty"`
+	URL         string `json:"url"`
+}
+
+// newRepoRecord converts a GitHub repository into a repoRecord
+func newRepoRecord(repo *github.Repository) repoRecord {
+	record := repoRecord{
+		FullName:    repo.GetFullName(),
+		Owner:       repo.GetOwner().GetLogin(),
+		Name:        repo.GetName(),
+		Description: repo.GetDescription(),
+		Language:    repo.GetLanguage(),
+		Private:     repo.GetPrivate(),
+		Fork:        repo.GetFork(),
+		URL:         repo.GetHTMLURL(),
+	}
+	if repo.UpdatedAt != nil {
+		record.UpdatedAt = repo.UpdatedAt.Format(time.RFC3339)
+	}
+	return record
+}
+
+// newReposCommand creates the repos command
+func newReposCommand() *cobra.Command {
+	var output outputFlags
+	var list listFlags
+
+	cmd := &cobra.Command{
+		Use:   "repos",
+		Short: "List accessible repositories",
+		Long:  "List all repositories (personal and organizational) accessible with the configured token.",
+		Args:  noArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := output.resolve()
+			if err != nil {
+				return err
+			}
+
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			listOpts, err := list.options(ghclient.ListOptionsFromLimits(cfg.Limits.Repos))
+			if err != nil {
+				return err
+			}
+			client, err := newProviderFromConfig(cfg)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := commandContext(cmd, client)
+			defer cancel()
+
+			repos, err := client.ListRepos(ctx, listOpts)
+			if err != nil {
+				return err
+			}
+			repos = provider.DedupRepos(repos)
+
+			records := make([]repoRecord, len(repos))
+			for i, repo := range repos {
+				records[i] = newRepoRecord(repo)
+			}
+
+			header := []string{"repository", "language", "private", "updated", "description"}
+			return writeList(cmd.OutOrStdout(), format, records, header, func(r repoRecord) []string {
+				return []string{r.FullName, r.Language, strconv.FormatBool(r.Private), r.UpdatedAt, r.Description}
+			})
+		},
+	}
+
+	output.register(cmd)
+	list.register(cmd)
+
+	return cmd
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stefrushxyz/nitpick/internal/provider"
+	"github.com/stefrushxyz/nitpick/internal/stats"
+)
+
+// newResolveCommand creates the resolve command
+func newResolveCommand() *cobra.Command {
+	var commentID int64
+	var unresolve bool
+
+	cmd := &cobra.Command{
+		Use:   "resolve (comment-url | owner/repo#N --comment ID)",
+		Short: "Resolve the review thread containing a comment",
+		Long: `Resolve the review thread containing a review comment, identified by its URL
+(https://github.com/owner/repo/pull/N#discussion_rID) or by pull request and --comment ID.`,
+		Args: exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, err := parseCommentRef(args[0], commentID)
+			if err != nil {
+				return err
+			}
+
+			client, err := newProvider(cmd)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := commandContext(cmd, client)
+			defer cancel()
+
+			thread, err := provider.FindThread(ctx, client, ref.Owner, ref.Name, ref.Number, ref.ID)
+			if err != nil {
+				return err
+			}
+
+			action := "Resolved"
+			if unresolve {
+				action = "Unresolved"
+				if !thread.IsResolved {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Thread for comment %d is already unresolved\n", ref.ID)
+					return nil
+				}
+				err = client.Resolve(ctx, ref.Owner, ref.Name, ref.Number, thread.ID, false)
+			} else {
+				if thread.IsResolved {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Thread for comment %d is already resolved\n", ref.ID)
+					return nil
+				}
+				err = client.Resolve(ctx, ref.Owner, ref.Name, ref.Number, thread.ID, true)
+			}
+			if err != nil {
+				return err
+			}
+			if !unresolve {
+				stats.Record(stats.EventResolve, ref.repoRef.String(), 1)
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "%s thread for comment %d on %s\n", action, ref.ID, ref.prRef)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&commentID, "comment", 0, "ID of a comment in the thread")
+	cmd.Flags().BoolVar(&unresolve, "unresolve", false, "mark the thread as unresolved instead")
+	addTimeoutFlag(cmd)
+
+	return cmd
+}
@@ -0,0 +1,410 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	"github.com/stefrushxyz/nitpick/internal/app"
+	"github.com/stefrushxyz/nitpick/internal/config"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/gitrepo"
+	"github.com/stefrushxyz/nitpick/internal/logging"
+	"github.com/stefrushxyz/nitpick/internal/provider"
+)
+
+// errMissingToken is returned when no GitHub token is configured
+var errMissingToken = errors.New(`please set GITHUB_TOKEN environment variable
+You can either:
+  1. Set environment variable: export GITHUB_TOKEN=your_token
+  2. Create a .env file with: GITHUB_TOKEN=your_token
+  3. Add "token: your_token" to ~/.config/nitpick/config.yml
+  4. Store it in the OS keyring: nitpick login
+You can create a personal access token at: https://github.com/settings/personal-access-tokens`)
+
+// Execute runs the nitpick command line and returns the process exit code
+func Execute() int {
+	root := NewRootCommand()
+	defer logging.Close()
+
+	err := root.Execute()
+	if err == nil {
+		return ExitOK
+	}
+
+	code, kind := classifyError(err)
+	slog.Error("command failed", "kind", kind, "exit_code", code, "error", err)
+
+	if jsonErrors, _ := root.PersistentFlags().GetBool("json-errors"); jsonErrors {
+		var record errorRecord
+		record.Error.Kind = kind
+		record.Error.Message = err.Error()
+		record.Error.ExitCode = code
+		_ = writeJSON(root.ErrOrStderr(), record)
+	} else {
+		fmt.Fprintln(root.ErrOrStderr(), "Error:", err)
+	}
+
+	return code
+}
+
+// tuiOptions holds the flags of the root command that configure the TUI
+type tuiOptions struct {
+	repo    string
+	pr      int
+	comment int64
+	popup   bool
+	height  int
+	board   bool
+}
+
+// NewRootCommand creates the root command, which launches the TUI when run without a subcommand
+func NewRootCommand() *cobra.Command {
+	var opts tuiOptions
+
+	root := &cobra.Command{
+		Use:           "nitpick [owner/name[#number] | url]",
+		Short:         "Browse GitHub pull request comments and generate AI prompts",
+		Args:          maxArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Long: `Browse GitHub pull request comments and generate AI prompts.
+
+Without a subcommand the interactive TUI is launched. When stdout is not a terminal, the
+headless equivalent of the starting view is printed instead: repos, prs for --repo,
+comments for --repo and --pr, or board for --board. Inside a project with a .nitpick.toml, --repo defaults to its repo.
+
+A repository, pull request or comment given as an argument (owner/name, owner/name#number, or
+its GitHub URL, such as https://github.com/owner/name/pull/123#discussion_r456) is opened
+directly, skipping the lists before it.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			if len(args) == 1 {
+				if opts.repo != "" || opts.pr != 0 {
+					return usageErrorf("a repository, pull request or comment argument cannot be combined with --repo or --pr")
+				}
+				ref, err := parseTargetRef(args[0], 0)
+				if err != nil {
+					return err
+				}
+				opts.repo, opts.pr, opts.comment = ref.repoRef.String(), ref.Number, ref.CommentID
+			}
+			if opts.popup && opts.repo != "" && opts.pr == 0 {
+				return usageErrorf("--popup is bound to a pull request: give --pr, or omit --repo to use your git checkout's")
+			}
+			// Without --pr, the popup opens the git checkout's pull request rather than the project's repo
+			if opts.repo == "" && !opts.board && !(opts.popup && opts.pr == 0) {
+				opts.repo = cfg.Project.Repo
+			}
+			if opts.pr != 0 && opts.repo == "" {
+				return usageErrorf("--pr requires --repo")
+			}
+			if opts.board && (opts.repo != "" || opts.popup) {
+				return usageErrorf("--board cannot be combined with --repo, --pr or --popup")
+			}
+			if !isTerminal(os.Stdout) {
+				return runHeadless(cmd, opts)
+			}
+			if needsSetup(cfg) {
+				if cfg, err = runSetup(cmd, cfg, false); err != nil {
+					return err
+				}
+			}
+			return runTUI(cfg, opts)
+		},
+	}
+
+	root.PersistentFlags().String("config", "", "path to the config file (default ~/.config/nitpick/config.yml)")
+	root.PersistentFlags().String("profile", "", "configuration profile to use (default $NITPICK_PROFILE or default_profile)")
+	root.PersistentFlags().Bool("json-errors", false, "report failures as JSON on stderr")
+	root.PersistentFlags().String("mock", os.Getenv("NITPICK_MOCK"), "answer GitHub API requests from the JSON fixtures of a directory (--mock=dir), or the built-in demo without one ($NITPICK_MOCK)")
+	root.PersistentFlags().Lookup("mock").NoOptDefVal = ghclient.DemoFixtures
+	root.PersistentFlags().String("log-file", os.Getenv("NITPICK_LOG_FILE"), "append API calls, timings, cache hits and errors to this file ($NITPICK_LOG_FILE)")
+	root.PersistentFlags().String("log-level", envOr("NITPICK_LOG_LEVEL", "info"), "log level: debug, info, warn or error ($NITPICK_LOG_LEVEL)")
+	root.PersistentFlags().String("pprof", "", "serve net/http/pprof at this address and log the timing of every API call")
+	root.PersistentFlags().Lookup("pprof").NoOptDefVal = "localhost:6060"
+	_ = root.PersistentFlags().MarkHidden("pprof")
+	root.PersistentPreRunE = func(cmd *cobra.Command, _ []string) error {
+		lookupAlias = aliasResolver(cmd)
+		return setupLogging(cmd)
+	}
+	root.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return usageError{err: err}
+	})
+
+	root.Flags().StringVar(&opts.repo, "repo", "", "open the TUI on a repository (owner/name or alias)")
+	root.Flags().IntVar(&opts.pr, "pr", 0, "open the TUI on a pull request's comments (requires --repo)")
+	root.Flags().BoolVar(&opts.popup, "popup", false, "compact single pull request layout for tmux display-popup, without the alternate screen")
+	root.Flags().IntVar(&opts.height, "height", 0, "maximum height of the --popup layout in lines (default the terminal's height)")
+	root.Flags().BoolVar(&opts.board, "board", false, "open the TUI on the workboard of pull requests needing your attention across repositories")
+
+	root.AddCommand(
+		newReposCommand(),
+		newPRsCommand(),
+		newBoardCommand(),
+		newCommentsCommand(),
+		newPromptCommand(),
+		newWatchCommand(),
+		newMCPCommand(),
+		newResolveCommand(),
+		newReplyCommand(),
+		newExportCommand(),
+		newOpenCommand(),
+		newWorktreeCommand(),
+		newDigestCommand(),
+		newLoginCommand(),
+		newLogoutCommand(),
+		newCacheCommand(),
+		newStatsCommand(),
+		newSetupCommand(),
+		newBookmarksCommand(),
+		newConfigCommand(),
+	)
+
+	return root
+}
+
+// setupLogging enables logging as selected by the --log-file and --log-level flags, and profiling as
+// selected by the hidden --pprof flag
+func setupLogging(cmd *cobra.Command) error {
+	path, _ := cmd.Root().PersistentFlags().GetString("log-file")
+	levelName, _ := cmd.Root().PersistentFlags().GetString("log-level")
+	pprofAddr, _ := cmd.Root().PersistentFlags().GetString("pprof")
+
+	level, err := logging.ParseLevel(levelName)
+	if err != nil {
+		return usageError{err: err}
+	}
+	if pprofAddr != "" {
+		// Profiling diagnoses slowness, which the timing of each API call explains
+		level = slog.LevelDebug
+		if path == "" {
+			path = defaultDebugLog()
+		}
+	}
+	if err := logging.Setup(path, level); err != nil {
+		return err
+	}
+
+	if pprofAddr != "" {
+		addr, err := logging.ServePprof(pprofAddr)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Serving pprof at http://%s/debug/pprof/ and API call stats at http://%s/debug/vars; logging API call timings to %s\n",
+			addr, addr, path)
+	}
+
+	slog.Info("starting", "command", cmd.CommandPath())
+	return nil
+}
+
+// defaultDebugLog returns the log file used by --pprof when no --log-file is given
+func defaultDebugLog() string {
+	dir, err := config.StateDir()
+	if err != nil {
+		return "nitpick-debug.log"
+	}
+	return filepath.Join(dir, "debug.log")
+}
+
+// envOr returns the value of the environment variable name, or fallback if it is unset
+func envOr(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// runTUI launches the interactive application
+func runTUI(cfg *config.Config, opts tuiOptions) error {
+	if cfg.Token == "" {
+		return errMissingToken
+	}
+
+	// Initialize the TUI application
+	application, err := app.New(cfg)
+	if err != nil {
+		return err
+	}
+	if opts.repo != "" {
+		ref, err := parseRepoRef(opts.repo)
+		if err != nil {
+			return err
+		}
+		application.Preselect(ref.Owner, ref.Name, opts.pr)
+		if opts.comment != 0 {
+			application.PreselectComment(opts.comment)
+		}
+	}
+	if opts.board {
+		application.ShowBoard()
+	}
+	checkout := detectCheckout(cfg)
+	if checkout != nil {
+		application.DetectCheckout(checkout)
+	}
+
+	var programOpts []tea.ProgramOption
+	if opts.popup {
+		if opts.pr == 0 && checkout == nil {
+			return usageErrorf("--popup needs --repo and --pr outside of a git checkout of a repository on %s", cfg.Host)
+		}
+		application.SetCompact(opts.height)
+	} else {
+		programOpts = append(programOpts, tea.WithAltScreen())
+	}
+	p := tea.NewProgram(application, programOpts...)
+
+	_, err = p.Run()
+	return err
+}
+
+// detectCheckout returns the git checkout of the working directory, or nil if there is none or its origin
+// remote is not on the configured host
+func detectCheckout(cfg *config.Config) *gitrepo.Checkout {
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	checkout, err := gitrepo.Detect(dir)
+	if err != nil {
+		slog.Debug("no git checkout detected", "dir", dir, "err", err)
+		return nil
+	}
+
+	hosts := []string{cfg.Host}
+	if u, err := url.Parse(cfg.BaseURL); err == nil && u.Host != "" {
+		hosts = append(hosts, u.Hostname())
+	}
+	for _, host := range hosts {
+		if strings.EqualFold(checkout.Host, host) {
+			return checkout
+		}
+	}
+
+	slog.Debug("git checkout is not on the configured host", "remote_host", checkout.Host, "host", cfg.Host)
+	return nil
+}
+
+// runHeadless prints the headless equivalent of the view the TUI would have opened
+func runHeadless(cmd *cobra.Command, opts tuiOptions) error {
+	args := []string{"repos"}
+	switch {
+	case opts.board:
+		args = []string{"board"}
+	case opts.pr != 0:
+		args = []string{"comments", fmt.Sprintf("%s#%d", opts.repo, opts.pr)}
+	case opts.repo != "":
+		args = []string{"prs", opts.repo}
+	}
+
+	sub, subArgs, err := cmd.Root().Find(args)
+	if err != nil {
+		return err
+	}
+	sub.SetContext(cmd.Context())
+
+	return sub.RunE(sub, subArgs)
+}
+
+// isTerminal reports whether f is attached to a terminal
+func isTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// loadConfig loads the configuration file and profile selected by the --config and --profile flags, in mock
+// mode when --mock is given
+func loadConfig(cmd *cobra.Command) (*config.Config, error) {
+	path, _ := cmd.Root().PersistentFlags().GetString("config")
+	profile, _ := cmd.Root().PersistentFlags().GetString("profile")
+	mock, _ := cmd.Root().PersistentFlags().GetString("mock")
+	cfg, err := config.Load(path, profile)
+	if err != nil {
+		return nil, err
+	}
+	if mock != "" {
+		cfg.UseMock(mock)
+	}
+	return cfg, nil
+}
+
+// aliasResolver returns a resolver of repository aliases that loads the configuration only once an
+// alias is looked up, so commands given owner/name references don't pay for it
+func aliasResolver(cmd *cobra.Command) func(string) (string, bool) {
+	loadAliases := sync.OnceValue(func() *config.Config {
+		cfg, err := loadConfig(cmd)
+		if err != nil {
+			// The command reports configuration errors itself when it loads the configuration
+			return &config.Config{}
+		}
+		return cfg
+	})
+	return func(alias string) (string, bool) {
+		return loadAliases().ResolveAlias(alias)
+	}
+}
+
+// newProvider creates the client of the configured provider
+func newProvider(cmd *cobra.Command) (provider.Provider, error) {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return newProviderFromConfig(cfg)
+}
+
+// newProviderFromConfig creates the client of the provider of an already loaded configuration
+func newProviderFromConfig(cfg *config.Config) (provider.Provider, error) {
+	if cfg.Token == "" {
+		return nil, errMissingToken
+	}
+	return provider.New(cfg)
+}
+
+// newClient creates a GitHub client from the configuration, for commands that only support GitHub
+func newClient(cmd *cobra.Command) (*ghclient.Client, error) {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return newClientFromConfig(cfg)
+}
+
+// newClientFromConfig creates a GitHub client from an already loaded configuration
+func newClientFromConfig(cfg *config.Config) (*ghclient.Client, error) {
+	if cfg.Provider != config.ProviderGitHub {
+		return nil, fmt.Errorf("this command supports only GitHub, not %s", cfg.Provider)
+	}
+	if cfg.Token == "" {
+		return nil, errMissingToken
+	}
+	return ghclient.New(ghclient.OptionsFromConfig(cfg))
+}
+
+// commandContext returns a context bounded by the command's --timeout flag, or the client's configured timeout
+func commandContext(cmd *cobra.Command, client provider.Provider) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(cmd.Context(), commandTimeout(cmd, client))
+}
+
+// commandTimeout returns the command's --timeout flag, or the client's configured timeout if unset
+func commandTimeout(cmd *cobra.Command, client provider.Provider) time.Duration {
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil || timeout <= 0 {
+		timeout = client.Timeout()
+	}
+	return timeout
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/stefrushxyz/nitpick/internal/config"
+	"github.com/stefrushxyz/nitpick/internal/wizard"
+)
+
+// newSetupCommand creates the setup command
+func newSetupCommand() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "setup",
+		Short: "Run the first-run setup wizard",
+		Long: `Run the setup wizard that also starts on the first launch without a token or config file: log in with
+a pasted token, the browser (device flow, requires oauth_client_id) or an existing GitHub CLI login, then
+pick a clipboard backend and a theme. The token is stored in the OS keyring and the rest in the config file.`,
+		Args: noArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			_, err = runSetup(cmd, cfg, force)
+			return err
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "overwrite an existing config file")
+
+	return cmd
+}
+
+// needsSetup reports whether the setup wizard should run before the TUI: there is no token,
+// no config file, and a terminal to run the wizard in
+func needsSetup(cfg *config.Config) bool {
+	if cfg.Token != "" || !isTerminal(os.Stdin) {
+		return false
+	}
+	_, err := os.Stat(cfg.File())
+	return errors.Is(err, os.ErrNotExist)
+}
+
+// runSetup runs the setup wizard, saves the choices and returns the reloaded configuration
+func runSetup(cmd *cobra.Command, cfg *config.Config, overwrite bool) (*config.Config, error) {
+	result, err := wizard.Run(cfg.Host, cfg.OAuthClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	initial := config.Initial{
+		Host:      cfg.Host,
+		Clipboard: result.Clipboard,
+		Theme:     result.Theme,
+	}
+	if err := config.StoreToken(config.ProviderGitHub, cfg.Host, result.Token); err != nil {
+		// Without a usable keyring, fall back to the private config file
+		fmt.Fprintf(cmd.ErrOrStderr(), "Could not store the token in the keyring (%v); saving it in the config file\n", err)
+		initial.Token = result.Token
+	}
+
+	if err := config.WriteInitial(cfg.File(), initial, overwrite); err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Saved settings to %s\n", cfg.File())
+
+	return cfg.Reload(cfg.Profile)
+}
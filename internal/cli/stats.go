@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stefrushxyz/nitpick/internal/stats"
+)
+
+// statsTotalRow is the repository column of the row holding the totals
+const statsTotalRow = "(total)"
+
+// statsRecord is the headless representation of a repository's usage stats
+type statsRecord struct {
+	Repo           string `json:"repo"`
+	Prompts        int    `json:"prompts"`
+	Resolved       int    `json:"resolved"`
+	Replies        int    `json:"replies"`
+	CommentsViewed int    `json:"comments_viewed"`
+	LastActivity   string `json:"last_activity,omitempty"`
+}
+
+// newStatsCommand creates the stats command
+func newStatsCommand() *cobra.Command {
+	var output outputFlags
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show local usage stats",
+		Long: `Show how many prompts were generated, threads resolved, replies posted and comments viewed, per repository
+and in total, most active repository first. The stats are recorded locally in the state directory and never sent anywhere.`,
+		Args: noArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format, err := output.resolve()
+			if err != nil {
+				return err
+			}
+
+			usage, err := stats.Load()
+			if err != nil {
+				return err
+			}
+
+			var records []statsRecord
+			for _, entry := range usage.ByActivity() {
+				records = append(records, statsRecord{
+					Repo:           entry.Repo,
+					Prompts:        entry.Prompts,
+					Resolved:       entry.Resolved,
+					Replies:        entry.Replies,
+					CommentsViewed: entry.CommentsViewed,
+					LastActivity:   entry.LastActivity.Format(time.RFC3339),
+				})
+			}
+			records = append(records, statsRecord{
+				Repo:           statsTotalRow,
+				Prompts:        usage.Total.Prompts,
+				Resolved:       usage.Total.Resolved,
+				Replies:        usage.Total.Replies,
+				CommentsViewed: usage.Total.CommentsViewed,
+			})
+
+			header := []string{"repo", "prompts", "resolved", "replies", "comments_viewed", "last_activity"}
+			return writeList(cmd.OutOrStdout(), format, records, header, func(r statsRecord) []string {
+				return []string{
+					r.Repo, strconv.Itoa(r.Prompts), strconv.Itoa(r.Resolved), strconv.Itoa(r.Replies),
+					strconv.Itoa(r.CommentsViewed), r.LastActivity,
+				}
+			})
+		},
+	}
+
+	output.register(cmd)
+
+	return cmd
+}
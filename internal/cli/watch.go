@@ -0,0 +1,248 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/spf13/cobra"
+	"github.com/stefrushxyz/nitpick/internal/notify"
+	"github.com/stefrushxyz/nitpick/internal/provider"
+	"github.com/stefrushxyz/nitpick/internal/webhook"
+)
+
+// newWatchCommand creates the watch command
+func newWatchCommand() *cobra.Command {
+	var interval time.Duration
+	var showExisting bool
+	var asJSON bool
+	var webhookURL string
+	var webhookFormat string
+	var bell bool
+	var desktop bool
+
+	cmd := &cobra.Command{
+		Use:   "watch owner/repo#N",
+		Short: "Print new review comments on a pull request as they arrive",
+		Long: `Poll a pull request and print each newly arrived review comment (author, file, summary and URL)
+until interrupted. Comments that already exist when watching starts are skipped unless --existing is set.
+
+With a webhook configured (webhook.url in config.yml, or --webhook), each new comment is also posted to it
+as a Slack or Discord message, or as JSON for other receivers, so others can be pinged as well.
+--bell rings the terminal bell and --desktop shows a desktop notification when comments arrive
+(defaults from notify.bell and notify.desktop in config.yml).`,
+		Args: exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, err := parsePRRef(args[0])
+			if err != nil {
+				return err
+			}
+			if interval < time.Second {
+				return usageErrorf("--interval must be at least 1s")
+			}
+
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			client, err := newProviderFromConfig(cfg)
+			if err != nil {
+				return err
+			}
+
+			var notifier *webhook.Notifier
+			if !cmd.Flags().Changed("webhook") {
+				webhookURL = cfg.Webhook.URL
+			}
+			if !cmd.Flags().Changed("webhook-format") {
+				webhookFormat = cfg.Webhook.Format
+			}
+			if !cmd.Flags().Changed("bell") {
+				bell = cfg.Notify.Bell
+			}
+			if !cmd.Flags().Changed("desktop") {
+				desktop = cfg.Notify.Desktop
+			}
+			if webhookURL != "" {
+				if notifier, err = webhook.New(webhookURL, webhookFormat, commandTimeout(cmd, client)); err != nil {
+					return usageError{err: err}
+				}
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+
+			w := &watcher{
+				client:   client,
+				timeout:  commandTimeout(cmd, client),
+				ref:      ref,
+				seen:     make(map[int64]bool),
+				out:      cmd.OutOrStdout(),
+				errOut:   cmd.ErrOrStderr(),
+				json:     asJSON,
+				notifier: notifier,
+				bell:     bell,
+				desktop:  desktop,
+			}
+
+			// Record the current comments so only new arrivals are printed
+			if err := w.poll(ctx, showExisting); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Watching %s for new review comments every %s (Ctrl+C to stop)\n", ref, interval)
+
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+					// Keep watching through transient failures
+					if err := w.poll(ctx, true); err != nil && ctx.Err() == nil {
+						fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %v\n", err)
+					}
+				}
+			}
+		},
+	}
+
+	cmd.Flags().DurationVar(&interval, "interval", 30*time.Second, "polling interval")
+	cmd.Flags().BoolVar(&showExisting, "existing", false, "print comments that already exist when watching starts")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print each comment as a line of JSON")
+	cmd.Flags().BoolVar(&bell, "bell", false, "ring the terminal bell when new comments arrive (default notify.bell from config)")
+	cmd.Flags().BoolVar(&desktop, "desktop", false, "show a desktop notification when new comments arrive (default notify.desktop from config)")
+	cmd.Flags().StringVar(&webhookURL, "webhook", "", "also post new comments to this webhook URL (default webhook.url from config; empty disables)")
+	cmd.Flags().StringVar(&webhookFormat, "webhook-format", "", "webhook payload format: auto, slack, discord or json (default webhook.format from config)")
+	addTimeoutFlag(cmd)
+
+	return cmd
+}
+
+// watcher tracks which comments on a pull request have already been reported
+type watcher struct {
+	client   provider.Provider
+	timeout  time.Duration // Timeout of each poll
+	ref      prRef
+	seen     map[int64]bool
+	out      io.Writer
+	errOut   io.Writer // Receives warnings about failed webhook notifications
+	json     bool
+	notifier *webhook.Notifier // Notified of new comments; nil when no webhook is configured
+	bell     bool              // Ring the terminal bell when comments arrive
+	desktop  bool              // Show a desktop notification when comments arrive
+}
+
+// poll fetches the PR's comments and records unseen ones, printing them when report is set
+func (w *watcher) poll(ctx context.Context, report bool) error {
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	comments, err := w.client.ListComments(ctx, w.ref.Owner, w.ref.Name, w.ref.Number, allPages)
+	if err != nil {
+		return err
+	}
+
+	// Comments are listed most recently updated first, so print them oldest first
+	var arrived []*github.PullRequestComment
+	for i := len(comments) - 1; i >= 0; i-- {
+		comment := comments[i]
+		if w.seen[comment.GetID()] {
+			continue
+		}
+		w.seen[comment.GetID()] = true
+
+		if report {
+			if err := w.print(comment); err != nil {
+				return err
+			}
+			w.notify(ctx, comment)
+			arrived = append(arrived, comment)
+		}
+	}
+
+	w.alert(arrived)
+	return nil
+}
+
+// alert rings the bell and shows a desktop notification for the comments that arrived in a poll, if enabled
+func (w *watcher) alert(arrived []*github.PullRequestComment) {
+	if len(arrived) == 0 {
+		return
+	}
+
+	if w.bell {
+		if err := notify.Bell(w.errOut); err != nil {
+			fmt.Fprintf(w.errOut, "Warning: failed to ring the bell: %v\n", err)
+		}
+	}
+	if w.desktop {
+		title := fmt.Sprintf("New review comment on %s", w.ref)
+		if len(arrived) > 1 {
+			title = fmt.Sprintf("%d new review comments on %s", len(arrived), w.ref)
+		}
+		latest := newCommentRecord(arrived[len(arrived)-1])
+		body := fmt.Sprintf("%s: %s", latest.Reviewer, latest.Summary)
+		if err := notify.Desktop(title, body); err != nil {
+			fmt.Fprintf(w.errOut, "Warning: %v\n", err)
+		}
+	}
+}
+
+// notify posts a new comment to the webhook, if one is configured. A failure is reported as a warning, so an
+// unreachable webhook does not stop the watch.
+func (w *watcher) notify(ctx context.Context, comment *github.PullRequestComment) {
+	if w.notifier == nil {
+		return
+	}
+
+	record := newCommentRecord(comment)
+	event := webhook.Event{
+		Repo:     w.ref.repoRef.String(),
+		PR:       w.ref.Number,
+		ID:       record.ID,
+		Reviewer: record.Reviewer,
+		Location: record.Path,
+		Summary:  record.Summary,
+		Body:     record.Body,
+		URL:      record.URL,
+	}
+	if record.Path != "" && record.Line != 0 {
+		event.Location = fmt.Sprintf("%s:%d", record.Path, record.Line)
+	}
+
+	if err := w.notifier.Notify(ctx, event); err != nil {
+		fmt.Fprintf(w.errOut, "Warning: failed to notify webhook of comment %d: %v\n", record.ID, err)
+	}
+}
+
+// print writes a single comment to the output
+func (w *watcher) print(comment *github.PullRequestComment) error {
+	record := newCommentRecord(comment)
+
+	if w.json {
+		return json.NewEncoder(w.out).Encode(record)
+	}
+
+	location := record.Path
+	if location != "" && record.Line != 0 {
+		location = fmt.Sprintf("%s:%d", location, record.Line)
+	}
+	if location == "" {
+		location = "(general)"
+	}
+
+	created := ""
+	if comment.CreatedAt != nil {
+		created = comment.CreatedAt.Local().Format("2006-01-02 15:04")
+	}
+
+	_, err := fmt.Fprintf(w.out, "%s  %s  %s\n  %s\n  %s\n", created, record.Reviewer, location, record.Summary, record.URL)
+	return err
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stefrushxyz/nitpick/internal/clipboard"
+	"github.com/stefrushxyz/nitpick/internal/provider"
+)
+
+// newWorktreeCommand creates the worktree command
+func newWorktreeCommand() *cobra.Command {
+	var copyPath bool
+
+	cmd := &cobra.Command{
+		Use:   "worktree owner/repo#N [--copy]",
+		Short: "Check out a pull request's head branch in a dedicated git worktree",
+		Long: `Create a git worktree of the current checkout with the head branch of a pull request checked out,
+and print its path to stdout. Run it inside a checkout of the pull request's repository.
+
+Worktrees are created as <repo>-pr-<N> in the configured worktree_dir, or next to the checkout when it is
+not set. Pull requests from forks are checked out on a pr-<N> branch. An existing worktree is reused.
+Use --copy to copy the path to the clipboard as well, e.g. for cd "$(nitpick worktree owner/repo#1)".`,
+		Args: exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, err := parsePRRef(args[0])
+			if err != nil {
+				return err
+			}
+
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return err
+			}
+			checkout := detectCheckout(cfg)
+			if checkout == nil || !checkout.Is(ref.Owner, ref.Name) {
+				return fmt.Errorf("run nitpick worktree in a checkout of %s", ref.repoRef)
+			}
+
+			client, err := newProviderFromConfig(cfg)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := commandContext(cmd, client)
+			defer cancel()
+
+			pr, err := client.GetChange(ctx, ref.Owner, ref.Name, ref.Number)
+			if err != nil {
+				return err
+			}
+
+			result, err := provider.CreateWorktree(cfg, checkout, pr)
+			if err != nil {
+				return err
+			}
+			if result.Created {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Created worktree for %s on branch %s\n", ref, result.Branch)
+			}
+
+			if copyPath {
+				if err := clipboard.CopyWith(cfg.Clipboard.Backend, result.Path); err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.ErrOrStderr(), "Path copied to clipboard")
+			}
+
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), result.Path)
+			return err
+		},
+	}
+
+	cmd.Flags().BoolVar(&copyPath, "copy", false, "also copy the worktree's path to the clipboard")
+	addTimeoutFlag(cmd)
+
+	return cmd
+}
@@ -2,12 +2,76 @@ package clipboard
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 	"runtime"
+	"strings"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+)
+
+// Clipboard backends that can be selected explicitly
+const (
+	BackendAuto   = "auto"
+	BackendPbcopy = "pbcopy"
+	BackendXsel   = "xsel"
+	BackendXclip  = "xclip"
+	BackendWlCopy = "wl-copy"
+	BackendClip   = "clip"
+	BackendTmux   = "tmux"
+	BackendOSC52  = "osc52"
 )
 
+// Backends lists every supported clipboard backend
+var Backends = []string{
+	BackendAuto, BackendPbcopy, BackendXsel, BackendXclip, BackendWlCopy, BackendClip, BackendTmux, BackendOSC52,
+}
+
 // Copy copies the given text to the system clipboard
 func Copy(text string) error {
+	return CopyWith(BackendAuto, text)
+}
+
+// CopyWith copies the given text using the named backend, detecting one when backend is auto or empty
+func CopyWith(backend, text string) error {
+	var cmd *exec.Cmd
+
+	switch backend {
+	case BackendAuto, "":
+		return copyAuto(text)
+	case BackendTmux:
+		return CopyTmux(text)
+	case BackendOSC52:
+		return CopyOSC52(text)
+	case BackendPbcopy, BackendClip, BackendWlCopy:
+		cmd = exec.Command(backend)
+	case BackendXsel:
+		cmd = exec.Command("xsel", "--clipboard", "--input")
+	case BackendXclip:
+		cmd = exec.Command("xclip", "-selection", "clipboard")
+	default:
+		return fmt.Errorf("unknown clipboard backend %q (expected one of %s)", backend, strings.Join(Backends, ", "))
+	}
+
+	return run(cmd, text)
+}
+
+// CopyOSC52 copies the given text through the OSC 52 terminal escape sequence,
+// which works over SSH in terminals that support it
+func CopyOSC52(text string) error {
+	seq := osc52.New(text)
+	if os.Getenv("TMUX") != "" {
+		seq = seq.Tmux()
+	}
+
+	if _, err := seq.WriteTo(os.Stderr); err != nil {
+		return fmt.Errorf("failed to write OSC 52 sequence: %w", err)
+	}
+	return nil
+}
+
+// copyAuto copies the given text with the clipboard utility of the current platform
+func copyAuto(text string) error {
 	var cmd *exec.Cmd
 
 	switch runtime.GOOS {
@@ -27,6 +91,11 @@ func Copy(text string) error {
 		return fmt.Errorf("unsupported operating system: %s", runtime.GOOS)
 	}
 
+	return run(cmd, text)
+}
+
+// run pipes the given text into a clipboard command
+func run(cmd *exec.Cmd, text string) error {
 	cmd.Stdin = nil
 	stdin, err := cmd.StdinPipe()
 	if err != nil {
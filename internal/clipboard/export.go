@@ -0,0 +1,37 @@
+package clipboard
+
+import (
+	"fmt"
+	"os"
+)
+
+// ExportToFile writes text to a new file in the system temp directory and returns its path
+func ExportToFile(text string) (string, error) {
+	file, err := os.CreateTemp("", "nitpick-prompt-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create export file: %w", err)
+	}
+
+	if _, err := file.WriteString(text); err != nil {
+		file.Close()
+		return "", fmt.Errorf("failed to write export file: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to close export file: %w", err)
+	}
+
+	return file.Name(), nil
+}
+
+// FormatSize formats a byte count for display in status messages
+func FormatSize(n int) string {
+	switch {
+	case n >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1024*1024))
+	case n >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(n)/1024)
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
@@ -0,0 +1,100 @@
+package clipboard
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ScratchFileName is the name of the scratch file written by CopyEverywhere
+const ScratchFileName = "nitpick-prompt.md"
+
+// Result reports the outcome of writing to a single copy target
+type Result struct {
+	Target string
+	Err    error
+}
+
+// CopyTmux loads the given text into the tmux paste buffer
+func CopyTmux(text string) error {
+	if os.Getenv("TMUX") == "" {
+		return fmt.Errorf("not running inside tmux")
+	}
+
+	cmd := exec.Command("tmux", "load-buffer", "-")
+	cmd.Stdin = strings.NewReader(text)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("tmux load-buffer failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
+
+// ScratchPath returns the path of the scratch file written by CopyEverywhere in dir
+func ScratchPath(dir string) string {
+	return filepath.Join(dir, ScratchFileName)
+}
+
+// WriteScratch replaces the scratch file in dir with the given text and returns its path. The text is
+// written to a new file that is renamed over the scratch file, so a symlink planted in its place is
+// replaced rather than followed.
+func WriteScratch(dir, text string) (string, error) {
+	if dir == "" {
+		return "", fmt.Errorf("no directory for the scratch file")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create scratch file directory: %w", err)
+	}
+
+	file, err := os.CreateTemp(dir, ScratchFileName+".*")
+	if err != nil {
+		return "", fmt.Errorf("failed to write scratch file: %w", err)
+	}
+	_, err = file.WriteString(text)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	path := ScratchPath(dir)
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write scratch file: %w", err)
+	}
+	return path, nil
+}
+
+// TooLarge returns an error if text exceeds the clipboard size limit, where a limit of 0 disables it
+func TooLarge(text string, limit int) error {
+	if limit > 0 && len(text) > limit {
+		return fmt.Errorf("%s exceeds the %s limit", FormatSize(len(text)), FormatSize(limit))
+	}
+	return nil
+}
+
+// CopyEverywhere writes the given text to the clipboard using the named backend, the tmux buffer
+// and the scratch file in dir. Every target is attempted; the returned results are in that order.
+// Text exceeding the clipboard size limit only goes to the scratch file.
+func CopyEverywhere(backend string, limit int, dir, text string) []Result {
+	var results []Result
+	if err := TooLarge(text, limit); err != nil {
+		results = []Result{{Target: "clipboard", Err: err}, {Target: "tmux", Err: err}}
+	} else {
+		results = []Result{
+			{Target: "clipboard", Err: CopyWith(backend, text)},
+			{Target: "tmux", Err: CopyTmux(text)},
+		}
+	}
+
+	path, err := WriteScratch(dir, text)
+	if err == nil {
+		results = append(results, Result{Target: path})
+	} else {
+		results = append(results, Result{Target: "scratch file", Err: err})
+	}
+
+	return results
+}
@@ -0,0 +1,78 @@
+package clipboard
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteScratch(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+
+	path, err := WriteScratch(dir, "first")
+	if err != nil {
+		t.Fatalf("WriteScratch() error = %v", err)
+	}
+	if path != ScratchPath(dir) {
+		t.Errorf("WriteScratch() = %q, want %q", path, ScratchPath(dir))
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("scratch file permissions = %o, want 600", perm)
+	}
+
+	if _, err := WriteScratch(dir, "second"); err != nil {
+		t.Fatalf("WriteScratch() error = %v", err)
+	}
+	if content, _ := os.ReadFile(path); string(content) != "second" {
+		t.Errorf("scratch file = %q, want %q", content, "second")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("scratch directory holds %d files, want 1", len(entries))
+	}
+}
+
+func TestWriteScratchReplacesSymlink(t *testing.T) {
+	dir := t.TempDir()
+	victim := filepath.Join(t.TempDir(), "victim")
+	if err := os.WriteFile(victim, []byte("keep"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(victim, ScratchPath(dir)); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+
+	if _, err := WriteScratch(dir, "prompt"); err != nil {
+		t.Fatalf("WriteScratch() error = %v", err)
+	}
+	if content, _ := os.ReadFile(victim); string(content) != "keep" {
+		t.Errorf("symlink target = %q, want it untouched", content)
+	}
+	info, err := os.Lstat(ScratchPath(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		t.Error("scratch file is still a symlink")
+	}
+}
+
+func TestCopyEverywhereOverLimit(t *testing.T) {
+	dir := t.TempDir()
+
+	results := CopyEverywhere(BackendAuto, 4, dir, "too long")
+	if len(results) != 3 {
+		t.Fatalf("CopyEverywhere() returned %d results, want 3", len(results))
+	}
+	for _, result := range results[:2] {
+		if result.Err == nil {
+			t.Errorf("%s: copied text over the limit", result.Target)
+		}
+	}
+	if results[2].Err != nil || results[2].Target != ScratchPath(dir) {
+		t.Errorf("scratch file result = %+v, want it written to %s", results[2], ScratchPath(dir))
+	}
+}
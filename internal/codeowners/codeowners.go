@@ -0,0 +1,123 @@
+package codeowners
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Locations lists where a CODEOWNERS file is looked up, relative to the repository root, in order of
+// precedence: GitHub's locations, then GitLab's
+var Locations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS"}
+
+// Rule assigns owners to the files matching a pattern
+type Rule struct {
+	Pattern string
+	Owners  []string // Users (@user), teams (@org/team) or emails; empty leaves matching files unowned
+	re      *regexp.Regexp
+}
+
+// File is a parsed CODEOWNERS file
+type File struct {
+	Rules []Rule
+}
+
+// Parse parses the content of a CODEOWNERS file. GitLab section headers such as [Docs] are skipped, so
+// their rules apply as if they were not in a section.
+func Parse(content []byte) *File {
+	f := &File{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+		pattern := strings.ReplaceAll(fields[0], `\#`, "#")
+		f.Rules = append(f.Rules, Rule{Pattern: pattern, Owners: fields[1:], re: compile(pattern)})
+	}
+	return f
+}
+
+// Load reads the CODEOWNERS file of the checkout at root from the first location that has one. It
+// returns nil without an error when the repository has none.
+func Load(root string) (*File, error) {
+	for _, location := range Locations {
+		content, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(location)))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return Parse(content), nil
+	}
+	return nil, nil
+}
+
+// Owners returns the owners of the file at path, relative to the repository root, by the last matching
+// rule as GitHub and GitLab apply them. It returns nil for unowned files and for a nil File.
+func (f *File) Owners(path string) []string {
+	if f == nil {
+		return nil
+	}
+	path = strings.TrimPrefix(path, "/")
+	for i := len(f.Rules) - 1; i >= 0; i-- {
+		if f.Rules[i].re.MatchString(path) {
+			return f.Rules[i].Owners
+		}
+	}
+	return nil
+}
+
+// compile translates a gitignore-style CODEOWNERS pattern into a regular expression matching the paths
+// of the files it covers, including every file under a matching directory
+func compile(pattern string) *regexp.Regexp {
+	// Patterns with a slash other than a trailing one are relative to the root; others match at any depth
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.Trim(pattern, "/")
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			b.WriteString("[^/]*")
+		case pattern[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	switch {
+	case dirOnly:
+		b.WriteString("/.*$")
+	case strings.HasSuffix(pattern, "/*"):
+		// docs/* covers the files directly in docs, not those in its subdirectories
+		b.WriteString("$")
+	default:
+		b.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.MustCompile(b.String())
+}
@@ -0,0 +1,367 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultHost is the GitHub host used when none is configured
+const DefaultHost = "github.com"
+
+// DefaultPageSize is the number of results requested per API page
+const DefaultPageSize = 100
+
+// DefaultClipboardLimit is the default maximum payload size, in bytes, sent to the clipboard
+const DefaultClipboardLimit = 100 * 1024
+
+// DefaultTheme is the glamour style used to render markdown; auto picks dark or light by the terminal background
+const DefaultTheme = "auto"
+
+// ThemeHighContrast is the theme drawing the TUI and markdown in the terminal's own colors
+const ThemeHighContrast = "high-contrast"
+
+// Themes lists the built-in themes: the glamour styles and the high-contrast theme. The theme can also be the
+// path of a glamour style JSON file.
+var Themes = []string{"auto", "dark", "light", "dracula", "tokyo-night", "pink", "notty", "ascii", ThemeHighContrast}
+
+// DefaultTimeout bounds the API requests made for a single view or command
+const DefaultTimeout = 30 * time.Second
+
+// MinPollInterval is the shortest poll_interval accepted, keeping polling well within the API rate limit
+const MinPollInterval = 10 * time.Second
+
+// Config holds the user's settings
+type Config struct {
+	Provider       string             `yaml:"provider"`          // Code review provider: github, gitlab, bitbucket, gitea, azuredevops or gerrit
+	Token          string             `yaml:"token"`             // Personal access token for the provider
+	Host           string             `yaml:"host"`              // Provider host, e.g. github.com, a GitHub Enterprise, GitLab or Gitea hostname
+	BaseURL        string             `yaml:"base_url"`          // REST API base URL; overrides the URL derived from Host
+	Timeout        time.Duration      `yaml:"timeout"`           // Timeout for the API requests of a view or command
+	Retry          RetryConfig        `yaml:"retry"`             // Retries of API requests failing transiently
+	HTTP           HTTPConfig         `yaml:"http"`              // Proxy and TLS settings of GitHub API requests
+	OAuthClientID  string             `yaml:"oauth_client_id"`   // Client ID of the OAuth app used for device flow login
+	ShowReplies    bool               `yaml:"show_replies"`      // Whether reply comments are shown by default
+	Conversation   bool               `yaml:"conversation"`      // Whether pull requests' conversation comments are listed with their review comments
+	PollInterval   time.Duration      `yaml:"poll_interval"`     // How often the open pull request's comments are fetched again; 0 disables polling
+	PromptTemplate string             `yaml:"prompt_template"`   // Default prompt template (full or simple)
+	PromptOwners   bool               `yaml:"prompt_codeowners"` // Whether prompts name the CODEOWNERS of commented files
+	PromptBlame    bool               `yaml:"prompt_blame"`      // Whether prompts name the commits that last changed commented lines
+	PromptSymbols  bool               `yaml:"prompt_symbols"`    // Whether prompts quote the function enclosing commented lines
+	PromptContext  bool               `yaml:"prompt_context"`    // Whether prompts quote the file's lines around commented lines
+	PromptIssues   bool               `yaml:"prompt_issues"`     // Whether prompts quote the issues the pull request closes
+	ContextLines   int                `yaml:"context_lines"`     // Lines of the commented file shown around commented lines; 0 for none
+	PageSize       int                `yaml:"page_size"`         // Results requested per API page
+	Theme          string             `yaml:"theme"`             // Glamour style used to render markdown, or the high-contrast theme
+	ASCII          bool               `yaml:"ascii"`             // Whether the TUI draws only ASCII characters
+	Editor         string             `yaml:"editor"`            // Editor preset, URI or command template opening commented files; empty for $EDITOR
+	WorktreeDir    string             `yaml:"worktree_dir"`      // Directory of the worktrees created for pull requests; empty for the checkout's parent
+	TemplatesDir   string             `yaml:"templates_dir"`     // Directory searched for user prompt templates
+	CacheDir       string             `yaml:"cache_dir"`         // Directory for cached data
+	CacheTTL       CacheTTLConfig     `yaml:"cache_ttl"`         // How long cached API responses are reused
+	Clipboard      ClipboardConfig    `yaml:"clipboard"`
+	Webhook        WebhookConfig      `yaml:"webhook"`
+	Notify         NotifyConfig       `yaml:"notify"`
+	Jira           JiraConfig         `yaml:"jira"`
+	Linear         LinearConfig       `yaml:"linear"`
+	Limits         LimitsConfig       `yaml:"limits"`
+	Prefetch       PrefetchConfig     `yaml:"prefetch"`
+	Repos          map[string]Filters `yaml:"repos"`           // Default filters per repository (owner/name)
+	Aliases        map[string]string  `yaml:"aliases"`         // Short names for repositories, e.g. api: acme-corp/backend-api
+	Profiles       map[string]Profile `yaml:"profiles"`        // Named hosts or accounts selectable with --profile
+	DefaultProfile string             `yaml:"default_profile"` // Profile used when none is selected
+	Profile        string             `yaml:"-"`               // Name of the active profile, if any
+	Project        Project            `yaml:"-"`               // Settings from the working directory's .nitpick.toml
+	Mock           string             `yaml:"-"`               // JSON fixtures answering GitHub API requests instead of the network, set by --mock
+
+	file string // Path of the configuration file the settings were loaded from
+}
+
+// RetryConfig holds how API requests failing transiently, with a server error or a network timeout, are retried
+type RetryConfig struct {
+	Attempts int           `yaml:"attempts"` // Attempts per request, the first included; 1 disables retries
+	Backoff  time.Duration `yaml:"backoff"`  // Delay before the first retry, doubled before each next one
+}
+
+// ClipboardConfig holds the clipboard settings
+type ClipboardConfig struct {
+	Backend string `yaml:"backend"` // Clipboard backend, or auto to detect one
+	Limit   int    `yaml:"limit"`   // Maximum payload size in bytes before falling back to a file; 0 disables the limit
+}
+
+// HTTPConfig holds the proxy and TLS settings of the connections to the GitHub API, for corporate networks
+type HTTPConfig struct {
+	Proxy              string `yaml:"proxy"`                // Proxy URL, e.g. http://proxy.acme.com:3128; empty for HTTPS_PROXY and HTTP_PROXY
+	CACert             string `yaml:"ca_cert"`              // PEM file of CA certificates trusted besides the system's, e.g. a corporate root
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"` // Accept any TLS certificate; only to get past an intercepting proxy
+}
+
+// WebhookConfig holds the webhook that watch notifies of new review comments
+type WebhookConfig struct {
+	URL    string `yaml:"url"`    // Slack, Discord or other webhook URL; empty disables notifications
+	Format string `yaml:"format"` // Payload format: auto, slack, discord or json
+}
+
+// NotifyConfig holds how watch alerts you to new review comments
+type NotifyConfig struct {
+	Bell    bool `yaml:"bell"`    // Ring the terminal bell
+	Desktop bool `yaml:"desktop"` // Show a desktop notification (notify-send or osascript)
+}
+
+// JiraConfig holds the Jira site whose tickets, referenced by key in pull request titles and branches, are
+// shown with the pull request
+type JiraConfig struct {
+	BaseURL  string   `yaml:"base_url"` // Jira site, e.g. https://acme.atlassian.net; empty disables the integration
+	Email    string   `yaml:"email"`    // Account email of a Jira Cloud API token; empty sends token as a personal access token
+	Token    string   `yaml:"token"`    // Jira Cloud API token, or Server/Data Center personal access token
+	Projects []string `yaml:"projects"` // Project keys recognized in titles and branches; empty accepts any key
+	Prompt   bool     `yaml:"prompt"`   // Whether prompts include the linked tickets
+}
+
+// LinearConfig holds the Linear workspace whose issues, referenced by identifier in pull request titles,
+// branches and descriptions, are shown with the pull request
+type LinearConfig struct {
+	APIKey string   `yaml:"api_key"` // Personal API key; empty disables the integration
+	Teams  []string `yaml:"teams"`   // Team keys recognized in identifiers; empty accepts any key
+	Prompt bool     `yaml:"prompt"`  // Whether prompts include the linked issues
+}
+
+// CacheTTLConfig holds how long cached API responses of each kind are reused; 0 disables caching
+type CacheTTLConfig struct {
+	Repos    time.Duration `yaml:"repos"`
+	PRs      time.Duration `yaml:"prs"`
+	Comments time.Duration `yaml:"comments"`
+}
+
+// LimitsConfig holds the fetch limits of each list
+type LimitsConfig struct {
+	Repos    FetchLimits `yaml:"repos"`
+	PRs      FetchLimits `yaml:"prs"`
+	Comments FetchLimits `yaml:"comments"`
+}
+
+// PrefetchConfig holds how much the TUI fetches in the background, ahead of navigation
+type PrefetchConfig struct {
+	Concurrency int `yaml:"concurrency"` // Background fetches run at once
+	Comments    int `yaml:"comments"`    // Pull requests at the top of a list whose comments are prefetched; 0 disables
+}
+
+// DefaultPrefetchConcurrency is the number of background fetches run at once by default
+const DefaultPrefetchConcurrency = 4
+
+// FetchLimits bounds how much of a list is fetched
+type FetchLimits struct {
+	PerPage  int `yaml:"per_page"`  // Results per page; 0 uses page_size
+	MaxPages int `yaml:"max_pages"` // Pages fetched; 0 follows pagination to the end
+	MaxItems int `yaml:"max_items"` // Maximum number of results; 0 means no limit
+}
+
+// Default returns the configuration used when no settings are given
+func Default() *Config {
+	cfg := &Config{
+		Provider:       ProviderGitHub,
+		Host:           DefaultHost,
+		Timeout:        DefaultTimeout,
+		PromptTemplate: "full",
+		PageSize:       DefaultPageSize,
+		Theme:          DefaultTheme,
+		ContextLines:   5,
+		Retry: RetryConfig{
+			Attempts: 3,
+			Backoff:  500 * time.Millisecond,
+		},
+		Clipboard: ClipboardConfig{
+			Backend: "auto",
+			Limit:   DefaultClipboardLimit,
+		},
+		Webhook: WebhookConfig{
+			Format: "auto",
+		},
+		CacheTTL: CacheTTLConfig{
+			Repos:    time.Hour,
+			PRs:      5 * time.Minute,
+			Comments: 2 * time.Minute,
+		},
+		Limits: LimitsConfig{
+			Repos:    FetchLimits{},
+			PRs:      FetchLimits{MaxPages: 1},
+			Comments: FetchLimits{MaxPages: 1},
+		},
+		Prefetch: PrefetchConfig{
+			Concurrency: DefaultPrefetchConcurrency,
+			Comments:    5,
+		},
+	}
+	if dir, err := Dir(); err == nil {
+		cfg.TemplatesDir = filepath.Join(dir, "templates")
+	}
+	if dir, err := CacheDir(); err == nil {
+		cfg.CacheDir = dir
+	}
+	return cfg
+}
+
+// Path returns the path of the configuration file.
+// NITPICK_CONFIG overrides the default location of config.yml in the configuration directory.
+func Path() (string, error) {
+	if path := os.Getenv("NITPICK_CONFIG"); path != "" {
+		return path, nil
+	}
+
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.yml"), nil
+}
+
+// keyringToken looks up the token stored by nitpick login; a variable so tests stay off the OS keyring
+var keyringToken = KeyringToken
+
+// Load reads the configuration file at path, or the default path if empty, then applies the
+// selected profile, the working directory's .nitpick.toml and environment variable overrides.
+// An empty profile selects NITPICK_PROFILE or default_profile, if set. Without a configured
+// token, GITHUB_TOKEN is used for github.com, then the one stored in the OS keyring for the
+// provider's host. A missing file yields the defaults.
+func Load(path, profile string) (*Config, error) {
+	if path == "" {
+		var err error
+		path, err = Path()
+		if err != nil {
+			return nil, fmt.Errorf("failed to locate config file: %w", err)
+		}
+	}
+
+	cfg := Default()
+
+	content, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		// No config file; defaults and environment only
+	case err != nil:
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	default:
+		if err := cfg.decodeFile(path, content); err != nil {
+			return nil, err
+		}
+	}
+	cfg.file = path
+
+	cfg.applyProviderHost()
+	if name := cfg.selectProfile(profile); name != "" {
+		if err := cfg.applyProfile(name); err != nil {
+			return nil, err
+		}
+	}
+
+	project, err := LoadProject()
+	if err != nil {
+		return nil, err
+	}
+	cfg.Project = project
+	if project.Template != "" {
+		cfg.PromptTemplate = project.Template
+	}
+
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+	if cfg.Token == "" {
+		cfg.Token = cfg.githubToken()
+	}
+	if cfg.Token == "" && cfg.Host != "" {
+		// Fall back to a token saved by nitpick login; a missing or unavailable keyring is not an error
+		if token, err := keyringToken(cfg.Provider, cfg.Host); err == nil {
+			cfg.Token = token
+		}
+	}
+	cfg.TemplatesDir = expandHome(cfg.TemplatesDir)
+	cfg.CacheDir = expandHome(cfg.CacheDir)
+	cfg.WorktreeDir = expandHome(cfg.WorktreeDir)
+	cfg.Theme = cfg.themePath(cfg.Theme)
+	if cfg.HTTP.CACert != "" {
+		cfg.HTTP.CACert = cfg.filePath(cfg.HTTP.CACert)
+	}
+
+	return cfg, nil
+}
+
+// ResolveAlias returns the repository (owner/name) a configured alias stands for
+func (c *Config) ResolveAlias(alias string) (string, bool) {
+	repo, ok := c.Aliases[alias]
+	return repo, ok && repo != ""
+}
+
+// RepoAlias returns the alias configured for a repository (owner/name), if any. Aliases are compared
+// case-insensitively, like GitHub repository names; the alphabetically first of several aliases wins.
+func (c *Config) RepoAlias(repo string) string {
+	var alias string
+	for name, target := range c.Aliases {
+		if strings.EqualFold(target, repo) && (alias == "" || name < alias) {
+			alias = name
+		}
+	}
+	return alias
+}
+
+// RepoAliases returns the alias of each repository with one, by lowercased owner/name, as RepoAlias does
+// for a single repository
+func (c *Config) RepoAliases() map[string]string {
+	aliases := make(map[string]string, len(c.Aliases))
+	for name, target := range c.Aliases {
+		key := strings.ToLower(target)
+		if alias, ok := aliases[key]; target != "" && (!ok || name < alias) {
+			aliases[key] = name
+		}
+	}
+	return aliases
+}
+
+// File returns the path of the configuration file the settings were loaded from
+func (c *Config) File() string {
+	return c.file
+}
+
+// APICacheDir returns the directory of the API response cache, inside the cache directory
+func (c *Config) APICacheDir() string {
+	if c.CacheDir == "" {
+		return ""
+	}
+	return filepath.Join(c.CacheDir, "api")
+}
+
+// themePath resolves a theme naming a style file: ~ is expanded and relative paths are taken relative
+// to the directory of the configuration file. Built-in style names are returned unchanged.
+func (c *Config) themePath(theme string) string {
+	if slices.Contains(Themes, theme) {
+		return theme
+	}
+	return c.filePath(theme)
+}
+
+// filePath resolves the path of a file named in the configuration: ~ is expanded and relative paths are
+// taken relative to the directory of the configuration file
+func (c *Config) filePath(path string) string {
+	path = expandHome(path)
+	if !filepath.IsAbs(path) && c.file != "" {
+		path = filepath.Join(filepath.Dir(c.file), path)
+	}
+	return path
+}
+
+// expandHome replaces a leading ~ in path with the user's home directory
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
@@ -0,0 +1,235 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// isolate clears the environment variables and project file Load reads, and keeps it off the OS keyring
+func isolate(t *testing.T) {
+	t.Helper()
+	for _, env := range envVars {
+		t.Setenv(env.name, "")
+	}
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("NITPICK_PROFILE", "")
+	t.Setenv("NITPICK_CONFIG", "")
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	lookup := keyringToken
+	keyringToken = func(provider, host string) (string, error) { return "", ErrNoKeyringToken }
+	t.Cleanup(func() { keyringToken = lookup })
+}
+
+func TestLoadTokenPrecedence(t *testing.T) {
+	const profiles = `
+profiles:
+  lab:
+    provider: gitlab
+    token: lab-token
+  tea:
+    provider: gitea
+    host: codeberg.org
+  ghe:
+    host: github.example.com
+  work:
+    token: work-token
+`
+
+	tests := []struct {
+		name     string
+		file     string
+		profile  string
+		env      map[string]string
+		keyring  map[string]string // Tokens in the keyring by account
+		provider string
+		host     string
+		token    string
+	}{
+		{
+			name:     "GITHUB_TOKEN for github.com",
+			env:      map[string]string{"GITHUB_TOKEN": "gh-token"},
+			provider: ProviderGitHub,
+			host:     DefaultHost,
+			token:    "gh-token",
+		},
+		{
+			name:     "NITPICK_TOKEN over GITHUB_TOKEN",
+			env:      map[string]string{"GITHUB_TOKEN": "gh-token", "NITPICK_TOKEN": "nitpick-token"},
+			provider: ProviderGitHub,
+			host:     DefaultHost,
+			token:    "nitpick-token",
+		},
+		{
+			name:     "config token over GITHUB_TOKEN",
+			file:     "token: file-token\n",
+			env:      map[string]string{"GITHUB_TOKEN": "gh-token"},
+			provider: ProviderGitHub,
+			host:     DefaultHost,
+			token:    "file-token",
+		},
+		{
+			name:     "profile token over GITHUB_TOKEN",
+			file:     profiles,
+			profile:  "work",
+			env:      map[string]string{"GITHUB_TOKEN": "gh-token"},
+			provider: ProviderGitHub,
+			host:     DefaultHost,
+			token:    "work-token",
+		},
+		{
+			name:     "GITHUB_TOKEN over keyring",
+			env:      map[string]string{"GITHUB_TOKEN": "gh-token"},
+			keyring:  map[string]string{"github.com": "keyring-token"},
+			provider: ProviderGitHub,
+			host:     DefaultHost,
+			token:    "gh-token",
+		},
+		{
+			name:     "keyring without token",
+			keyring:  map[string]string{"github.com": "keyring-token"},
+			provider: ProviderGitHub,
+			host:     DefaultHost,
+			token:    "keyring-token",
+		},
+		{
+			name:     "profile provider keeps its token despite GITHUB_TOKEN",
+			file:     profiles,
+			profile:  "lab",
+			env:      map[string]string{"GITHUB_TOKEN": "gh-token"},
+			provider: ProviderGitLab,
+			host:     "gitlab.com",
+			token:    "lab-token",
+		},
+		{
+			name:     "profile provider without token ignores GITHUB_TOKEN",
+			file:     profiles,
+			profile:  "tea",
+			env:      map[string]string{"GITHUB_TOKEN": "gh-token"},
+			provider: ProviderGitea,
+			host:     "codeberg.org",
+		},
+		{
+			name:     "profile provider with NITPICK_TOKEN",
+			file:     profiles,
+			profile:  "tea",
+			env:      map[string]string{"GITHUB_TOKEN": "gh-token", "NITPICK_TOKEN": "nitpick-token"},
+			provider: ProviderGitea,
+			host:     "codeberg.org",
+			token:    "nitpick-token",
+		},
+		{
+			name:     "profile host ignores GITHUB_TOKEN",
+			file:     profiles,
+			profile:  "ghe",
+			env:      map[string]string{"GITHUB_TOKEN": "gh-token"},
+			provider: ProviderGitHub,
+			host:     "github.example.com",
+		},
+		{
+			name:     "config provider ignores GITHUB_TOKEN",
+			file:     "provider: bitbucket\n",
+			env:      map[string]string{"GITHUB_TOKEN": "gh-token"},
+			provider: ProviderBitbucket,
+			host:     "bitbucket.org",
+		},
+		{
+			name:     "NITPICK_PROVIDER drops the config token",
+			file:     "token: file-token\n",
+			env:      map[string]string{"NITPICK_PROVIDER": "gitlab", "GITHUB_TOKEN": "gh-token"},
+			provider: ProviderGitLab,
+			host:     "gitlab.com",
+		},
+		{
+			name:     "NITPICK_PROVIDER drops the profile token",
+			file:     profiles,
+			profile:  "lab",
+			env:      map[string]string{"NITPICK_PROVIDER": "azuredevops"},
+			provider: ProviderAzureDevOps,
+			host:     "dev.azure.com",
+		},
+		{
+			name:     "NITPICK_PROVIDER matching the profile keeps its token",
+			file:     profiles,
+			profile:  "lab",
+			env:      map[string]string{"NITPICK_PROVIDER": "gitlab", "NITPICK_HOST": "gitlab.com"},
+			provider: ProviderGitLab,
+			host:     "gitlab.com",
+			token:    "lab-token",
+		},
+		{
+			name:     "NITPICK_HOST drops the profile token",
+			file:     profiles,
+			profile:  "lab",
+			env:      map[string]string{"NITPICK_HOST": "gitlab.example.com", "GITHUB_TOKEN": "gh-token"},
+			provider: ProviderGitLab,
+			host:     "gitlab.example.com",
+		},
+		{
+			name:     "keyring token of the provider",
+			file:     "provider: gitlab\n",
+			keyring:  map[string]string{"github.com": "keyring-token", "gitlab:gitlab.com": "lab-keyring-token"},
+			provider: ProviderGitLab,
+			host:     "gitlab.com",
+			token:    "lab-keyring-token",
+		},
+		{
+			name:     "no github.com keyring token for another provider",
+			file:     "provider: gitlab\nhost: gitlab.example.com\n",
+			keyring:  map[string]string{"github.com": "keyring-token"},
+			provider: ProviderGitLab,
+			host:     "gitlab.example.com",
+		},
+		{
+			name:     "no keyring token without a host",
+			file:     "provider: gitea\nbase_url: https://git.example.com/api/v1\n",
+			keyring:  map[string]string{"github.com": "keyring-token", "": "keyring-token", "gitea:": "keyring-token"},
+			provider: ProviderGitea,
+		},
+		{
+			name:     "NITPICK_PROFILE selects the profile",
+			file:     profiles,
+			env:      map[string]string{"NITPICK_PROFILE": "lab", "GITHUB_TOKEN": "gh-token"},
+			provider: ProviderGitLab,
+			host:     "gitlab.com",
+			token:    "lab-token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolate(t)
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			keyringToken = func(provider, host string) (string, error) {
+				if token, ok := tt.keyring[keyringUser(provider, host)]; ok {
+					return token, nil
+				}
+				return "", ErrNoKeyringToken
+			}
+
+			path := filepath.Join(t.TempDir(), "config.yml")
+			if err := os.WriteFile(path, []byte(tt.file), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := Load(path, tt.profile)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.Provider != tt.provider || cfg.Host != tt.host || cfg.Token != tt.token {
+				t.Errorf("Load() = provider %q, host %q, token %q; want %q, %q, %q",
+					cfg.Provider, cfg.Host, cfg.Token, tt.provider, tt.host, tt.token)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Directories nitpick reads and writes, following the XDG base directory specification.
+// Each can be moved with a NITPICK_*_DIR environment variable:
+//
+//	config  NITPICK_CONFIG_DIR  $XDG_CONFIG_HOME/nitpick  ~/.config/nitpick       config.yml, templates
+//	cache   NITPICK_CACHE_DIR   $XDG_CACHE_HOME/nitpick   ~/.cache/nitpick        API responses, prompt scratch file (safe to delete)
+//	state   NITPICK_STATE_DIR   $XDG_STATE_HOME/nitpick   ~/.local/state/nitpick  UI toggles, review progress, stats, digest watermarks
+//	data    NITPICK_DATA_DIR    $XDG_DATA_HOME/nitpick    ~/.local/share/nitpick  bookmarks
+
+// Dir returns the nitpick configuration directory
+func Dir() (string, error) {
+	return xdgDir("NITPICK_CONFIG_DIR", "XDG_CONFIG_HOME", ".config")
+}
+
+// CacheDir returns the default nitpick cache directory; cache_dir overrides it
+func CacheDir() (string, error) {
+	return xdgDir("NITPICK_CACHE_DIR", "XDG_CACHE_HOME", ".cache")
+}
+
+// StateDir returns the nitpick state directory
+func StateDir() (string, error) {
+	return xdgDir("NITPICK_STATE_DIR", "XDG_STATE_HOME", filepath.Join(".local", "state"))
+}
+
+// DataDir returns the nitpick data directory
+func DataDir() (string, error) {
+	return xdgDir("NITPICK_DATA_DIR", "XDG_DATA_HOME", filepath.Join(".local", "share"))
+}
+
+// xdgDir returns the directory named by the override variable, or the nitpick directory inside the
+// XDG base directory, or inside its default location relative to the home directory
+func xdgDir(override, xdgVar, homeRelative string) (string, error) {
+	if dir := os.Getenv(override); dir != "" {
+		return expandHome(dir), nil
+	}
+	if dir := os.Getenv(xdgVar); dir != "" {
+		return filepath.Join(dir, "nitpick"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, homeRelative, "nitpick"), nil
+}
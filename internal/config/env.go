@@ -0,0 +1,165 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// envVar maps an environment variable onto a setting
+type envVar struct {
+	name  string
+	apply func(c *Config, value string) error
+}
+
+// envVars lists the environment variables that override settings, in the order they are applied. Like a
+// profile, NITPICK_PROVIDER and NITPICK_HOST drop the token of another provider or host, so NITPICK_TOKEN
+// comes after them. GITHUB_TOKEN is not among them: it is honored only when no token is set otherwise.
+var envVars = []envVar{
+	{"NITPICK_PROVIDER", func(c *Config, v string) error { c.switchProvider(v); return nil }},
+	{"NITPICK_HOST", func(c *Config, v string) error { c.switchHost(v); return nil }},
+	{"NITPICK_BASE_URL", func(c *Config, v string) error { c.BaseURL = v; return nil }},
+	{"NITPICK_TOKEN", func(c *Config, v string) error { c.Token = v; return nil }},
+	{"NITPICK_OAUTH_CLIENT_ID", func(c *Config, v string) error { c.OAuthClientID = v; return nil }},
+	{"NITPICK_TIMEOUT", func(c *Config, v string) error { return parseDuration(&c.Timeout, v) }},
+	{"NITPICK_RETRY_ATTEMPTS", func(c *Config, v string) error { return parseInt(&c.Retry.Attempts, v) }},
+	{"NITPICK_RETRY_BACKOFF", func(c *Config, v string) error { return parseDuration(&c.Retry.Backoff, v) }},
+	{"NITPICK_HTTP_PROXY", func(c *Config, v string) error { c.HTTP.Proxy = v; return nil }},
+	{"NITPICK_CA_CERT", func(c *Config, v string) error { c.HTTP.CACert = v; return nil }},
+	{"NITPICK_INSECURE_SKIP_VERIFY", func(c *Config, v string) error { return parseBool(&c.HTTP.InsecureSkipVerify, v) }},
+	{"NITPICK_SHOW_REPLIES", func(c *Config, v string) error { return parseBool(&c.ShowReplies, v) }},
+	{"NITPICK_CONVERSATION", func(c *Config, v string) error { return parseBool(&c.Conversation, v) }},
+	{"NITPICK_POLL_INTERVAL", func(c *Config, v string) error { return parsePollInterval(&c.PollInterval, v) }},
+	{"NITPICK_PROMPT_TEMPLATE", func(c *Config, v string) error { c.PromptTemplate = v; return nil }},
+	{"NITPICK_PROMPT_CODEOWNERS", func(c *Config, v string) error { return parseBool(&c.PromptOwners, v) }},
+	{"NITPICK_PROMPT_BLAME", func(c *Config, v string) error { return parseBool(&c.PromptBlame, v) }},
+	{"NITPICK_PROMPT_SYMBOLS", func(c *Config, v string) error { return parseBool(&c.PromptSymbols, v) }},
+	{"NITPICK_PROMPT_CONTEXT", func(c *Config, v string) error { return parseBool(&c.PromptContext, v) }},
+	{"NITPICK_PROMPT_ISSUES", func(c *Config, v string) error { return parseBool(&c.PromptIssues, v) }},
+	{"NITPICK_CONTEXT_LINES", func(c *Config, v string) error { return parseInt(&c.ContextLines, v) }},
+	{"NITPICK_PAGE_SIZE", func(c *Config, v string) error { return parseInt(&c.PageSize, v) }},
+	{"NITPICK_THEME", func(c *Config, v string) error { c.Theme = v; return nil }},
+	{"NITPICK_ASCII", func(c *Config, v string) error { return parseBool(&c.ASCII, v) }},
+	{"NITPICK_EDITOR", func(c *Config, v string) error { c.Editor = v; return nil }},
+	{"NITPICK_WORKTREE_DIR", func(c *Config, v string) error { c.WorktreeDir = v; return nil }},
+	{"NITPICK_TEMPLATES_DIR", func(c *Config, v string) error { c.TemplatesDir = v; return nil }},
+	{"NITPICK_CACHE_DIR", func(c *Config, v string) error { c.CacheDir = v; return nil }},
+	{"NITPICK_CACHE_TTL_REPOS", func(c *Config, v string) error { return parseTTL(&c.CacheTTL.Repos, v) }},
+	{"NITPICK_CACHE_TTL_PRS", func(c *Config, v string) error { return parseTTL(&c.CacheTTL.PRs, v) }},
+	{"NITPICK_CACHE_TTL_COMMENTS", func(c *Config, v string) error { return parseTTL(&c.CacheTTL.Comments, v) }},
+	{"NITPICK_CLIPBOARD_BACKEND", func(c *Config, v string) error { c.Clipboard.Backend = v; return nil }},
+	{"NITPICK_CLIPBOARD_LIMIT", func(c *Config, v string) error { return parseInt(&c.Clipboard.Limit, v) }},
+	{"NITPICK_WEBHOOK_URL", func(c *Config, v string) error { c.Webhook.URL = v; return nil }},
+	{"NITPICK_WEBHOOK_FORMAT", func(c *Config, v string) error { c.Webhook.Format = v; return nil }},
+	{"NITPICK_NOTIFY_BELL", func(c *Config, v string) error { return parseBool(&c.Notify.Bell, v) }},
+	{"NITPICK_NOTIFY_DESKTOP", func(c *Config, v string) error { return parseBool(&c.Notify.Desktop, v) }},
+	{"NITPICK_JIRA_BASE_URL", func(c *Config, v string) error { c.Jira.BaseURL = v; return nil }},
+	{"NITPICK_JIRA_EMAIL", func(c *Config, v string) error { c.Jira.Email = v; return nil }},
+	{"NITPICK_JIRA_TOKEN", func(c *Config, v string) error { c.Jira.Token = v; return nil }},
+	{"NITPICK_JIRA_PROJECTS", func(c *Config, v string) error { c.Jira.Projects = parseList(v); return nil }},
+	{"NITPICK_JIRA_PROMPT", func(c *Config, v string) error { return parseBool(&c.Jira.Prompt, v) }},
+	{"NITPICK_LINEAR_API_KEY", func(c *Config, v string) error { c.Linear.APIKey = v; return nil }},
+	{"NITPICK_LINEAR_TEAMS", func(c *Config, v string) error { c.Linear.Teams = parseList(v); return nil }},
+	{"NITPICK_LINEAR_PROMPT", func(c *Config, v string) error { return parseBool(&c.Linear.Prompt, v) }},
+	{"NITPICK_REPOS_PER_PAGE", func(c *Config, v string) error { return parseInt(&c.Limits.Repos.PerPage, v) }},
+	{"NITPICK_REPOS_MAX_PAGES", func(c *Config, v string) error { return parseInt(&c.Limits.Repos.MaxPages, v) }},
+	{"NITPICK_REPOS_MAX_ITEMS", func(c *Config, v string) error { return parseInt(&c.Limits.Repos.MaxItems, v) }},
+	{"NITPICK_PRS_PER_PAGE", func(c *Config, v string) error { return parseInt(&c.Limits.PRs.PerPage, v) }},
+	{"NITPICK_PRS_MAX_PAGES", func(c *Config, v string) error { return parseInt(&c.Limits.PRs.MaxPages, v) }},
+	{"NITPICK_PRS_MAX_ITEMS", func(c *Config, v string) error { return parseInt(&c.Limits.PRs.MaxItems, v) }},
+	{"NITPICK_COMMENTS_PER_PAGE", func(c *Config, v string) error { return parseInt(&c.Limits.Comments.PerPage, v) }},
+	{"NITPICK_COMMENTS_MAX_PAGES", func(c *Config, v string) error { return parseInt(&c.Limits.Comments.MaxPages, v) }},
+	{"NITPICK_COMMENTS_MAX_ITEMS", func(c *Config, v string) error { return parseInt(&c.Limits.Comments.MaxItems, v) }},
+	{"NITPICK_PREFETCH_CONCURRENCY", func(c *Config, v string) error { return parseInt(&c.Prefetch.Concurrency, v) }},
+	{"NITPICK_PREFETCH_COMMENTS", func(c *Config, v string) error { return parseInt(&c.Prefetch.Comments, v) }},
+}
+
+// applyEnv overrides settings with values from environment variables
+func (c *Config) applyEnv() error {
+	for _, env := range envVars {
+		value := os.Getenv(env.name)
+		if value == "" {
+			continue
+		}
+		if err := env.apply(c, value); err != nil {
+			return fmt.Errorf("invalid %s %q: %w", env.name, value, err)
+		}
+	}
+	return nil
+}
+
+// githubToken returns the GITHUB_TOKEN environment variable, honored for compatibility as the token of
+// github.com only: it is never sent to another provider or a GitHub Enterprise host, and a token from the
+// config file, a profile or NITPICK_TOKEN takes precedence over it
+func (c *Config) githubToken() string {
+	if c.Provider != ProviderGitHub || c.Host != DefaultHost || c.BaseURL != "" {
+		return ""
+	}
+	return os.Getenv("GITHUB_TOKEN")
+}
+
+// parseInt parses a non-negative integer setting
+func parseInt(dst *int, value string) error {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return fmt.Errorf("expected a non-negative integer")
+	}
+	*dst = n
+	return nil
+}
+
+// parseBool parses a boolean setting
+func parseBool(dst *bool, value string) error {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("expected true or false")
+	}
+	*dst = b
+	return nil
+}
+
+// parseList parses a comma-separated list setting
+func parseList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseDuration parses a duration setting such as 30s or 2m
+func parseDuration(dst *time.Duration, value string) error {
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("expected a positive duration such as 30s or 2m")
+	}
+	*dst = d
+	return nil
+}
+
+// parsePollInterval parses a polling interval of at least MinPollInterval, where 0 disables polling
+func parsePollInterval(dst *time.Duration, value string) error {
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return fmt.Errorf("expected a duration such as 1m, or 0 to disable polling")
+	}
+	if d > 0 && d < MinPollInterval {
+		return fmt.Errorf("poll_interval must be 0 (disabled) or at least %s, got %s", MinPollInterval, d)
+	}
+	*dst = d
+	return nil
+}
+
+// parseTTL parses a cache time-to-live, where 0 disables caching
+func parseTTL(dst *time.Duration, value string) error {
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return fmt.Errorf("expected a duration such as 1h or 2m, or 0 to disable caching")
+	}
+	*dst = d
+	return nil
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestApplyEnvPollInterval(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "1m", want: time.Minute},
+		{value: "0", want: 0},
+		{value: "0s", want: 0},
+		{value: MinPollInterval.String(), want: MinPollInterval},
+		{value: "1s", wantErr: true},
+		{value: "-1m", wantErr: true},
+		{value: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			isolate(t)
+			t.Setenv("NITPICK_POLL_INTERVAL", tt.value)
+
+			cfg := Default()
+			cfg.PollInterval = 30 * time.Second
+			err := cfg.applyEnv()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cfg.PollInterval != tt.want {
+				t.Errorf("PollInterval = %s, want %s", cfg.PollInterval, tt.want)
+			}
+		})
+	}
+}
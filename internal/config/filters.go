@@ -0,0 +1,45 @@
+package config
+
+import (
+	"slices"
+	"strings"
+)
+
+// Filters holds the default filters applied when a repository is opened
+type Filters struct {
+	Base          string   `yaml:"base" toml:"base"`                     // Only list pull requests targeting this base branch
+	IgnoreAuthors []string `yaml:"ignore_authors" toml:"ignore_authors"` // Logins whose review comments are hidden
+	HideBots      bool     `yaml:"hide_bots" toml:"hide_bots"`           // Whether review comments from bot accounts are hidden
+}
+
+// IgnoresAuthor reports whether review comments by the given login should be hidden
+func (f Filters) IgnoresAuthor(login string, bot bool) bool {
+	if f.HideBots && (bot || strings.HasSuffix(login, "[bot]")) {
+		return true
+	}
+	return slices.ContainsFunc(f.IgnoreAuthors, func(ignored string) bool {
+		return strings.EqualFold(ignored, login)
+	})
+}
+
+// RepoFilters returns the filters for the repository owner/name: those configured for it under
+// repos, overlaid with the filters of the working directory's .nitpick.toml
+func (c *Config) RepoFilters(owner, name string) Filters {
+	var filters Filters
+	fullName := owner + "/" + name
+	for repo, repoFilters := range c.Repos {
+		if strings.EqualFold(repo, fullName) {
+			filters = repoFilters
+			break
+		}
+	}
+
+	project := c.Project.Filters
+	if project.Base != "" {
+		filters.Base = project.Base
+	}
+	filters.IgnoreAuthors = append(slices.Clone(filters.IgnoreAuthors), project.IgnoreAuthors...)
+	filters.HideBots = filters.HideBots || project.HideBots
+
+	return filters
+}
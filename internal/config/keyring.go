@@ -0,0 +1,59 @@
+package config
+
+import (
+	"errors"
+
+	"github.com/zalando/go-keyring"
+)
+
+// keyringService is the service name under which tokens are stored in the OS keyring
+const keyringService = "nitpick"
+
+// ErrNoKeyringToken is returned when the OS keyring holds no token for a host
+var ErrNoKeyringToken = errors.New("no token stored in the keyring")
+
+// errNoKeyringHost is returned when a token is to be stored for no host
+var errNoKeyringHost = errors.New("no host to store the token for")
+
+// KeyringToken returns the token stored in the OS keyring (keychain, secret service or wincred) for a
+// provider's host. Without a host, as for a self-hosted provider configured by base URL, there is none.
+func KeyringToken(provider, host string) (string, error) {
+	if host == "" {
+		return "", ErrNoKeyringToken
+	}
+	token, err := keyring.Get(keyringService, keyringUser(provider, host))
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrNoKeyringToken
+	}
+	return token, err
+}
+
+// StoreToken stores the token for a provider's host in the OS keyring
+func StoreToken(provider, host, token string) error {
+	if host == "" {
+		return errNoKeyringHost
+	}
+	return keyring.Set(keyringService, keyringUser(provider, host), token)
+}
+
+// DeleteToken removes the token for a provider's host from the OS keyring
+func DeleteToken(provider, host string) error {
+	if host == "" {
+		return ErrNoKeyringToken
+	}
+	err := keyring.Delete(keyringService, keyringUser(provider, host))
+	if errors.Is(err, keyring.ErrNotFound) {
+		return ErrNoKeyringToken
+	}
+	return err
+}
+
+// keyringUser returns the keyring account name for a provider's host: the host itself for GitHub, as stored
+// by earlier versions, and provider:host for the others, so a token is only ever read back for the provider
+// and host it was stored for
+func keyringUser(provider, host string) string {
+	if provider == ProviderGitHub || provider == "" {
+		return host
+	}
+	return provider + ":" + host
+}
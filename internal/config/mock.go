@@ -0,0 +1,16 @@
+package config
+
+// MockToken stands in for the token in mock mode, where requests never leave the process
+const MockToken = "mock"
+
+// UseMock switches the configuration to mock mode: GitHub API requests are answered from the JSON fixtures
+// of a directory, or from the built-in demo fixtures, so no token or network is needed. Responses are not
+// cached, keeping fixtures out of the cache of real responses and edits to them visible at once.
+func (c *Config) UseMock(fixtures string) {
+	c.Mock = fixtures
+	c.Provider = ProviderGitHub
+	c.Host = DefaultHost
+	c.BaseURL = ""
+	c.Token = MockToken
+	c.CacheTTL = CacheTTLConfig{}
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// Profile holds the settings of one configured host or account. Unset fields keep the top-level values.
+type Profile struct {
+	Provider       string        `yaml:"provider"`        // Code review provider: github, gitlab, bitbucket, gitea, azuredevops or gerrit
+	Token          string        `yaml:"token"`           // GitHub personal access token for this profile
+	Host           string        `yaml:"host"`            // GitHub host, e.g. github.com or a GitHub Enterprise hostname
+	BaseURL        string        `yaml:"base_url"`        // REST API base URL; overrides the URL derived from Host
+	Timeout        time.Duration `yaml:"timeout"`         // Timeout for the API requests of a view or command
+	PageSize       int           `yaml:"page_size"`       // Results requested per API page
+	PromptTemplate string        `yaml:"prompt_template"` // Default prompt template
+	Theme          string        `yaml:"theme"`           // Glamour style used to render markdown
+}
+
+// ProfileNames returns the names of the configured profiles in alphabetical order
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Reload loads the configuration again from the same file, with the given profile selected
+func (c *Config) Reload(profile string) (*Config, error) {
+	return Load(c.file, profile)
+}
+
+// selectProfile returns the profile to use: the requested one, NITPICK_PROFILE, or the configured default
+func (c *Config) selectProfile(requested string) string {
+	if requested != "" {
+		return requested
+	}
+	if name := os.Getenv("NITPICK_PROFILE"); name != "" {
+		return name
+	}
+	return c.DefaultProfile
+}
+
+// applyProfile overlays the settings of the named profile
+func (c *Config) applyProfile(name string) error {
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return fmt.Errorf("unknown profile %q", name)
+	}
+
+	c.Profile = name
+	if profile.Provider != "" {
+		c.switchProvider(profile.Provider)
+	}
+	if profile.Host != "" {
+		c.switchHost(profile.Host)
+	}
+	if profile.Token != "" {
+		c.Token = profile.Token
+	}
+	if profile.BaseURL != "" {
+		c.BaseURL = profile.BaseURL
+	}
+	if profile.Timeout != 0 {
+		c.Timeout = profile.Timeout
+	}
+	if profile.PageSize != 0 {
+		c.PageSize = profile.PageSize
+	}
+	if profile.PromptTemplate != "" {
+		c.PromptTemplate = profile.PromptTemplate
+	}
+	if profile.Theme != "" {
+		c.Theme = profile.Theme
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ProjectFileName is the name of the per-project configuration file
+const ProjectFileName = ".nitpick.toml"
+
+// Project holds the settings of a per-project .nitpick.toml
+type Project struct {
+	Repo     string `toml:"repo"`     // Default repository (owner/name) opened for the project
+	Template string `toml:"template"` // Preferred prompt template; overrides prompt_template
+	Path     string `toml:"-"`        // Path of the file the settings were read from
+	Filters         // Filters applied to every repository opened in the project
+}
+
+// FindProject returns the path of the .nitpick.toml in dir or its nearest parent directory,
+// or an empty string if there is none
+func FindProject(dir string) string {
+	for {
+		path := filepath.Join(dir, ProjectFileName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// LoadProject reads the .nitpick.toml that applies to the working directory.
+// A missing file yields empty settings.
+func LoadProject() (Project, error) {
+	var project Project
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return project, nil
+	}
+	path := FindProject(dir)
+	if path == "" {
+		return project, nil
+	}
+
+	meta, err := toml.DecodeFile(path, &project)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return project, nil
+		}
+		return project, fmt.Errorf("failed to parse project config %s: %w", path, err)
+	}
+	project.Path = path
+
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		invalid := &ValidationError{File: path}
+		for _, key := range undecoded {
+			invalid.Problems = append(invalid.Problems, Problem{Message: fmt.Sprintf("unknown key %q", key.String())})
+		}
+		return project, invalid
+	}
+
+	return project, nil
+}
@@ -0,0 +1,55 @@
+package config
+
+// Code review providers
+const (
+	ProviderGitHub      = "github"
+	ProviderGitLab      = "gitlab"
+	ProviderBitbucket   = "bitbucket"
+	ProviderGitea       = "gitea"
+	ProviderAzureDevOps = "azuredevops"
+	ProviderGerrit      = "gerrit"
+)
+
+// Providers lists the supported code review providers
+var Providers = []string{ProviderGitHub, ProviderGitLab, ProviderBitbucket, ProviderGitea, ProviderAzureDevOps, ProviderGerrit}
+
+// providerHosts holds the host of each provider's hosted service, used when no host is configured.
+// Self-hosted providers such as Gitea and Gerrit have none and must be given a host.
+var providerHosts = map[string]string{
+	ProviderGitHub:      DefaultHost,
+	ProviderGitLab:      "gitlab.com",
+	ProviderBitbucket:   "bitbucket.org",
+	ProviderAzureDevOps: "dev.azure.com",
+}
+
+// applyProviderHost replaces the default GitHub host with the hosted service of another provider, or
+// clears it for a self-hosted provider
+func (c *Config) applyProviderHost() {
+	if c.Provider != ProviderGitHub && c.Host == DefaultHost {
+		c.Host = providerHosts[c.Provider]
+	}
+}
+
+// switchProvider selects another provider, dropping the token, host and base URL configured for the previous
+// one: a token for another provider must not be sent to this one
+func (c *Config) switchProvider(provider string) {
+	if provider == c.Provider {
+		return
+	}
+	c.Provider = provider
+	c.Host = DefaultHost
+	c.Token = ""
+	c.BaseURL = ""
+	c.applyProviderHost()
+}
+
+// switchHost selects another host, dropping the token and base URL configured for the previous one: a token
+// configured for another host must not be sent to this one
+func (c *Config) switchHost(host string) {
+	if host == c.Host {
+		return
+	}
+	c.Host = host
+	c.Token = ""
+	c.BaseURL = ""
+}
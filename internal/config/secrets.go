@@ -0,0 +1,94 @@
+package config
+
+import (
+	"bytes"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StripSecrets removes the tokens from the content of a configuration file, at the top level and in
+// every profile, along with the webhook URL and the credentials of the proxy URL, keeping the rest of
+// the file including its comments
+func StripSecrets(content []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return content, nil
+	}
+
+	root := doc.Content[0]
+	removeKey(root, "token")
+	if jira := mappingValue(root, "jira"); jira != nil && jira.Kind == yaml.MappingNode {
+		removeKey(jira, "token")
+	}
+	if linear := mappingValue(root, "linear"); linear != nil && linear.Kind == yaml.MappingNode {
+		removeKey(linear, "api_key")
+	}
+	if webhook := mappingValue(root, "webhook"); webhook != nil && webhook.Kind == yaml.MappingNode {
+		removeKey(webhook, "url")
+	}
+	if network := mappingValue(root, "http"); network != nil && network.Kind == yaml.MappingNode {
+		if proxy := mappingValue(network, "proxy"); proxy != nil && proxy.Kind == yaml.ScalarNode {
+			proxy.Value = stripUserinfo(proxy.Value)
+		}
+	}
+	if profiles := mappingValue(root, "profiles"); profiles != nil && profiles.Kind == yaml.MappingNode {
+		for i := 1; i < len(profiles.Content); i += 2 {
+			if profiles.Content[i].Kind == yaml.MappingNode {
+				removeKey(profiles.Content[i], "token")
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// mappingValue returns the value of key in a YAML mapping node, or nil
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// removeKey deletes key and its value from a YAML mapping node
+func removeKey(mapping *yaml.Node, key string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value != key {
+			continue
+		}
+		// Keep a comment above the key, which may describe the whole file, on the key that follows
+		if comment := mapping.Content[i].HeadComment; comment != "" && i+2 < len(mapping.Content) {
+			next := mapping.Content[i+2]
+			next.HeadComment = strings.TrimSpace(comment + "\n" + next.HeadComment)
+		}
+		mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+		return
+	}
+}
+
+// stripUserinfo removes the user and password from a URL, with or without a scheme
+func stripUserinfo(raw string) string {
+	at := strings.LastIndex(raw, "@")
+	if at < 0 {
+		return raw
+	}
+	if scheme := strings.Index(raw, "://"); scheme >= 0 && scheme < at {
+		return raw[:scheme+len("://")] + raw[at+1:]
+	}
+	return raw[at+1:]
+}
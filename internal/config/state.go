@@ -0,0 +1,76 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Comment sort orders of the TUI comment list
+const (
+	SortUpdated = "updated" // Most recently updated first
+	SortCreated = "created" // Most recently created first
+	SortFile    = "file"    // By file path and line
+)
+
+// UIState holds the TUI toggles that persist across sessions
+type UIState struct {
+	ShowReplies    bool   `json:"show_replies"`    // Whether reply comments are shown
+	PromptTemplate string `json:"prompt_template"` // Active prompt template (full or simple)
+	CommentSort    string `json:"comment_sort"`    // Sort order of the comment list
+	HideBots       bool   `json:"hide_bots"`       // Whether review comments from bot accounts are hidden
+	ShowResolved   bool   `json:"show_resolved"`   // Whether comments of resolved threads are shown
+}
+
+// StatePath returns the path of the UI state file
+func StatePath() (string, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "state.json"), nil
+}
+
+// LoadState reads the UI state file. ok is false if no state has been saved yet.
+func LoadState() (state UIState, ok bool, err error) {
+	path, err := StatePath()
+	if err != nil {
+		return state, false, fmt.Errorf("failed to locate state file: %w", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, false, nil
+	}
+	if err != nil {
+		return state, false, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	if err := json.Unmarshal(content, &state); err != nil {
+		return state, false, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+	return state, true, nil
+}
+
+// SaveState writes the UI state file
+func SaveState(state UIState) error {
+	path, err := StatePath()
+	if err != nil {
+		return fmt.Errorf("failed to locate state file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	content, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,316 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/stefrushxyz/nitpick/internal/clipboard"
+	"github.com/stefrushxyz/nitpick/internal/webhook"
+	"gopkg.in/yaml.v3"
+)
+
+// Problem is an issue found in a configuration file. Line is 0 when the position is unknown.
+type Problem struct {
+	Line    int
+	Message string
+}
+
+// ValidationError reports every problem found in a configuration file
+type ValidationError struct {
+	File     string
+	Problems []Problem
+}
+
+// Error lists the problems with file:line hints
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "invalid config file %s:", e.File)
+	for _, problem := range e.Problems {
+		if problem.Line > 0 {
+			fmt.Fprintf(&b, "\n  %s:%d: %s", e.File, problem.Line, problem.Message)
+		} else {
+			fmt.Fprintf(&b, "\n  %s: %s", e.File, problem.Message)
+		}
+	}
+	return b.String()
+}
+
+var (
+	// yamlProblem matches a problem reported by the YAML decoder
+	yamlProblem = regexp.MustCompile(`^line (\d+): (.*)$`)
+	// unknownField matches the decoder's message for a key that is not a setting
+	unknownField = regexp.MustCompile(`^field (\S+) not found in type \S+$`)
+	// typeMismatch matches the decoder's message for a value of the wrong type
+	typeMismatch = regexp.MustCompile("^cannot unmarshal !!\\w+ `(.*)` into (\\S+)$")
+	// ticketKeyPrefix matches a Jira project key or Linear team key
+	ticketKeyPrefix = regexp.MustCompile(`^[A-Z][A-Z0-9_]+$`)
+)
+
+// typeNames describes the Go types of settings in the terms of the configuration file
+var typeNames = map[string]string{
+	"time.Duration": "duration (e.g. 30s or 5m)",
+	"int":           "whole number",
+	"bool":          "boolean (true or false)",
+	"string":        "string",
+	"[]string":      "list of strings",
+}
+
+// decodeFile decodes the content of the configuration file at path into c, rejecting unknown keys,
+// values of the wrong type and invalid values
+func (c *Config) decodeFile(path string, content []byte) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	v := &validator{}
+	if len(doc.Content) > 0 {
+		v.root = doc.Content[0]
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+		for _, message := range typeErr.Errors {
+			v.problems = append(v.problems, decodeProblem(message))
+		}
+	}
+
+	v.check(c, path)
+	if len(v.problems) > 0 {
+		slices.SortStableFunc(v.problems, func(a, b Problem) int { return a.Line - b.Line })
+		return &ValidationError{File: path, Problems: v.problems}
+	}
+	return nil
+}
+
+// decodeProblem converts a message of the YAML decoder into a problem
+func decodeProblem(message string) Problem {
+	var problem Problem
+	if m := yamlProblem.FindStringSubmatch(message); m != nil {
+		problem.Line, _ = strconv.Atoi(m[1])
+		message = m[2]
+	}
+	if m := unknownField.FindStringSubmatch(message); m != nil {
+		message = fmt.Sprintf("unknown key %q", m[1])
+	} else if m := typeMismatch.FindStringSubmatch(message); m != nil {
+		expected, ok := typeNames[m[2]]
+		if !ok {
+			expected = "value of this kind"
+			if strings.HasPrefix(m[2], "map[") || strings.HasPrefix(m[2], "config.") {
+				expected = "mapping of keys"
+			} else if strings.HasPrefix(m[2], "[]") {
+				expected = "list"
+			}
+		}
+		message = fmt.Sprintf("%q is not a valid %s", m[1], expected)
+	}
+	problem.Message = message
+	return problem
+}
+
+// validator collects the problems of a configuration file, locating them in its YAML tree
+type validator struct {
+	root     *yaml.Node
+	problems []Problem
+}
+
+// add records a problem at the value of the key path, or at the key itself when keyOnly is set
+func (v *validator) add(path []string, keyOnly bool, format string, args ...any) {
+	key, value := lookup(v.root, path...)
+	line := 0
+	switch {
+	case keyOnly && key != nil:
+		line = key.Line
+	case value != nil:
+		line = value.Line
+	}
+	v.problems = append(v.problems, Problem{Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+// set reports whether the key path is present in the file
+func (v *validator) set(path ...string) bool {
+	_, value := lookup(v.root, path...)
+	return value != nil
+}
+
+// check validates the values read from the file at path
+func (v *validator) check(c *Config, path string) {
+	if !slices.Contains(Providers, c.Provider) {
+		v.add([]string{"provider"}, false, "unknown provider %q (expected one of %s)", c.Provider, strings.Join(Providers, ", "))
+	}
+	if strings.Contains(c.Host, "://") {
+		v.add([]string{"host"}, false, "host %q should be a hostname like github.com; put API URLs in base_url", c.Host)
+	}
+	if c.Timeout < 0 {
+		v.add([]string{"timeout"}, false, "timeout must not be negative")
+	}
+	if c.PollInterval < 0 || (c.PollInterval > 0 && c.PollInterval < MinPollInterval) {
+		v.add([]string{"poll_interval"}, false, "poll_interval must be 0 (disabled) or at least %s, got %s", MinPollInterval, c.PollInterval)
+	}
+	if c.Retry.Attempts < 1 || c.Retry.Attempts > 10 {
+		v.add([]string{"retry", "attempts"}, false, "retry.attempts must be between 1 and 10, got %d", c.Retry.Attempts)
+	}
+	if c.Retry.Backoff < 0 {
+		v.add([]string{"retry", "backoff"}, false, "retry.backoff must not be negative")
+	}
+	if c.HTTP.Proxy != "" {
+		if u, err := url.Parse(c.HTTP.Proxy); err != nil || !slices.Contains([]string{"http", "https", "socks5"}, u.Scheme) || u.Host == "" {
+			v.add([]string{"http", "proxy"}, false, "http.proxy %q should be a URL like http://proxy.acme.com:3128", c.HTTP.Proxy)
+		}
+	}
+	if c.HTTP.CACert != "" {
+		if _, err := os.Stat((&Config{file: path}).filePath(c.HTTP.CACert)); err != nil {
+			v.add([]string{"http", "ca_cert"}, false, "http.ca_cert %q is not an existing file", c.HTTP.CACert)
+		}
+	}
+	if c.ContextLines < 0 || c.ContextLines > 50 {
+		v.add([]string{"context_lines"}, false, "context_lines must be between 0 and 50, got %d", c.ContextLines)
+	}
+	if v.set("page_size") && (c.PageSize < 1 || c.PageSize > 100) {
+		v.add([]string{"page_size"}, false, "page_size must be between 1 and 100, got %d", c.PageSize)
+	}
+	if v.set("theme") {
+		v.checkTheme([]string{"theme"}, c.Theme, path)
+	}
+	if c.Clipboard.Backend != "" && !slices.Contains(clipboard.Backends, c.Clipboard.Backend) {
+		v.add([]string{"clipboard", "backend"}, false, "unknown clipboard backend %q (expected one of %s)",
+			c.Clipboard.Backend, strings.Join(clipboard.Backends, ", "))
+	}
+	if c.Clipboard.Limit < 0 {
+		v.add([]string{"clipboard", "limit"}, false, "clipboard.limit must not be negative")
+	}
+	if c.Webhook.Format != "" && !slices.Contains(webhook.Formats, c.Webhook.Format) {
+		v.add([]string{"webhook", "format"}, false, "unknown webhook format %q (expected one of %s)",
+			c.Webhook.Format, strings.Join(webhook.Formats, ", "))
+	}
+	if c.Jira.BaseURL != "" {
+		if u, err := url.Parse(c.Jira.BaseURL); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			v.add([]string{"jira", "base_url"}, false, "jira.base_url %q should be a URL like https://acme.atlassian.net", c.Jira.BaseURL)
+		}
+	}
+	for _, project := range c.Jira.Projects {
+		if !ticketKeyPrefix.MatchString(project) {
+			v.add([]string{"jira", "projects"}, false, "jira.projects entry %q is not a project key like PROJ", project)
+		}
+	}
+	for _, team := range c.Linear.Teams {
+		if !ticketKeyPrefix.MatchString(team) {
+			v.add([]string{"linear", "teams"}, false, "linear.teams entry %q is not a team key like ENG", team)
+		}
+	}
+
+	for kind, ttl := range map[string]int64{
+		"repos": int64(c.CacheTTL.Repos), "prs": int64(c.CacheTTL.PRs), "comments": int64(c.CacheTTL.Comments),
+	} {
+		if ttl < 0 {
+			v.add([]string{"cache_ttl", kind}, false, "cache_ttl.%s must not be negative", kind)
+		}
+	}
+	for kind, limits := range map[string]FetchLimits{
+		"repos": c.Limits.Repos, "prs": c.Limits.PRs, "comments": c.Limits.Comments,
+	} {
+		for field, value := range map[string]int{
+			"per_page": limits.PerPage, "max_pages": limits.MaxPages, "max_items": limits.MaxItems,
+		} {
+			if value < 0 {
+				v.add([]string{"limits", kind, field}, false, "limits.%s.%s must not be negative", kind, field)
+			}
+		}
+		if limits.PerPage > 100 {
+			v.add([]string{"limits", kind, "per_page"}, false, "limits.%s.per_page must be at most 100", kind)
+		}
+	}
+	if c.Prefetch.Concurrency < 1 || c.Prefetch.Concurrency > 16 {
+		v.add([]string{"prefetch", "concurrency"}, false, "prefetch.concurrency must be between 1 and 16")
+	}
+	if c.Prefetch.Comments < 0 {
+		v.add([]string{"prefetch", "comments"}, false, "prefetch.comments must not be negative")
+	}
+
+	for repo := range c.Repos {
+		if !isRepoName(repo) {
+			v.add([]string{"repos", repo}, true, "repos key %q is not a repository (owner/name)", repo)
+		}
+	}
+	for alias, repo := range c.Aliases {
+		if strings.Contains(alias, "/") {
+			v.add([]string{"aliases", alias}, true, "alias %q must not contain /", alias)
+		}
+		if !isRepoName(repo) {
+			v.add([]string{"aliases", alias}, false, "alias %q points to %q, which is not a repository (owner/name)", alias, repo)
+		}
+	}
+
+	if c.DefaultProfile != "" {
+		if _, ok := c.Profiles[c.DefaultProfile]; !ok {
+			v.add([]string{"default_profile"}, false, "default_profile %q is not one of the configured profiles", c.DefaultProfile)
+		}
+	}
+	for name, profile := range c.Profiles {
+		if profile.Provider != "" && !slices.Contains(Providers, profile.Provider) {
+			v.add([]string{"profiles", name, "provider"}, false, "unknown provider %q (expected one of %s)",
+				profile.Provider, strings.Join(Providers, ", "))
+		}
+		if strings.Contains(profile.Host, "://") {
+			v.add([]string{"profiles", name, "host"}, false, "host %q should be a hostname like github.com; put API URLs in base_url", profile.Host)
+		}
+		if profile.Timeout < 0 {
+			v.add([]string{"profiles", name, "timeout"}, false, "timeout must not be negative")
+		}
+		if v.set("profiles", name, "page_size") && (profile.PageSize < 1 || profile.PageSize > 100) {
+			v.add([]string{"profiles", name, "page_size"}, false, "page_size must be between 1 and 100, got %d", profile.PageSize)
+		}
+		if profile.Theme != "" {
+			v.checkTheme([]string{"profiles", name, "theme"}, profile.Theme, path)
+		}
+	}
+}
+
+// checkTheme reports a theme that is neither a built-in style nor an existing style file
+func (v *validator) checkTheme(keyPath []string, theme, path string) {
+	if slices.Contains(Themes, theme) {
+		return
+	}
+	styleFile := (&Config{file: path}).themePath(theme)
+	if _, err := os.Stat(styleFile); err != nil {
+		v.add(keyPath, false, "theme %q is neither a built-in style (%s) nor an existing style file",
+			theme, strings.Join(Themes, ", "))
+	}
+}
+
+// isRepoName reports whether s has the form owner/name
+func isRepoName(s string) bool {
+	owner, name, ok := strings.Cut(s, "/")
+	return ok && owner != "" && name != "" && !strings.Contains(name, "/")
+}
+
+// lookup returns the key and value nodes at the key path of a YAML mapping, or nils
+func lookup(node *yaml.Node, path ...string) (key, value *yaml.Node) {
+	for _, name := range path {
+		if node == nil || node.Kind != yaml.MappingNode {
+			return nil, nil
+		}
+		key, value = nil, nil
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == name {
+				key, value = node.Content[i], node.Content[i+1]
+				break
+			}
+		}
+		node = value
+	}
+	return key, value
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Initial holds the settings chosen during first-run setup
+type Initial struct {
+	Token     string // Written to the file only when it could not be stored in the keyring
+	Host      string
+	Clipboard string
+	Theme     string
+}
+
+// initialFile is the layout of a configuration file written by first-run setup
+type initialFile struct {
+	Token     string `yaml:"token,omitempty"`
+	Host      string `yaml:"host,omitempty"`
+	Theme     string `yaml:"theme,omitempty"`
+	Clipboard struct {
+		Backend string `yaml:"backend,omitempty"`
+	} `yaml:"clipboard,omitempty"`
+}
+
+// WriteInitial writes a configuration file holding the first-run settings to path.
+// An existing file is only replaced when overwrite is set.
+func WriteInitial(path string, initial Initial, overwrite bool) error {
+	if !overwrite {
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("config file %s already exists", path)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to check config file: %w", err)
+		}
+	}
+
+	var file initialFile
+	file.Token = initial.Token
+	if initial.Host != DefaultHost {
+		file.Host = initial.Host
+	}
+	file.Theme = initial.Theme
+	file.Clipboard.Backend = initial.Clipboard
+
+	content, err := yaml.Marshal(file)
+	if err != nil {
+		return err
+	}
+	content = append([]byte("# Written by nitpick setup; see config.example.yml for every option\n"), content...)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	// The file may hold a token, so keep it private
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package diff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Hunk returns the part of a file's unified diff from the header of the hunk containing the line up to the
+// line itself, like the diff_hunk of a GitHub review comment. old selects a line number of the old file.
+// It returns an empty string if no hunk contains the line.
+func Hunk(diff string, line int, old bool) string {
+	if diff == "" || line <= 0 {
+		return ""
+	}
+
+	var hunk []string
+	oldLine, newLine := 0, 0
+	for _, text := range strings.Split(diff, "\n") {
+		if strings.HasPrefix(text, "@@") {
+			hunk = []string{text}
+			oldLine, newLine = hunkStart(text)
+			continue
+		}
+		if hunk == nil {
+			continue
+		}
+		hunk = append(hunk, text)
+
+		var current int
+		switch {
+		case strings.HasPrefix(text, "-"):
+			current = -1
+			if old {
+				current = oldLine
+			}
+			oldLine++
+		case strings.HasPrefix(text, "+"):
+			current = -1
+			if !old {
+				current = newLine
+			}
+			newLine++
+		default:
+			current = newLine
+			if old {
+				current = oldLine
+			}
+			oldLine++
+			newLine++
+		}
+		if current == line {
+			return strings.Join(hunk, "\n")
+		}
+	}
+	return ""
+}
+
+// Context returns the lines of a file's content leading up to the line as an unchanged hunk, for providers
+// that locate comments in files but serve no diff. It returns an empty string if the file has no such line.
+func Context(content string, line, before int) string {
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	if content == "" || line <= 0 || line > len(lines) {
+		return ""
+	}
+
+	start := max(line-before, 1)
+	hunk := []string{fmt.Sprintf("@@ -%d,%d +%d,%d @@", start, line-start+1, start, line-start+1)}
+	for _, text := range lines[start-1 : line] {
+		hunk = append(hunk, " "+strings.TrimSuffix(text, "\r"))
+	}
+	return strings.Join(hunk, "\n")
+}
+
+// Around returns the lines start to end of a file's content, with up to n lines above and below them, as an
+// unchanged hunk. It returns an empty string if the file has no line start.
+func Around(content string, start, end, n int) string {
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	if content == "" || start <= 0 || start > len(lines) {
+		return ""
+	}
+
+	first := max(start-n, 1)
+	last := min(max(end, start)+n, len(lines))
+	hunk := []string{fmt.Sprintf("@@ -%d,%d +%d,%d @@", first, last-first+1, first, last-first+1)}
+	for _, text := range lines[first-1 : last] {
+		hunk = append(hunk, " "+strings.TrimSuffix(text, "\r"))
+	}
+	return strings.Join(hunk, "\n")
+}
+
+// Line is a line of a file's unified diff with its line numbers in the old and the new file, 0 in the file
+// it is not in; hunk headers have neither
+type Line struct {
+	Text string
+	Old  int
+	New  int
+}
+
+// Lines numbers the lines of a file's unified diff, such as the patch of a file changed by a pull request
+func Lines(patch string) []Line {
+	if patch == "" {
+		return nil
+	}
+
+	var lines []Line
+	oldLine, newLine := 0, 0
+	for _, text := range strings.Split(strings.TrimSuffix(patch, "\n"), "\n") {
+		line := Line{Text: text}
+		switch {
+		case strings.HasPrefix(text, "@@"):
+			oldLine, newLine = hunkStart(text)
+		case strings.HasPrefix(text, "-"):
+			line.Old = oldLine
+			oldLine++
+		case strings.HasPrefix(text, "+"):
+			line.New = newLine
+			newLine++
+		case strings.HasPrefix(text, `\`):
+			// "\ No newline at end of file" is not a line of either file
+		default:
+			line.Old, line.New = oldLine, newLine
+			oldLine++
+			newLine++
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// hunkStart parses the first old and new line numbers from a hunk header such as @@ -10,6 +10,8 @@
+func hunkStart(header string) (oldLine, newLine int) {
+	for _, field := range strings.Fields(header) {
+		start, _, _ := strings.Cut(field[1:], ",")
+		switch field[0] {
+		case '-':
+			oldLine, _ = strconv.Atoi(start)
+		case '+':
+			newLine, _ = strconv.Atoi(start)
+		}
+	}
+	return oldLine, newLine
+}
+
+// SplitFiles splits a multi-file unified diff, as produced by git diff, into the diff of each file
+// keyed by both its old and new path. The diff of a file starts at its first hunk header.
+func SplitFiles(unified string) map[string]string {
+	files := make(map[string]string)
+
+	var oldPath, newPath string
+	var body []string
+	flush := func() {
+		if len(body) == 0 {
+			return
+		}
+		text := strings.Join(body, "\n")
+		if oldPath != "" {
+			files[oldPath] = text
+		}
+		if newPath != "" {
+			files[newPath] = text
+		}
+		body = nil
+	}
+
+	for _, line := range strings.Split(unified, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			flush()
+			oldPath, newPath = "", ""
+		case body == nil && strings.HasPrefix(line, "--- "):
+			oldPath = diffPath(line[4:], "a/")
+		case body == nil && strings.HasPrefix(line, "+++ "):
+			newPath = diffPath(line[4:], "b/")
+		case strings.HasPrefix(line, "@@") || body != nil:
+			body = append(body, line)
+		}
+	}
+	flush()
+
+	return files
+}
+
+// diffPath returns the path named in a ---/+++ line of a diff, or an empty string for /dev/null
+func diffPath(name, prefix string) string {
+	name, _, _ = strings.Cut(name, "\t")
+	if name == "/dev/null" {
+		return ""
+	}
+	return strings.TrimPrefix(name, prefix)
+}
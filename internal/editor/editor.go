@@ -0,0 +1,108 @@
+package editor
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/stefrushxyz/nitpick/internal/browser"
+)
+
+// Presets maps the names of supported editors to the URI or command template that opens a file at a line.
+// {path} is replaced by the file's absolute path and {line} by the line number; {nvim} by the address of
+// the Neovim server nitpick runs in ($NVIM).
+var Presets = map[string]string{
+	"vscode":          "vscode://file{path}:{line}:1",
+	"vscode-insiders": "vscode-insiders://file{path}:{line}:1",
+	"cursor":          "cursor://file{path}:{line}:1",
+	"idea":            "idea://open?file={path}&line={line}",
+	"zed":             "zed://file{path}:{line}:1",
+	"nvim":            `nvim --server {nvim} --remote-send <C-\><C-N>:edit<Space>+{line}<Space>{path}<CR>`,
+}
+
+// errNoNeovimServer is returned when the nvim preset is used outside of a Neovim terminal
+var errNoNeovimServer = errors.New("no Neovim server to open the file in: run nitpick in a Neovim terminal, or set NVIM")
+
+// Launch describes how a file is opened: by a URI handler, or by running a command
+type Launch struct {
+	URI      string    // URI handed to the system's URI handler, e.g. vscode://file/...
+	Cmd      *exec.Cmd // Command to run when URI is empty
+	Terminal bool      // Whether Cmd is a terminal editor that needs the terminal until it exits
+}
+
+// Prepare returns how to open path at line with the editor spec: a preset name, a URI template
+// (containing "://"), a command template, or empty for $VISUAL or $EDITOR in the terminal
+func Prepare(spec, path string, line int) (*Launch, error) {
+	if line < 1 {
+		line = 1
+	}
+	if preset, ok := Presets[spec]; ok {
+		spec = preset
+	}
+
+	if spec == "" {
+		return terminalEditor(path, line)
+	}
+
+	if strings.Contains(spec, "://") {
+		uriPath := filepath.ToSlash(path)
+		if !strings.HasPrefix(uriPath, "/") {
+			// Windows paths, e.g. C:/src, need a leading slash to form file URIs
+			uriPath = "/" + uriPath
+		}
+		return &Launch{URI: expand(spec, (&url.URL{Path: uriPath}).EscapedPath(), line)}, nil
+	}
+
+	if strings.Contains(spec, "{nvim}") && os.Getenv("NVIM") == "" {
+		return nil, errNoNeovimServer
+	}
+	args := strings.Fields(spec)
+	for i, arg := range args {
+		args[i] = expand(arg, path, line)
+	}
+	return &Launch{Cmd: exec.Command(args[0], args[1:]...)}, nil
+}
+
+// terminalEditor opens path at line in $VISUAL or $EDITOR, with the +line argument most terminal editors accept
+func terminalEditor(path string, line int) (*Launch, error) {
+	command := os.Getenv("VISUAL")
+	if command == "" {
+		command = os.Getenv("EDITOR")
+	}
+	if command == "" {
+		return nil, errors.New("no editor configured: set editor in the config file, or VISUAL or EDITOR")
+	}
+
+	args := strings.Fields(command)
+	args = append(args, "+"+strconv.Itoa(line), path)
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return &Launch{Cmd: cmd, Terminal: true}, nil
+}
+
+// expand replaces the placeholders of a URI or command template
+func expand(template, path string, line int) string {
+	return strings.NewReplacer(
+		"{path}", path,
+		"{line}", strconv.Itoa(line),
+		"{nvim}", os.Getenv("NVIM"),
+	).Replace(template)
+}
+
+// Start runs a launch that does not need the terminal, without waiting for the editor to exit
+func (l *Launch) Start() error {
+	if l.URI != "" {
+		return browser.Open(l.URI)
+	}
+
+	if err := l.Cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start editor: %w", err)
+	}
+	// Editors and their remote clients may outlive nitpick
+	return l.Cmd.Process.Release()
+}
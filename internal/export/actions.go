@@ -0,0 +1,99 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/ui"
+)
+
+// Annotations renders the review threads of a pull request as GitHub Actions workflow commands, one
+// annotation of the given level (notice, warning or error) per thread, shown on the commented lines of the
+// job's run and the pull request's diff
+func Annotations(comments []*github.PullRequestComment, level string) string {
+	var b strings.Builder
+	for _, thread := range ghclient.GroupCommentThreads(comments) {
+		root := thread.Root
+
+		var props []string
+		if path := root.GetPath(); path != "" {
+			props = append(props, "file="+escapeProperty(path))
+			if line := threadLine(root); line != 0 {
+				if start := root.GetStartLine(); start != 0 && start < line {
+					props = append(props, fmt.Sprintf("line=%d", start), fmt.Sprintf("endLine=%d", line))
+				} else {
+					props = append(props, fmt.Sprintf("line=%d", line))
+				}
+			}
+		}
+		title := fmt.Sprintf("Review comment by %s", root.GetUser().GetLogin())
+		props = append(props, "title="+escapeProperty(title))
+
+		message := strings.TrimSpace(root.GetBody())
+		if url := root.GetHTMLURL(); url != "" {
+			message += "\n\n" + url
+		}
+		fmt.Fprintf(&b, "::%s %s::%s\n", level, strings.Join(props, ","), escapeData(message))
+	}
+	return b.String()
+}
+
+// JobSummary renders the review threads of a pull request as markdown for a GitHub Actions job summary
+// ($GITHUB_STEP_SUMMARY)
+func JobSummary(repo string, pr *github.PullRequest, comments []*github.PullRequestComment) string {
+	threads := ghclient.GroupCommentThreads(comments)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Review comments on [%s#%d](%s)\n\n", repo, pr.GetNumber(), pr.GetHTMLURL())
+	if len(threads) == 0 {
+		b.WriteString("✅ No unresolved review comments.\n")
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "⚠️ %d unresolved review %s.\n\n", len(threads), plural(len(threads), "thread", "threads"))
+	b.WriteString("| Reviewer | Location | Comment | Replies |\n| --- | --- | --- | --- |\n")
+	for _, thread := range threads {
+		root := thread.Root
+
+		location := "General"
+		if path := root.GetPath(); path != "" {
+			location = "`" + path + "`"
+			if line := threadLine(root); line != 0 {
+				location = fmt.Sprintf("`%s:%d`", path, line)
+			}
+		}
+		summary := escapeCell(ui.CommentItem{Comment: root}.Title())
+		if url := root.GetHTMLURL(); url != "" {
+			summary = fmt.Sprintf("[%s](%s)", summary, url)
+		}
+
+		fmt.Fprintf(&b, "| %s | %s | %s | %d |\n",
+			escapeCell(root.GetUser().GetLogin()), location, summary, len(thread.Replies))
+	}
+	return b.String()
+}
+
+// threadLine returns the line a thread's root comment is on, or 0 for a comment on a whole file
+func threadLine(root *github.PullRequestComment) int {
+	if line := root.GetLine(); line != 0 {
+		return line
+	}
+	return root.GetOriginalLine()
+}
+
+// escapeData escapes the message of a workflow command
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes a property value of a workflow command
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// escapeCell escapes text for a markdown table cell
+func escapeCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
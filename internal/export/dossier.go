@@ -0,0 +1,198 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+)
+
+// DossierData holds everything rendered into a PR review dossier
+type DossierData struct {
+	Repository   string
+	Number       int
+	Title        string
+	Author       string
+	State        string
+	IsDraft      bool
+	IsMerged     bool
+	Created      string
+	SourceBranch string
+	TargetBranch string
+	URL          string
+	Body         string
+	Files        []FileData
+	Additions    int
+	Deletions    int
+	Threads      []ThreadData
+	Generated    string
+}
+
+// FileData summarizes a changed file
+type FileData struct {
+	Path      string
+	Status    string
+	Additions int
+	Deletions int
+}
+
+// ThreadData holds a review thread
+type ThreadData struct {
+	Path     string
+	Lines    string
+	DiffHunk string
+	URL      string
+	Comments []CommentData
+}
+
+// CommentData holds a single comment within a thread
+type CommentData struct {
+	Author string
+	Date   string
+	Body   string
+}
+
+const dossierTemplate = `# {{.Repository}} #{{.Number}}: {{.Title}}
+
+| | |
+| --- | --- |
+| **Author** | {{.Author}} |
+| **Status** | {{.State}}{{if .IsDraft}} (draft){{end}}{{if .IsMerged}} (merged){{end}} |
+{{- if .Created}}
+| **Created** | {{.Created}} |
+{{- end}}
+{{- if .SourceBranch}}
+| **Branches** | ` + "`{{.SourceBranch}}`" + ` → ` + "`{{.TargetBranch}}`" + ` |
+{{- end}}
+| **Link** | {{.URL}} |
+
+## Description
+
+{{if .Body}}{{.Body}}{{else}}_No description provided._{{end}}
+
+## Changed Files
+
+**Files changed**: {{len .Files}} (+{{.Additions}} −{{.Deletions}})
+
+{{range .Files -}}
+- ` + "`{{.Path}}`" + ` ({{.Status}}, +{{.Additions}} −{{.Deletions}})
+{{end}}
+## Review Threads
+{{if not .Threads}}
+_No review comments._
+{{end}}
+{{- range $i, $t := .Threads}}
+### {{add $i 1}}. {{if $t.Path}}` + "`{{$t.Path}}`" + `{{if $t.Lines}} ({{$t.Lines}}){{end}}{{else}}General{{end}}
+{{- if $t.DiffHunk}}
+
+` + "```diff" + `
+{{$t.DiffHunk}}
+` + "```" + `
+{{- end}}
+{{range $t.Comments}}
+**{{.Author}}**{{if .Date}} · {{.Date}}{{end}}
+
+{{quote .Body}}
+{{end}}
+{{- if $t.URL}}
+[View on GitHub]({{$t.URL}})
+{{end}}
+{{- end}}
+---
+_Exported by nitpick on {{.Generated}}_
+`
+
+var dossierTmpl = template.Must(template.New("dossier").Funcs(template.FuncMap{
+	"add":   func(a, b int) int { return a + b },
+	"quote": quote,
+}).Parse(dossierTemplate))
+
+// Dossier renders a markdown document of a pull request's metadata, changed files and review threads
+func Dossier(repo *github.Repository, pr *github.PullRequest, files []*github.CommitFile, comments []*github.PullRequestComment) (string, error) {
+	data := DossierData{
+		Repository:   repo.GetFullName(),
+		Number:       pr.GetNumber(),
+		Title:        pr.GetTitle(),
+		Author:       pr.GetUser().GetLogin(),
+		State:        pr.GetState(),
+		IsDraft:      pr.GetDraft(),
+		IsMerged:     pr.GetMerged(),
+		SourceBranch: pr.GetHead().GetRef(),
+		TargetBranch: pr.GetBase().GetRef(),
+		URL:          pr.GetHTMLURL(),
+		Body:         strings.TrimSpace(pr.GetBody()),
+		Generated:    time.Now().Format("2006-01-02 15:04:05"),
+	}
+	if pr.CreatedAt != nil {
+		data.Created = pr.CreatedAt.Format("2006-01-02 15:04")
+	}
+
+	for _, file := range files {
+		data.Files = append(data.Files, FileData{
+			Path:      file.GetFilename(),
+			Status:    file.GetStatus(),
+			Additions: file.GetAdditions(),
+			Deletions: file.GetDeletions(),
+		})
+		data.Additions += file.GetAdditions()
+		data.Deletions += file.GetDeletions()
+	}
+
+	for _, thread := range ghclient.GroupCommentThreads(comments) {
+		data.Threads = append(data.Threads, newThreadData(thread))
+	}
+
+	var buf bytes.Buffer
+	if err := dossierTmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render dossier: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// newThreadData converts a comment thread to template-friendly data
+func newThreadData(thread ghclient.CommentThread) ThreadData {
+	root := thread.Root
+	data := ThreadData{
+		Path:     root.GetPath(),
+		DiffHunk: root.GetDiffHunk(),
+		URL:      root.GetHTMLURL(),
+	}
+
+	line := root.GetLine()
+	if line == 0 {
+		line = root.GetOriginalLine()
+	}
+	if line != 0 {
+		if start := root.GetStartLine(); start != 0 && start != line {
+			data.Lines = fmt.Sprintf("L%d-%d", start, line)
+		} else {
+			data.Lines = fmt.Sprintf("L%d", line)
+		}
+	}
+
+	for _, comment := range append([]*github.PullRequestComment{root}, thread.Replies...) {
+		commentData := CommentData{
+			Author: comment.GetUser().GetLogin(),
+			Body:   strings.TrimSpace(comment.GetBody()),
+		}
+		if comment.CreatedAt != nil {
+			commentData.Date = comment.CreatedAt.Format("2006-01-02 15:04")
+		}
+		data.Comments = append(data.Comments, commentData)
+	}
+
+	return data
+}
+
+// quote formats text as a markdown blockquote
+func quote(text string) string {
+	if text == "" {
+		text = "_No content provided_"
+	}
+	return "> " + strings.ReplaceAll(text, "\n", "\n> ")
+}
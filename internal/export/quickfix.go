@@ -0,0 +1,101 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/ui"
+)
+
+// QuickfixPattern matches the lines written by Quickfix: file, line and message
+const QuickfixPattern = `^(.+?):(\d+): (.*)$`
+
+// Quickfix renders the review threads of a pull request as a Vim quickfix list, one
+// "path:line: reviewer: summary" line per thread ordered by file and line, for :cfile or vim -q.
+// Threads not on a file, such as comments on a whole patch set, are left out.
+func Quickfix(comments []*github.PullRequestComment) string {
+	type entry struct {
+		path string
+		line int
+		text string
+	}
+
+	var entries []entry
+	for _, thread := range ghclient.GroupCommentThreads(comments) {
+		root := thread.Root
+		if root.GetPath() == "" {
+			continue
+		}
+
+		line := threadLine(root)
+		if line == 0 {
+			// Comments on a whole file jump to its top
+			line = 1
+		}
+
+		text := fmt.Sprintf("%s: %s", root.GetUser().GetLogin(), ui.CommentItem{Comment: root}.Title())
+		if n := len(thread.Replies); n > 0 {
+			text += fmt.Sprintf(" (+%d %s)", n, plural(n, "reply", "replies"))
+		}
+		entries = append(entries, entry{path: root.GetPath(), line: line, text: text})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].path != entries[j].path {
+			return entries[i].path < entries[j].path
+		}
+		return entries[i].line < entries[j].line
+	})
+
+	var b strings.Builder
+	for _, e := range entries {
+		fmt.Fprintf(&b, "%s:%d: %s\n", e.path, e.line, e.text)
+	}
+	return b.String()
+}
+
+// VSCodeTasks renders a VS Code tasks.json with a task that runs command, which prints a quickfix list,
+// and a problem matcher that turns its lines into entries of the Problems panel
+func VSCodeTasks(label, command string) (string, error) {
+	tasks := map[string]any{
+		"version": "2.0.0",
+		"tasks": []map[string]any{{
+			"label":   label,
+			"type":    "shell",
+			"command": command,
+			"presentation": map[string]any{
+				"reveal": "silent",
+			},
+			"problemMatcher": map[string]any{
+				"owner":        "nitpick",
+				"source":       "nitpick",
+				"fileLocation": []string{"relative", "${workspaceFolder}"},
+				"severity":     "info",
+				"pattern": map[string]any{
+					"regexp":  QuickfixPattern,
+					"file":    1,
+					"line":    2,
+					"message": 3,
+				},
+			},
+		}},
+	}
+
+	data, err := json.MarshalIndent(tasks, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode tasks: %w", err)
+	}
+	return string(data) + "\n", nil
+}
+
+// plural returns singular when n is 1, and otherwise pluralForm
+func plural(n int, singular, pluralForm string) string {
+	if n == 1 {
+		return singular
+	}
+	return pluralForm
+}
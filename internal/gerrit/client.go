@@ -0,0 +1,430 @@
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/stefrushxyz/nitpick/internal/diff"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/rest"
+)
+
+// defaultPerPage is the page size used when Options.PageSize is unset
+const defaultPerPage = 100
+
+// contextLines is the number of lines shown above a commented line, as Gerrit serves no diff hunks
+const contextLines = 3
+
+// xssiPrefix is the line Gerrit prepends to JSON responses to prevent their inclusion as scripts
+var xssiPrefix = []byte(")]}'")
+
+// errMissingHost is returned when neither a host nor a base URL is configured, as Gerrit is self-hosted
+var errMissingHost = errors.New("the gerrit provider needs host set to your Gerrit hostname, or base_url")
+
+// Client reads projects, changes and inline comments from the Gerrit REST API, presenting them as the
+// GitHub repositories, pull requests and review comments the TUI and prompt generator work with.
+// A project named parent/name is presented as repository name owned by parent.
+type Client struct {
+	rest          *rest.Client
+	baseURL       string // URL of the Gerrit web UI, which also serves the API
+	authenticated bool
+}
+
+// Options configures a Client. Token is username:http_password, as generated in the Gerrit settings,
+// Host the hostname of the Gerrit server and BaseURL overrides https://<host>, e.g. for servers under a
+// path.
+type Options = rest.Options
+
+// New creates a Gerrit client. Without credentials the API is read anonymously.
+func New(opts Options) (*Client, error) {
+	baseURL := opts.BaseURL
+	if baseURL == "" {
+		if opts.Host == "" {
+			return nil, errMissingHost
+		}
+		baseURL = "https://" + opts.Host
+	}
+
+	user, password, ok := strings.Cut(opts.Token, ":")
+	if opts.Token != "" && !ok {
+		return nil, errors.New("the gerrit token must be username:http_password")
+	}
+
+	api := rest.API{
+		Name:     "Gerrit",
+		BaseURL:  baseURL,
+		PageSize: defaultPerPage,
+		// Gerrit reports errors as plain text, as the default error describes them
+	}
+	if user != "" {
+		api.Auth = func(req *http.Request) {
+			req.SetBasicAuth(user, password)
+		}
+	}
+	client, err := rest.New(api, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{rest: client, baseURL: client.BaseURL(), authenticated: user != ""}, nil
+}
+
+// Timeout returns the timeout for the requests of a single fetch
+func (c *Client) Timeout() time.Duration {
+	return c.rest.Timeout()
+}
+
+// do sends an API request with in, if not nil, as its JSON body and returns the response body
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in any) ([]byte, error) {
+	if c.authenticated {
+		// Authenticated requests are served under /a/
+		path = "/a" + path
+	}
+	resp, err := c.rest.Do(ctx, method, path, query, in)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// get fetches an API path and decodes the JSON response into out
+func (c *Client) get(ctx context.Context, path string, query url.Values, out any) error {
+	body, err := c.do(ctx, http.MethodGet, path, query, nil)
+	if err != nil {
+		return err
+	}
+	return c.rest.Decode(bytes.TrimPrefix(body, xssiPrefix), out)
+}
+
+// projectName returns the Gerrit project presented as the repository name owned by owner
+func projectName(owner, name string) string {
+	if owner == "" {
+		return name
+	}
+	return owner + "/" + name
+}
+
+// changeID returns the API identifier of a change given its project and number
+func changeID(project string, number int) string {
+	return url.PathEscape(project + "~" + strconv.Itoa(number))
+}
+
+// ListRepos lists the active projects of the server by name
+func (c *Client) ListRepos(ctx context.Context, opts ghclient.ListOptions) ([]*github.Repository, error) {
+	perPage := c.rest.PageSize(opts)
+	query := url.Values{"d": {""}, "state": {"ACTIVE"}, "n": {strconv.Itoa(perPage)}}
+
+	return rest.List(ctx, opts, func(cursor string) (rest.Page[*github.Repository], error) {
+		skip, _ := strconv.Atoi(cursor)
+		query.Set("S", strconv.Itoa(skip))
+		var page map[string]project
+		if err := c.get(ctx, "/projects/", query, &page); err != nil {
+			return rest.Page[*github.Repository]{}, err
+		}
+		names := make([]string, 0, len(page))
+		for name := range page {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var result rest.Page[*github.Repository]
+		for _, name := range names {
+			result.Items = append(result.Items, page[name].toRepository(name, c.baseURL))
+		}
+		if len(page) >= perPage {
+			result.Next = strconv.Itoa(skip + len(page))
+		}
+		return result, nil
+	})
+}
+
+// GetRepo fetches a single project by the owner and name it is presented as
+func (c *Client) GetRepo(ctx context.Context, owner, name string) (*github.Repository, error) {
+	var p project
+	full := projectName(owner, name)
+	if err := c.get(ctx, "/projects/"+url.PathEscape(full), nil, &p); err != nil {
+		return nil, err
+	}
+	return p.toRepository(full, c.baseURL), nil
+}
+
+// changeOptions are the additional fields requested with changes
+var changeOptions = []string{"DETAILED_ACCOUNTS", "CURRENT_REVISION", "CURRENT_COMMIT"}
+
+// ListChanges lists the changes of a project, highest number first
+func (c *Client) ListChanges(ctx context.Context, owner, name string, opts ghclient.PRListOptions) ([]*github.PullRequest, error) {
+	terms := []string{"project:" + quote(projectName(owner, name))}
+	switch opts.State {
+	case "closed":
+		terms = append(terms, "is:closed")
+	case "all":
+	default:
+		terms = append(terms, "is:open")
+	}
+	if opts.Base != "" {
+		terms = append(terms, "branch:"+quote(opts.Base))
+	}
+
+	perPage := c.rest.PageSize(opts.ListOptions)
+	query := url.Values{"q": {strings.Join(terms, " ")}, "o": changeOptions, "n": {strconv.Itoa(perPage)}}
+
+	prs, err := rest.List(ctx, opts.ListOptions, func(cursor string) (rest.Page[*github.PullRequest], error) {
+		skip, _ := strconv.Atoi(cursor)
+		query.Set("S", strconv.Itoa(skip))
+		var page []change
+		if err := c.get(ctx, "/changes/", query, &page); err != nil {
+			return rest.Page[*github.PullRequest]{}, err
+		}
+		var result rest.Page[*github.PullRequest]
+		for _, ch := range page {
+			result.Items = append(result.Items, ch.toPullRequest(c.baseURL))
+		}
+		if len(page) > 0 && page[len(page)-1].MoreChanges {
+			result.Next = strconv.Itoa(skip + len(page))
+		}
+		return result, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(prs, func(i, j int) bool {
+		return prs[i].GetNumber() > prs[j].GetNumber()
+	})
+	return prs, nil
+}
+
+// getChange fetches a single change by number
+func (c *Client) getChange(ctx context.Context, project string, number int) (change, error) {
+	var ch change
+	err := c.get(ctx, "/changes/"+changeID(project, number), url.Values{"o": append([]string{"ALL_REVISIONS"}, changeOptions...)}, &ch)
+	return ch, err
+}
+
+// GetChange fetches a single change by number
+func (c *Client) GetChange(ctx context.Context, owner, name string, number int) (*github.PullRequest, error) {
+	ch, err := c.getChange(ctx, projectName(owner, name), number)
+	if err != nil {
+		return nil, err
+	}
+	return ch.toPullRequest(c.baseURL), nil
+}
+
+// listComments lists the published comments of every patch set of a change
+func (c *Client) listComments(ctx context.Context, project string, number int) ([]comment, error) {
+	var byPath map[string][]comment
+	if err := c.get(ctx, "/changes/"+changeID(project, number)+"/comments", nil, &byPath); err != nil {
+		return nil, err
+	}
+
+	var all []comment
+	for path, comments := range byPath {
+		for _, cm := range comments {
+			cm.Path = path
+			all = append(all, cm)
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].Updated.Before(all[j].Updated.Time)
+	})
+	return all, nil
+}
+
+// fileContent fetches the content of a file in a patch set, or in its parent commit
+func (c *Client) fileContent(ctx context.Context, project string, number, patchSet int, path string, parent bool) (string, error) {
+	endpoint := fmt.Sprintf("/changes/%s/revisions/%d/files/%s/content", changeID(project, number), patchSet, url.PathEscape(path))
+	var query url.Values
+	if parent {
+		query = url.Values{"parent": {"1"}}
+	}
+	body, err := c.do(ctx, http.MethodGet, endpoint, query, nil)
+	if err != nil {
+		return "", err
+	}
+	content, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(bytes.TrimPrefix(body, xssiPrefix))))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode file content: %w", err)
+	}
+	return string(content), nil
+}
+
+// ListComments lists the comments of a change's unresolved threads across its patch sets, with the lines
+// leading up to each commented line as their diff context, most recently updated first. Comments are listed
+// in full; opts only limits how many are kept.
+func (c *Client) ListComments(ctx context.Context, owner, name string, number int, opts ghclient.ListOptions) ([]*github.PullRequestComment, error) {
+	full := projectName(owner, name)
+	ch, err := c.getChange(ctx, full, number)
+	if err != nil {
+		return nil, err
+	}
+	pr := ch.toPullRequest(c.baseURL)
+	all, err := c.listComments(ctx, full, number)
+	if err != nil {
+		return nil, err
+	}
+
+	// Files are fetched once per patch set, side and path, and only for comments on a line
+	contents := make(map[string]string)
+	hunk := func(root comment) string {
+		if root.Line == 0 || strings.HasPrefix(root.Path, "/") {
+			// Comments on the patch set or the commit message rather than a file
+			return ""
+		}
+		parent := root.Side == "PARENT"
+		key := fmt.Sprintf("%d:%t:%s", root.PatchSet, parent, root.Path)
+		content, ok := contents[key]
+		if !ok {
+			var err error
+			if content, err = c.fileContent(ctx, full, number, root.PatchSet, root.Path, parent); err != nil {
+				slog.WarnContext(ctx, "failed to fetch file content", "project", full, "change", number, "path", root.Path, "err", err)
+			}
+			contents[key] = content
+		}
+		return diff.Context(content, root.Line, contextLines)
+	}
+
+	var comments []*github.PullRequestComment
+	for _, t := range threads(all) {
+		if !t.unresolved() {
+			continue
+		}
+		lines := hunk(t[0])
+		for _, cm := range t {
+			comments = append(comments, cm.toComment(pr, ch.revisionCommit(cm.PatchSet), t[0], lines))
+		}
+	}
+	slog.DebugContext(ctx, "listed change comments", "project", full, "change", number, "count", len(comments))
+
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].GetUpdatedAt().After(comments[j].GetUpdatedAt().Time)
+	})
+	if opts.Limit > 0 && len(comments) > opts.Limit {
+		comments = comments[:opts.Limit]
+	}
+	return comments, nil
+}
+
+// ListThreads lists the comment threads of a change, which are resolved unless their latest comment
+// is marked unresolved
+func (c *Client) ListThreads(ctx context.Context, owner, name string, number int) ([]ghclient.ReviewThread, error) {
+	all, err := c.listComments(ctx, projectName(owner, name), number)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []ghclient.ReviewThread
+	for _, t := range threads(all) {
+		rt := ghclient.ReviewThread{ID: t[0].ID, IsResolved: !t.unresolved()}
+		for _, cm := range t {
+			rt.CommentIDs = append(rt.CommentIDs, commentIDOf(cm.ID))
+		}
+		result = append(result, rt)
+	}
+	return result, nil
+}
+
+// findThread returns the thread of a change containing the comment matching
+func (c *Client) findThread(ctx context.Context, project string, number int, match func(comment) bool) (thread, error) {
+	all, err := c.listComments(ctx, project, number)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range threads(all) {
+		for _, cm := range t {
+			if match(cm) {
+				return t, nil
+			}
+		}
+	}
+	return nil, ghclient.ErrThreadNotFound
+}
+
+// replyTo publishes a comment replying to the latest comment of a thread, on its patch set, marking the
+// thread unresolved or resolved, and returns the ID of the new comment
+func (c *Client) replyTo(ctx context.Context, project string, number int, t thread, message string, unresolved bool) (string, error) {
+	root, last := t[0], t[len(t)-1]
+	input := map[string]any{
+		"in_reply_to": last.ID,
+		"message":     message,
+		"unresolved":  unresolved,
+	}
+	if root.Line != 0 {
+		input["line"] = root.Line
+	}
+	if root.Side != "" {
+		input["side"] = root.Side
+	}
+	if root.Range != nil {
+		input["range"] = root.Range
+	}
+	review := map[string]any{"comments": map[string]any{root.Path: []any{input}}}
+	path := fmt.Sprintf("/changes/%s/revisions/%d/review", changeID(project, number), last.PatchSet)
+	if _, err := c.do(ctx, http.MethodPost, path, nil, review); err != nil {
+		return "", err
+	}
+
+	// The review response does not identify the published comments, so find the new reply
+	reply, err := c.findThread(ctx, project, number, func(cm comment) bool { return cm.InReplyTo == last.ID })
+	if err != nil {
+		return "", err
+	}
+	for i := len(reply) - 1; i >= 0; i-- {
+		if reply[i].InReplyTo == last.ID && reply[i].Message == message {
+			return reply[i].ID, nil
+		}
+	}
+	return "", fmt.Errorf("published reply to comment %s not found", last.ID)
+}
+
+// Reply publishes a reply in the thread of the given comment, keeping the thread's resolution state
+func (c *Client) Reply(ctx context.Context, owner, name string, number int, commentID int64, body string) (*github.PullRequestComment, error) {
+	full := projectName(owner, name)
+	ch, err := c.getChange(ctx, full, number)
+	if err != nil {
+		return nil, err
+	}
+	t, err := c.findThread(ctx, full, number, func(cm comment) bool { return commentIDOf(cm.ID) == commentID })
+	if err != nil {
+		return nil, fmt.Errorf("%w for comment %d", err, commentID)
+	}
+	id, err := c.replyTo(ctx, full, number, t, body, t.unresolved())
+	if err != nil {
+		return nil, err
+	}
+
+	t, err = c.findThread(ctx, full, number, func(cm comment) bool { return cm.ID == id })
+	if err != nil {
+		return nil, err
+	}
+	for _, cm := range t {
+		if cm.ID == id {
+			return cm.toComment(ch.toPullRequest(c.baseURL), ch.revisionCommit(cm.PatchSet), t[0], ""), nil
+		}
+	}
+	return nil, fmt.Errorf("published reply %s not found", id)
+}
+
+// Resolve resolves a thread by replying "Done", or unresolves it by replying "Reopened", as Gerrit records
+// the resolution state on a thread's latest comment
+func (c *Client) Resolve(ctx context.Context, owner, name string, number int, threadID string, resolved bool) error {
+	full := projectName(owner, name)
+	t, err := c.findThread(ctx, full, number, func(cm comment) bool { return cm.ID == threadID })
+	if err != nil {
+		return err
+	}
+	message := "Done"
+	if !resolved {
+		message = "Reopened"
+	}
+	_, err = c.replyTo(ctx, full, number, t, message, !resolved)
+	return err
+}
@@ -0,0 +1,291 @@
+package gerrit
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// timeLayout is the format of Gerrit timestamps, which are in UTC
+const timeLayout = "2006-01-02 15:04:05.000000000"
+
+// timestamp is a Gerrit timestamp
+type timestamp struct {
+	time.Time
+}
+
+// UnmarshalJSON parses a timestamp such as "2024-01-02 03:04:05.000000000"
+func (t *timestamp) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseInLocation(timeLayout, s, time.UTC)
+	if err != nil {
+		return fmt.Errorf("invalid Gerrit timestamp %q: %w", s, err)
+	}
+	t.Time = parsed
+	return nil
+}
+
+// github returns the timestamp as a GitHub timestamp
+func (t timestamp) github() *github.Timestamp {
+	return &github.Timestamp{Time: t.Time}
+}
+
+// quote quotes a search operator value for Gerrit queries
+func quote(value string) string {
+	return strconv.Quote(value)
+}
+
+// project is a Gerrit project
+type project struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	State       string `json:"state"`
+}
+
+// toRepository presents the project named name as a GitHub repository owned by its parent path
+func (p project) toRepository(name, baseURL string) *github.Repository {
+	owner, short := path.Split(name)
+	return &github.Repository{
+		NodeID:      github.String(p.ID),
+		Name:        github.String(short),
+		FullName:    github.String(name),
+		Owner:       &github.User{Login: github.String(strings.TrimSuffix(owner, "/"))},
+		Description: github.String(p.Description),
+		HTMLURL:     github.String(baseURL + "/admin/repos/" + url.PathEscape(name)),
+	}
+}
+
+// account is a Gerrit account
+type account struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Username string `json:"username"`
+}
+
+// toUser presents the account as a GitHub user; autogenerated is set for comments tagged as such by bots
+// and CI systems, which are presented as coming from a bot
+func (a account) toUser(autogenerated bool) *github.User {
+	login := a.Username
+	if login == "" {
+		login = a.Email
+	}
+	if login == "" {
+		login = a.Name
+	}
+	kind := "User"
+	if autogenerated {
+		kind = "Bot"
+	}
+	return &github.User{
+		Login: github.String(login),
+		Name:  github.String(a.Name),
+		Email: github.String(a.Email),
+		Type:  github.String(kind),
+	}
+}
+
+// revision is a patch set of a change
+type revision struct {
+	Number int `json:"_number"`
+	Commit *struct {
+		Message string `json:"message"`
+	} `json:"commit"`
+}
+
+// change is a Gerrit change
+type change struct {
+	Project         string              `json:"project"`
+	Branch          string              `json:"branch"`
+	Subject         string              `json:"subject"`
+	Status          string              `json:"status"` // NEW, MERGED or ABANDONED
+	Number          int                 `json:"_number"`
+	Owner           account             `json:"owner"`
+	Created         timestamp           `json:"created"`
+	Updated         timestamp           `json:"updated"`
+	WorkInProgress  bool                `json:"work_in_progress"`
+	CurrentRevision string              `json:"current_revision"`
+	Revisions       map[string]revision `json:"revisions"`
+	MoreChanges     bool                `json:"_more_changes"`
+}
+
+// revisionCommit returns the commit of a patch set, if the change was fetched with it
+func (ch change) revisionCommit(patchSet int) string {
+	for sha, rev := range ch.Revisions {
+		if rev.Number == patchSet {
+			return sha
+		}
+	}
+	return ""
+}
+
+// webURL returns the URL of the change in the Gerrit web UI
+func (ch change) webURL(baseURL string) string {
+	return fmt.Sprintf("%s/c/%s/+/%d", baseURL, ch.Project, ch.Number)
+}
+
+// toPullRequest presents the change as a GitHub pull request whose head is its current patch set and
+// whose body is the rest of its commit message
+func (ch change) toPullRequest(baseURL string) *github.PullRequest {
+	state := "open"
+	if ch.Status != "NEW" {
+		state = "closed"
+	}
+
+	var body string
+	if rev, ok := ch.Revisions[ch.CurrentRevision]; ok && rev.Commit != nil {
+		_, body, _ = strings.Cut(rev.Commit.Message, "\n")
+		body = strings.TrimSpace(body)
+	}
+
+	return &github.PullRequest{
+		Number:    github.Int(ch.Number),
+		Title:     github.String(ch.Subject),
+		Body:      github.String(body),
+		State:     github.String(state),
+		Draft:     github.Bool(ch.WorkInProgress),
+		Merged:    github.Bool(ch.Status == "MERGED"),
+		HTMLURL:   github.String(ch.webURL(baseURL)),
+		User:      ch.Owner.toUser(false),
+		CreatedAt: ch.Created.github(),
+		UpdatedAt: ch.Updated.github(),
+		Head:      &github.PullRequestBranch{SHA: github.String(ch.CurrentRevision)},
+		Base:      &github.PullRequestBranch{Ref: github.String(ch.Branch)},
+	}
+}
+
+// commentRange is the range of lines a comment is on
+type commentRange struct {
+	StartLine int `json:"start_line"`
+	EndLine   int `json:"end_line"`
+}
+
+// comment is a published comment of a change
+type comment struct {
+	ID         string        `json:"id"`
+	Path       string        `json:"path"` // Set from the key the comment is listed under
+	PatchSet   int           `json:"patch_set"`
+	Side       string        `json:"side"` // PARENT for the base of the patch set, otherwise empty
+	Line       int           `json:"line"`
+	Range      *commentRange `json:"range"`
+	InReplyTo  string        `json:"in_reply_to"`
+	Message    string        `json:"message"`
+	Updated    timestamp     `json:"updated"`
+	Author     account       `json:"author"`
+	Tag        string        `json:"tag"`
+	Unresolved bool          `json:"unresolved"`
+}
+
+// commentIDOf returns a numeric ID for a Gerrit comment, whose IDs are strings
+func commentIDOf(id string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(id))
+	return int64(h.Sum64() >> 1)
+}
+
+// thread is a comment and its replies, oldest first
+type thread []comment
+
+// unresolved reports whether the thread's latest comment marks it unresolved
+func (t thread) unresolved() bool {
+	return t[len(t)-1].Unresolved
+}
+
+// threads groups comments, oldest first, into threads by following their in_reply_to links
+func threads(comments []comment) []thread {
+	byID := make(map[string]comment, len(comments))
+	for _, cm := range comments {
+		byID[cm.ID] = cm
+	}
+	rootID := func(cm comment) string {
+		// Bound the walk in case of a malformed reply chain
+		for i := 0; cm.InReplyTo != "" && i < len(comments); i++ {
+			parent, ok := byID[cm.InReplyTo]
+			if !ok {
+				break
+			}
+			cm = parent
+		}
+		return cm.ID
+	}
+
+	index := make(map[string]int)
+	var result []thread
+	for _, cm := range comments {
+		root := rootID(cm)
+		i, ok := index[root]
+		if !ok {
+			i = len(result)
+			index[root] = i
+			result = append(result, nil)
+		}
+		result[i] = append(result[i], cm)
+	}
+	for _, t := range result {
+		// Put the root first should a reply have been updated before it
+		for i, cm := range t {
+			if cm.InReplyTo == "" || byID[cm.InReplyTo].ID == "" {
+				t[0], t[i] = t[i], t[0]
+				break
+			}
+		}
+	}
+	return result
+}
+
+// toComment presents the comment as a review comment located where the root of its thread is. commit is
+// the comment's patch set and hunk the diff context of the commented line.
+func (cm comment) toComment(pr *github.PullRequest, commit string, root comment, hunk string) *github.PullRequestComment {
+	result := &github.PullRequestComment{
+		ID:             github.Int64(commentIDOf(cm.ID)),
+		Body:           github.String(cm.Message),
+		User:           cm.Author.toUser(strings.HasPrefix(cm.Tag, "autogenerated:")),
+		CreatedAt:      cm.Updated.github(),
+		UpdatedAt:      cm.Updated.github(),
+		HTMLURL:        github.String(fmt.Sprintf("%s/comment/%s/", pr.GetHTMLURL(), cm.ID)),
+		PullRequestURL: github.String(pr.GetHTMLURL()),
+		CommitID:       github.String(pr.GetHead().GetSHA()),
+	}
+	if commit != "" {
+		result.OriginalCommitID = github.String(commit)
+	}
+	if cm.ID != root.ID {
+		result.InReplyTo = github.Int64(commentIDOf(root.ID))
+	}
+
+	switch root.Path {
+	case "/PATCHSET_LEVEL":
+		// A comment on the patch set as a whole
+		return result
+	case "/COMMIT_MSG":
+		result.Path = github.String("Commit message")
+	default:
+		result.Path = github.String(root.Path)
+	}
+	if root.Line != 0 {
+		side := "RIGHT"
+		if root.Side == "PARENT" {
+			side = "LEFT"
+		}
+		result.Line = github.Int(root.Line)
+		result.OriginalLine = github.Int(root.Line)
+		result.Side = github.String(side)
+		if root.Range != nil && root.Range.StartLine != 0 && root.Range.StartLine != root.Line {
+			result.StartLine = github.Int(root.Range.StartLine)
+			result.OriginalStartLine = github.Int(root.Range.StartLine)
+		}
+	}
+	if hunk != "" {
+		result.DiffHunk = github.String(hunk)
+	}
+	return result
+}
@@ -0,0 +1,300 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/rest"
+)
+
+// defaultPerPage is the page size used when Options.PageSize is unset; Gitea allows 50 by default
+const defaultPerPage = 50
+
+// errMissingHost is returned when neither a host nor a base URL is configured, as Gitea is self-hosted
+var errMissingHost = errors.New("the gitea provider needs host set to your Gitea or Forgejo hostname, or base_url")
+
+// Client reads repositories, pull requests and review comments from the Gitea REST API (v1), which
+// Forgejo also serves. Its responses are close enough to GitHub's to decode repositories and pull
+// requests into the GitHub types the TUI and prompt generator work with.
+type Client struct {
+	rest *rest.Client
+}
+
+// Options configures a Client. Token is an access token with access to repositories, Host the hostname
+// of the Gitea or Forgejo instance and BaseURL overrides https://<host>/api/v1.
+type Options = rest.Options
+
+// New creates a Gitea client
+func New(opts Options) (*Client, error) {
+	baseURL := opts.BaseURL
+	if baseURL == "" {
+		if opts.Host == "" {
+			return nil, errMissingHost
+		}
+		baseURL = fmt.Sprintf("https://%s/api/v1", opts.Host)
+	}
+
+	client, err := rest.New(rest.API{
+		Name:        "Gitea",
+		BaseURL:     baseURL,
+		PageSize:    defaultPerPage,
+		MaxPageSize: defaultPerPage,
+		Auth: func(req *http.Request) {
+			req.Header.Set("Authorization", "token "+opts.Token)
+		},
+		Error: apiError,
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{rest: client}, nil
+}
+
+// Timeout returns the timeout for the requests of a single fetch
+func (c *Client) Timeout() time.Duration {
+	return c.rest.Timeout()
+}
+
+// get fetches an API path and decodes the JSON response into out, reporting whether the Link header
+// points at a next page
+func (c *Client) get(ctx context.Context, path string, query url.Values, out any) (bool, error) {
+	return c.request(ctx, http.MethodGet, path, query, nil, out)
+}
+
+// request sends an API request with in, if not nil, as its JSON body and decodes the JSON response into
+// out, reporting whether the Link header points at a next page
+func (c *Client) request(ctx context.Context, method, path string, query url.Values, in, out any) (bool, error) {
+	header, err := c.rest.Request(ctx, method, path, query, in, out)
+	if err != nil {
+		return false, err
+	}
+	return strings.Contains(header.Get("Link"), `rel="next"`), nil
+}
+
+// apiError describes an error response of the Gitea API
+func apiError(status int, body []byte) error {
+	var apiErr struct {
+		Message string `json:"message"`
+	}
+	message := rest.Message(body)
+	if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+		message = apiErr.Message
+	}
+	return fmt.Errorf("Gitea API error %d: %s", status, message)
+}
+
+// getAll fetches the pages of a list within opts
+func getAll[T any](ctx context.Context, c *Client, path string, query url.Values, opts ghclient.ListOptions) ([]T, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("limit", strconv.Itoa(c.rest.PageSize(opts)))
+
+	return rest.List(ctx, opts, func(cursor string) (rest.Page[T], error) {
+		page, _ := strconv.Atoi(cursor)
+		page = max(page, 1)
+		query.Set("page", strconv.Itoa(page))
+		var results []T
+		more, err := c.get(ctx, path, query, &results)
+		if err != nil {
+			return rest.Page[T]{}, err
+		}
+		if !more || len(results) == 0 {
+			return rest.Page[T]{Items: results}, nil
+		}
+		return rest.Page[T]{Items: results, Next: strconv.Itoa(page + 1)}, nil
+	})
+}
+
+// repoPath returns the API path of a repository given its owner and name
+func repoPath(owner, name string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name)
+}
+
+// ListRepos lists the repositories the user owns or has access to, most recently updated first
+func (c *Client) ListRepos(ctx context.Context, opts ghclient.ListOptions) ([]*github.Repository, error) {
+	repos, err := getAll[*github.Repository](ctx, c, "/user/repos", nil, opts)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(repos, func(i, j int) bool {
+		return repos[i].GetUpdatedAt().After(repos[j].GetUpdatedAt().Time)
+	})
+	return repos, nil
+}
+
+// GetRepo fetches a single repository by owner and name
+func (c *Client) GetRepo(ctx context.Context, owner, name string) (*github.Repository, error) {
+	var repo *github.Repository
+	if _, err := c.get(ctx, repoPath(owner, name), nil, &repo); err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+// ListChanges lists the pull requests of a repository, highest number first
+func (c *Client) ListChanges(ctx context.Context, owner, name string, opts ghclient.PRListOptions) ([]*github.PullRequest, error) {
+	state := opts.State
+	if state == "" {
+		state = "open"
+	}
+	query := url.Values{"state": {state}, "sort": {"newest"}}
+
+	prs, err := getAll[*github.PullRequest](ctx, c, repoPath(owner, name)+"/pulls", query, opts.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Base != "" {
+		// The Gitea API cannot filter pull requests by base branch
+		filtered := prs[:0]
+		for _, pr := range prs {
+			if pr.GetBase().GetRef() == opts.Base {
+				filtered = append(filtered, pr)
+			}
+		}
+		prs = filtered
+	}
+	sort.Slice(prs, func(i, j int) bool {
+		return prs[i].GetNumber() > prs[j].GetNumber()
+	})
+	return prs, nil
+}
+
+// GetChange fetches a single pull request by number
+func (c *Client) GetChange(ctx context.Context, owner, name string, number int) (*github.PullRequest, error) {
+	var pr *github.PullRequest
+	if _, err := c.get(ctx, fmt.Sprintf("%s/pulls/%d", repoPath(owner, name), number), nil, &pr); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// listReviewComments lists the review comments of every review of a pull request, oldest first
+func (c *Client) listReviewComments(ctx context.Context, owner, name string, number int) ([]reviewComment, error) {
+	path := fmt.Sprintf("%s/pulls/%d/reviews", repoPath(owner, name), number)
+	reviews, err := getAll[review](ctx, c, path, nil, ghclient.ListOptions{AllPages: true})
+	if err != nil {
+		return nil, err
+	}
+
+	var all []reviewComment
+	for _, r := range reviews {
+		if r.CommentsCount == 0 {
+			continue
+		}
+		var comments []reviewComment
+		if _, err := c.get(ctx, fmt.Sprintf("%s/%d/comments", path, r.ID), nil, &comments); err != nil {
+			return nil, err
+		}
+		all = append(all, comments...)
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].CreatedAt.Before(all[j].CreatedAt)
+	})
+	return all, nil
+}
+
+// ListComments lists the review comments of a pull request's unresolved conversations, most recently
+// updated first. Reviews are listed in full; opts only limits how many comments are kept.
+func (c *Client) ListComments(ctx context.Context, owner, name string, number int, opts ghclient.ListOptions) ([]*github.PullRequestComment, error) {
+	all, err := c.listReviewComments(ctx, owner, name, number)
+	if err != nil {
+		return nil, err
+	}
+
+	var comments []*github.PullRequestComment
+	for _, conv := range conversations(all) {
+		if conv.resolved() {
+			continue
+		}
+		root := conv[0]
+		for _, cm := range conv {
+			comments = append(comments, cm.toComment(root))
+		}
+	}
+	slog.DebugContext(ctx, "listed review comments", "repo", owner+"/"+name, "pr", number, "count", len(comments))
+
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].GetUpdatedAt().After(comments[j].GetUpdatedAt().Time)
+	})
+	if opts.Limit > 0 && len(comments) > opts.Limit {
+		comments = comments[:opts.Limit]
+	}
+	return comments, nil
+}
+
+// ListThreads lists the conversations of a pull request with their resolution state
+func (c *Client) ListThreads(ctx context.Context, owner, name string, number int) ([]ghclient.ReviewThread, error) {
+	all, err := c.listReviewComments(ctx, owner, name, number)
+	if err != nil {
+		return nil, err
+	}
+
+	var threads []ghclient.ReviewThread
+	for _, conv := range conversations(all) {
+		thread := ghclient.ReviewThread{ID: strconv.FormatInt(conv[0].ID, 10), IsResolved: conv.resolved()}
+		for _, cm := range conv {
+			thread.CommentIDs = append(thread.CommentIDs, cm.ID)
+		}
+		threads = append(threads, thread)
+	}
+	return threads, nil
+}
+
+// Reply adds a comment to the conversation of the given comment. The Gitea API has no replies, so the
+// comment is posted as a review comment on the same line, which joins the conversation.
+func (c *Client) Reply(ctx context.Context, owner, name string, number int, commentID int64, body string) (*github.PullRequestComment, error) {
+	all, err := c.listReviewComments(ctx, owner, name, number)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, conv := range conversations(all) {
+		for _, cm := range conv {
+			if cm.ID != commentID {
+				continue
+			}
+			root := conv[0]
+			payload := map[string]any{
+				"event": "COMMENT",
+				"comments": []map[string]any{{
+					"path":         root.Path,
+					"body":         body,
+					"new_position": root.Position,
+					"old_position": root.OriginalPosition,
+				}},
+			}
+			path := fmt.Sprintf("%s/pulls/%d/reviews", repoPath(owner, name), number)
+			var created review
+			if _, err := c.request(ctx, http.MethodPost, path, nil, payload, &created); err != nil {
+				return nil, err
+			}
+			var comments []reviewComment
+			if _, err := c.get(ctx, fmt.Sprintf("%s/%d/comments", path, created.ID), nil, &comments); err != nil {
+				return nil, err
+			}
+			if len(comments) == 0 {
+				return nil, fmt.Errorf("reply review %d has no comments", created.ID)
+			}
+			return comments[0].toComment(root), nil
+		}
+	}
+	return nil, fmt.Errorf("%w for comment %d", ghclient.ErrThreadNotFound, commentID)
+}
+
+// Resolve fails, as the Gitea API cannot resolve conversations
+func (c *Client) Resolve(context.Context, string, string, int, string, bool) error {
+	return fmt.Errorf("resolving Gitea conversations: %w", ghclient.ErrUnsupported)
+}
@@ -0,0 +1,97 @@
+package gitea
+
+import (
+	"cmp"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// review is a Gitea pull request review
+type review struct {
+	ID            int64 `json:"id"`
+	CommentsCount int   `json:"comments_count"`
+}
+
+// reviewComment is a comment of a Gitea pull request review
+type reviewComment struct {
+	ID               int64        `json:"id"`
+	Body             string       `json:"body"`
+	User             *github.User `json:"user"`
+	Resolver         *github.User `json:"resolver"` // Set once the comment's conversation is resolved
+	Path             string       `json:"path"`
+	CommitID         string       `json:"commit_id"`
+	OriginalCommitID string       `json:"original_commit_id"`
+	DiffHunk         string       `json:"diff_hunk"`
+	Position         int          `json:"position"`          // Line in the new file, 0 for a removed line
+	OriginalPosition int          `json:"original_position"` // Line in the old file
+	HTMLURL          string       `json:"html_url"`
+	PullRequestURL   string       `json:"pull_request_url"`
+	CreatedAt        time.Time    `json:"created_at"`
+	UpdatedAt        time.Time    `json:"updated_at"`
+}
+
+// conversation is the comments on one line of a pull request, oldest first
+type conversation []reviewComment
+
+// resolved reports whether the conversation was resolved, which Gitea records on its comments
+func (c conversation) resolved() bool {
+	for _, cm := range c {
+		if cm.Resolver != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// conversations groups review comments, oldest first, into the conversations Gitea shows per line
+func conversations(comments []reviewComment) []conversation {
+	type lineKey struct {
+		path        string
+		line, oline int
+	}
+
+	index := make(map[lineKey]int)
+	var result []conversation
+	for _, cm := range comments {
+		key := lineKey{cm.Path, cm.Position, cm.OriginalPosition}
+		i, ok := index[key]
+		if !ok {
+			i = len(result)
+			index[key] = i
+			result = append(result, nil)
+		}
+		result[i] = append(result[i], cm)
+	}
+	return result
+}
+
+// toComment presents the comment as a GitHub review comment; comments after the first of a
+// conversation are replies to it
+func (cm reviewComment) toComment(root reviewComment) *github.PullRequestComment {
+	line, side := cm.Position, "RIGHT"
+	if line == 0 {
+		line, side = cm.OriginalPosition, "LEFT"
+	}
+
+	result := &github.PullRequestComment{
+		ID:               github.Int64(cm.ID),
+		Body:             github.String(cm.Body),
+		User:             cm.User,
+		Path:             github.String(cm.Path),
+		CommitID:         github.String(cm.CommitID),
+		OriginalCommitID: github.String(cm.OriginalCommitID),
+		DiffHunk:         github.String(cmp.Or(cm.DiffHunk, root.DiffHunk)),
+		Line:             github.Int(line),
+		OriginalLine:     github.Int(line),
+		Side:             github.String(side),
+		HTMLURL:          github.String(cm.HTMLURL),
+		PullRequestURL:   github.String(cm.PullRequestURL),
+		CreatedAt:        &github.Timestamp{Time: cm.CreatedAt},
+		UpdatedAt:        &github.Timestamp{Time: cm.UpdatedAt},
+	}
+	if cm.ID != root.ID {
+		result.InReplyTo = github.Int64(root.ID)
+	}
+	return result
+}
@@ -0,0 +1,72 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// CI states of a commit, from its statuses and check runs
+const (
+	CISuccess = "success"
+	CIFailure = "failure"
+	CIPending = "pending"
+)
+
+// CIStatus summarizes the commit statuses and check runs of a commit
+type CIStatus struct {
+	State   string   // CISuccess, CIFailure or CIPending; empty when the commit has no checks
+	Total   int      // Number of statuses and check runs
+	Pending int      // Number of those queued or running
+	Failed  []string // Names of those that failed
+}
+
+// add counts a status or check run
+func (s *CIStatus) add(name string, pending, failed bool) {
+	s.Total++
+	switch {
+	case failed:
+		s.Failed = append(s.Failed, name)
+	case pending:
+		s.Pending++
+	}
+}
+
+// GetCIStatus fetches the commit statuses and check runs of a commit and summarizes them
+func (c *Client) GetCIStatus(ctx context.Context, owner, repo, ref string) (*CIStatus, error) {
+	var status CIStatus
+
+	combined, _, err := c.gh.Repositories.GetCombinedStatus(ctx, owner, repo, ref, &github.ListOptions{PerPage: defaultPerPage})
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range combined.Statuses {
+		state := s.GetState()
+		status.add(s.GetContext(), state == "pending", state == "failure" || state == "error")
+	}
+
+	runs, _, err := c.gh.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, &github.ListCheckRunsOptions{
+		ListOptions: github.ListOptions{PerPage: defaultPerPage},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, run := range runs.CheckRuns {
+		switch run.GetConclusion() {
+		case "failure", "timed_out", "cancelled", "action_required":
+			status.add(run.GetName(), false, true)
+		default:
+			status.add(run.GetName(), run.GetStatus() != "completed", false)
+		}
+	}
+
+	switch {
+	case len(status.Failed) > 0:
+		status.State = CIFailure
+	case status.Pending > 0:
+		status.State = CIPending
+	case status.Total > 0:
+		status.State = CISuccess
+	}
+	return &status, nil
+}
@@ -3,162 +3,385 @@ package github
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"net/http"
 	"slices"
 	"sort"
+	"sync"
 	"time"
 
-	tea "github.com/charmbracelet/bubbletea"
 	"github.com/google/go-github/v57/github"
+	"github.com/stefrushxyz/nitpick/internal/logging"
+	"github.com/stefrushxyz/nitpick/internal/retry"
 	"golang.org/x/oauth2"
 )
 
 // Client wraps the GitHub API client
 type Client struct {
-	gh *github.Client
+	gh       *github.Client
+	pageSize int
+	timeout  time.Duration
 }
 
-// Messages for async operations
-type ReposMsg struct {
-	Repos []*github.Repository
-	Err   error
+// Options configures a Client
+type Options struct {
+	Token     string           // Personal access token
+	Host      string           // GitHub host; empty or github.com for GitHub.com, otherwise a GitHub Enterprise hostname
+	BaseURL   string           // REST API base URL; overrides the URL derived from Host
+	PageSize  int              // Default number of results per page; defaults to 100
+	Timeout   time.Duration    // Timeout for the requests of a single fetch; defaults to 30s
+	Retry     retry.Policy     // Retries of requests failing transiently
+	Transport TransportOptions // Proxy and TLS settings of the connections to the API
+	Fixtures  string           // Directory of JSON fixtures answering requests instead of the API, or DemoFixtures
 }
 
-// PRsMsg is a message containing pull requests
-type PRsMsg struct {
-	PRs []*github.PullRequest
-	Err error
-}
-
-// CommentsMsg is a message containing pull request comments
-type CommentsMsg struct {
-	Comments []*github.PullRequestComment
-	Err      error
-}
+// defaultTimeout bounds the requests of a single fetch when Options.Timeout is unset
+const defaultTimeout = 30 * time.Second
 
 // New creates a new GitHub client
-func New(token string) *Client {
+func New(opts Options) (*Client, error) {
+	var base http.RoundTripper
+	var err error
+	if opts.Fixtures != "" {
+		base, err = newMockTransport(opts.Fixtures)
+	} else {
+		base, err = newBaseTransport(opts.Transport)
+	}
+	if err != nil {
+		return nil, err
+	}
 	ctx := context.Background()
+	if base != nil {
+		// oauth2 sends its requests through the client in the context
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: base})
+	}
 	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token},
+		&oauth2.Token{AccessToken: opts.Token},
 	)
 	tc := oauth2.NewClient(ctx, ts)
+	tc.Transport = newETagTransport(retry.Transport(logging.Transport(tc.Transport), opts.Retry))
 	gh := github.NewClient(tc)
 
-	return &Client{gh: gh}
+	switch {
+	case opts.BaseURL != "":
+		gh, err = gh.WithEnterpriseURLs(opts.BaseURL, opts.BaseURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid API base URL %q: %w", opts.BaseURL, err)
+		}
+	case opts.Host != "" && opts.Host != "github.com":
+		// GitHub Enterprise Server serves the API under /api/v3 on its own host
+		baseURL := fmt.Sprintf("https://%s/api/v3/", opts.Host)
+		uploadURL := fmt.Sprintf("https://%s/api/uploads/", opts.Host)
+
+		gh, err = gh.WithEnterpriseURLs(baseURL, uploadURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid GitHub host %q: %w", opts.Host, err)
+		}
+	}
+
+	pageSize := opts.PageSize
+	if pageSize <= 0 || pageSize > defaultPerPage {
+		pageSize = defaultPerPage
+	}
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return &Client{
+		gh:       gh,
+		pageSize: pageSize,
+		timeout:  timeout,
+	}, nil
 }
 
-// FetchRepos fetches all repositories (personal and organizational)
-func (c *Client) FetchRepos() tea.Cmd {
-	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+// Timeout returns the timeout applied to the requests of a single fetch
+func (c *Client) Timeout() time.Duration {
+	return c.timeout
+}
 
-		// Fetch user repositories
-		opts := &github.RepositoryListOptions{
-			ListOptions: github.ListOptions{PerPage: 100},
+// listOptions fills in the client's default page size
+func (c *Client) listOptions(opts ListOptions) ListOptions {
+	if opts.PerPage == 0 {
+		opts.PerPage = c.pageSize
+	}
+	return opts
+}
+
+// ListRepos lists the repositories (personal and organizational) accessible to the user.
+// The options apply to the user's repositories and to those of each organization.
+func (c *Client) ListRepos(ctx context.Context, opts ListOptions) ([]*github.Repository, error) {
+	opts = c.listOptions(opts)
+
+	// Get user repos
+	allRepos, err := paginate(ctx, opts, func(listOpts github.ListOptions) ([]*github.Repository, *github.Response, error) {
+		return c.gh.Repositories.List(ctx, "", &github.RepositoryListOptions{
+			ListOptions: listOpts,
 			Sort:        "updated",
 			Direction:   "desc",
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Get organization repos; tokens without the read:org scope list none
+	orgs, err := paginate(ctx, ListOptions{PerPage: opts.PerPage, AllPages: true}, func(listOpts github.ListOptions) ([]*github.Organization, *github.Response, error) {
+		return c.gh.Organizations.List(ctx, "", &listOpts)
+	})
+	if err == nil {
+		for _, orgRepos := range c.listOrgRepos(ctx, orgs, opts) {
+			allRepos = append(allRepos, orgRepos...)
 		}
+	}
 
-		var allRepos []*github.Repository
+	if opts.Limit > 0 && len(allRepos) > opts.Limit {
+		allRepos = allRepos[:opts.Limit]
+	}
 
-		// Get user repos
-		userRepos, _, err := c.gh.Repositories.List(ctx, "", opts)
-		if err != nil {
-			return ReposMsg{Err: err}
-		}
-		allRepos = append(allRepos, userRepos...)
-
-		// Get organization repos
-		orgs, _, err := c.gh.Organizations.List(ctx, "", nil)
-		if err == nil {
-			for _, org := range orgs {
-				orgRepos, _, err := c.gh.Repositories.ListByOrg(ctx, org.GetLogin(), &github.RepositoryListByOrgOptions{
-					ListOptions: github.ListOptions{PerPage: 100},
+	return allRepos, nil
+}
+
+// orgConcurrency bounds the organizations whose repositories are listed at once
+const orgConcurrency = 4
+
+// listOrgRepos lists the repositories of organizations concurrently, returning those of each organization
+// in the order of orgs. Organizations whose repositories cannot be listed have none.
+func (c *Client) listOrgRepos(ctx context.Context, orgs []*github.Organization, opts ListOptions) [][]*github.Repository {
+	// Pages arrive from several organizations at once, but the page hook expects them one at a time
+	if hook, ok := ctx.Value(pageHookKey[*github.Repository]{}).(func([]*github.Repository)); ok {
+		var mu sync.Mutex
+		ctx = WithPageHook(ctx, func(page []*github.Repository) {
+			mu.Lock()
+			defer mu.Unlock()
+			hook(page)
+		})
+	}
+
+	results := make([][]*github.Repository, len(orgs))
+	sem := make(chan struct{}, orgConcurrency)
+	var wg sync.WaitGroup
+	for i, org := range orgs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			repos, err := paginate(ctx, opts, func(listOpts github.ListOptions) ([]*github.Repository, *github.Response, error) {
+				return c.gh.Repositories.ListByOrg(ctx, org.GetLogin(), &github.RepositoryListByOrgOptions{
+					ListOptions: listOpts,
 					Sort:        "updated",
 					Direction:   "desc",
 				})
-				if err == nil {
-					allRepos = append(allRepos, orgRepos...)
-				}
+			})
+			if err != nil {
+				slog.DebugContext(ctx, "failed to list organization repositories", "org", org.GetLogin(), "error", err)
+				return
 			}
-		}
-
-		return ReposMsg{Repos: allRepos}
+			results[i] = repos
+		}()
 	}
+	wg.Wait()
+	return results
 }
 
-// FetchPRs fetches pull requests for the given repository
-func (c *Client) FetchPRs(repo *github.Repository) tea.Cmd {
-	return func() tea.Msg {
-		if repo == nil {
-			return PRsMsg{Err: fmt.Errorf("no repository provided")}
-		}
+// PRListOptions controls which pull requests ListChanges returns
+type PRListOptions struct {
+	State string // open, closed or all; defaults to open
+	Base  string // Only list pull requests targeting this base branch; empty for all
+	ListOptions
+}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+// ListChanges lists pull requests for the given repository, highest PR number first
+func (c *Client) ListChanges(ctx context.Context, owner, repo string, opts PRListOptions) ([]*github.PullRequest, error) {
+	state := opts.State
+	if state == "" {
+		state = "open"
+	}
 
-		opts := &github.PullRequestListOptions{
-			State:       "open",
-			ListOptions: github.ListOptions{PerPage: 100},
-		}
+	prs, err := paginate(ctx, c.listOptions(opts.ListOptions), func(listOpts github.ListOptions) ([]*github.PullRequest, *github.Response, error) {
+		return c.gh.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{
+			State:       state,
+			Base:        opts.Base,
+			ListOptions: listOpts,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		prs, _, err := c.gh.PullRequests.List(ctx, repo.GetOwner().GetLogin(), repo.GetName(), opts)
-		if err != nil {
-			return PRsMsg{Err: err}
-		}
+	// Sort PRs by number in descending order (highest PR number first)
+	sort.Slice(prs, func(i, j int) bool {
+		return prs[i].GetNumber() > prs[j].GetNumber()
+	})
 
-		// Sort PRs by number in descending order (highest PR number first)
-		sort.Slice(prs, func(i, j int) bool {
-			return prs[i].GetNumber() > prs[j].GetNumber()
-		})
+	return prs, nil
+}
+
+// ListComments lists review comments for the given pull request, most recently updated first
+func (c *Client) ListComments(ctx context.Context, owner, repo string, number int, opts ListOptions) ([]*github.PullRequestComment, error) {
+	return c.listComments(ctx, owner, repo, number, time.Time{}, opts)
+}
+
+// ListCommentsSince lists the review comments of a pull request created or updated since the given time,
+// most recently updated first. Deleted comments are not reported.
+func (c *Client) ListCommentsSince(ctx context.Context, owner, repo string, number int, since time.Time, opts ListOptions) ([]*github.PullRequestComment, error) {
+	return c.listComments(ctx, owner, repo, number, since, opts)
+}
 
-		return PRsMsg{PRs: prs}
+// listComments lists the review comments of a pull request updated since the given time, or all of them
+// for the zero time, most recently updated first
+func (c *Client) listComments(ctx context.Context, owner, repo string, number int, since time.Time, opts ListOptions) ([]*github.PullRequestComment, error) {
+	comments, err := paginate(ctx, c.listOptions(opts), func(listOpts github.ListOptions) ([]*github.PullRequestComment, *github.Response, error) {
+		return c.gh.PullRequests.ListComments(ctx, owner, repo, number, &github.PullRequestListCommentsOptions{
+			Since:       since,
+			ListOptions: listOpts,
+		})
+	})
+	if err != nil {
+		return nil, err
 	}
+	slog.DebugContext(ctx, "listed review comments", "repo", owner+"/"+repo, "pr", number, "count", len(comments), "since", since)
+
+	// Filter for unresolved comments
+	unresolvedComments := slices.Clone(comments)
+	SortComments(unresolvedComments)
+
+	return unresolvedComments, nil
 }
 
-// FetchComments fetches comments for the given pull request
-func (c *Client) FetchComments(repo *github.Repository, pr *github.PullRequest) tea.Cmd {
-	return func() tea.Msg {
-		if repo == nil || pr == nil {
-			return CommentsMsg{Err: fmt.Errorf("no repository or PR provided")}
+// SortComments sorts review comments by UpdatedAt timestamp in descending order (most recently updated
+// first), comments without one last
+func SortComments(comments []*github.PullRequestComment) {
+	sort.Slice(comments, func(i, j int) bool {
+		if comments[i].UpdatedAt == nil && comments[j].UpdatedAt == nil {
+			return false
+		}
+		if comments[i].UpdatedAt == nil {
+			return false
+		}
+		if comments[j].UpdatedAt == nil {
+			return true
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+		// Sort by most recent first (descending order)
+		return comments[i].UpdatedAt.Time.After(comments[j].UpdatedAt.Time)
+	})
+}
 
-		opts := &github.PullRequestListCommentsOptions{
-			ListOptions: github.ListOptions{PerPage: 100},
-		}
+// MergeComments merges updated review comments into a list of comments: updated versions replace the
+// comments with the same ID and new comments are added. The result is sorted like ListComments; neither
+// list is modified.
+func MergeComments(comments, updates []*github.PullRequestComment) []*github.PullRequestComment {
+	updated := make(map[int64]*github.PullRequestComment, len(updates))
+	for _, comment := range updates {
+		updated[comment.GetID()] = comment
+	}
 
-		comments, _, err := c.gh.PullRequests.ListComments(ctx,
-			repo.GetOwner().GetLogin(),
-			repo.GetName(),
-			pr.GetNumber(),
-			opts)
-		if err != nil {
-			return CommentsMsg{Err: err}
+	merged := make([]*github.PullRequestComment, 0, len(comments)+len(updates))
+	for _, comment := range comments {
+		if _, ok := updated[comment.GetID()]; !ok {
+			merged = append(merged, comment)
 		}
+	}
+	merged = append(merged, updates...)
+	SortComments(merged)
+	return merged
+}
 
-		// Filter for unresolved comments
-		unresolvedComments := slices.Clone(comments)
+// CurrentUser fetches the user the client is authenticated as
+func (c *Client) CurrentUser(ctx context.Context) (*github.User, error) {
+	user, _, err := c.gh.Users.Get(ctx, "")
+	return user, err
+}
 
-		// Sort comments by UpdatedAt timestamp in descending order (most recently updated first)
-		sort.Slice(unresolvedComments, func(i, j int) bool {
-			if unresolvedComments[i].UpdatedAt == nil && unresolvedComments[j].UpdatedAt == nil {
-				return false
-			}
-			if unresolvedComments[i].UpdatedAt == nil {
-				return false
-			}
-			if unresolvedComments[j].UpdatedAt == nil {
-				return true
-			}
+// GetRepo fetches a single repository
+func (c *Client) GetRepo(ctx context.Context, owner, repo string) (*github.Repository, error) {
+	repository, _, err := c.gh.Repositories.Get(ctx, owner, repo)
+	return repository, err
+}
 
-			// Sort by most recent first (descending order)
-			return unresolvedComments[i].UpdatedAt.Time.After(unresolvedComments[j].UpdatedAt.Time)
-		})
+// GetChange fetches a single pull request
+func (c *Client) GetChange(ctx context.Context, owner, repo string, number int) (*github.PullRequest, error) {
+	pr, _, err := c.gh.PullRequests.Get(ctx, owner, repo, number)
+	return pr, err
+}
+
+// GetComment fetches a single review comment
+func (c *Client) GetComment(ctx context.Context, owner, repo string, id int64) (*github.PullRequestComment, error) {
+	comment, _, err := c.gh.PullRequests.GetComment(ctx, owner, repo, id)
+	return comment, err
+}
+
+// Reply posts a reply in the thread of the given review comment.
+// Replies to replies are posted to the thread's top-level comment, as GitHub requires.
+func (c *Client) Reply(ctx context.Context, owner, repo string, number int, commentID int64, body string) (*github.PullRequestComment, error) {
+	comment, err := c.GetComment(ctx, owner, repo, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.GetInReplyTo() != 0 {
+		commentID = comment.GetInReplyTo()
+	}
+
+	reply, _, err := c.gh.PullRequests.CreateCommentInReplyTo(ctx, owner, repo, number, body, commentID)
+	return reply, err
+}
+
+// GetFile fetches the content of a file in the repository at ref, or at the default branch when ref is
+// empty. It returns nil content without an error when the file does not exist.
+func (c *Client) GetFile(ctx context.Context, owner, repo, path, ref string) ([]byte, error) {
+	file, _, resp, err := c.gh.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return nil, fmt.Errorf("%s is a directory", path)
+	}
 
-		return CommentsMsg{Comments: unresolvedComments}
+	content, err := file.GetContent()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
 	}
+	return []byte(content), nil
+}
+
+// ListFiles lists the files changed by the given pull request
+func (c *Client) ListFiles(ctx context.Context, owner, repo string, number int, opts ListOptions) ([]*github.CommitFile, error) {
+	return paginate(ctx, c.listOptions(opts), func(listOpts github.ListOptions) ([]*github.CommitFile, *github.Response, error) {
+		return c.gh.PullRequests.ListFiles(ctx, owner, repo, number, &listOpts)
+	})
+}
+
+// ListCommits lists the commits of the given pull request, oldest first
+func (c *Client) ListCommits(ctx context.Context, owner, repo string, number int, opts ListOptions) ([]*github.RepositoryCommit, error) {
+	return paginate(ctx, c.listOptions(opts), func(listOpts github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+		return c.gh.PullRequests.ListCommits(ctx, owner, repo, number, &listOpts)
+	})
+}
+
+// ListWatchedRepos lists the repositories the user is watching
+func (c *Client) ListWatchedRepos(ctx context.Context, opts ListOptions) ([]*github.Repository, error) {
+	return paginate(ctx, c.listOptions(opts), func(listOpts github.ListOptions) ([]*github.Repository, *github.Response, error) {
+		return c.gh.Activity.ListWatched(ctx, "", &listOpts)
+	})
+}
+
+// ListRepoCommentsSince lists the review comments across all pull requests of a repository
+// created or updated since the given time, oldest first
+func (c *Client) ListRepoCommentsSince(ctx context.Context, owner, repo string, since time.Time, opts ListOptions) ([]*github.PullRequestComment, error) {
+	return paginate(ctx, c.listOptions(opts), func(listOpts github.ListOptions) ([]*github.PullRequestComment, *github.Response, error) {
+		// A PR number of 0 lists review comments for the whole repository
+		return c.gh.PullRequests.ListComments(ctx, owner, repo, 0, &github.PullRequestListCommentsOptions{
+			Sort:        "created",
+			Direction:   "asc",
+			Since:       since,
+			ListOptions: listOpts,
+		})
+	})
 }
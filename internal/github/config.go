@@ -0,0 +1,39 @@
+package github
+
+import (
+	"github.com/stefrushxyz/nitpick/internal/config"
+	"github.com/stefrushxyz/nitpick/internal/retry"
+)
+
+// OptionsFromConfig returns the client options for the given configuration
+func OptionsFromConfig(cfg *config.Config) Options {
+	return Options{
+		Token:    cfg.Token,
+		Host:     cfg.Host,
+		BaseURL:  cfg.BaseURL,
+		PageSize: cfg.PageSize,
+		Timeout:  cfg.Timeout,
+		Retry:    RetryPolicy(cfg),
+		Transport: TransportOptions{
+			Proxy:              cfg.HTTP.Proxy,
+			CACert:             cfg.HTTP.CACert,
+			InsecureSkipVerify: cfg.HTTP.InsecureSkipVerify,
+		},
+		Fixtures: cfg.Mock,
+	}
+}
+
+// RetryPolicy returns the policy retrying the API requests failing transiently for the given configuration
+func RetryPolicy(cfg *config.Config) retry.Policy {
+	return retry.Policy{Attempts: cfg.Retry.Attempts, Backoff: cfg.Retry.Backoff}
+}
+
+// ListOptionsFromLimits converts configured fetch limits to list options
+func ListOptionsFromLimits(limits config.FetchLimits) ListOptions {
+	return ListOptions{
+		PerPage:  limits.PerPage,
+		Limit:    limits.MaxItems,
+		AllPages: limits.MaxPages != 1,
+		MaxPages: limits.MaxPages,
+	}
+}
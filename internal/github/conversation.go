@@ -0,0 +1,62 @@
+package github
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// SubjectConversation is the subject type of the conversation comments of a pull request, posted on the
+// pull request as a whole rather than on its code
+const SubjectConversation = "conversation"
+
+// ListConversation lists the conversation comments of a pull request updated since the given time, or all
+// of them for the zero time, as review comments on no file, most recently updated first. They belong to the
+// pull request's issue, so their IDs do not identify review comments.
+func (c *Client) ListConversation(ctx context.Context, owner, repo string, number int, since time.Time, opts ListOptions) ([]*github.PullRequestComment, error) {
+	var listSince *time.Time
+	if !since.IsZero() {
+		listSince = &since
+	}
+	issueComments, err := paginate(ctx, c.listOptions(opts), func(listOpts github.ListOptions) ([]*github.IssueComment, *github.Response, error) {
+		return c.gh.Issues.ListComments(ctx, owner, repo, number, &github.IssueListCommentsOptions{
+			Since:       listSince,
+			ListOptions: listOpts,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.DebugContext(ctx, "listed conversation comments", "repo", owner+"/"+repo, "pr", number, "count", len(issueComments), "since", since)
+
+	comments := make([]*github.PullRequestComment, 0, len(issueComments))
+	for _, comment := range issueComments {
+		comments = append(comments, conversationComment(comment))
+	}
+	SortComments(comments)
+	return comments, nil
+}
+
+// conversationComment converts a conversation comment into a review comment on no file
+func conversationComment(comment *github.IssueComment) *github.PullRequestComment {
+	return &github.PullRequestComment{
+		ID:                comment.ID,
+		NodeID:            comment.NodeID,
+		Body:              comment.Body,
+		User:              comment.User,
+		Reactions:         comment.Reactions,
+		CreatedAt:         comment.CreatedAt,
+		UpdatedAt:         comment.UpdatedAt,
+		AuthorAssociation: comment.AuthorAssociation,
+		URL:               comment.URL,
+		HTMLURL:           comment.HTMLURL,
+		SubjectType:       github.String(SubjectConversation),
+	}
+}
+
+// IsConversation reports whether a comment is a conversation comment rather than a review comment
+func IsConversation(comment *github.PullRequestComment) bool {
+	return comment.GetSubjectType() == SubjectConversation
+}
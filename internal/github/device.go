@@ -0,0 +1,105 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DeviceCode is the code a user enters on GitHub to authorize a device flow login
+type DeviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"` // Seconds until the code expires
+	Interval        int    `json:"interval"`   // Minimum seconds between polls
+}
+
+// deviceScopes are the OAuth scopes requested by the device flow
+var deviceScopes = []string{"repo", "read:org"}
+
+// RequestDeviceCode starts an OAuth device flow login on host for the OAuth app with the given client ID
+func RequestDeviceCode(ctx context.Context, host, clientID string) (*DeviceCode, error) {
+	var code DeviceCode
+	err := postOAuthForm(ctx, host, "/login/device/code", url.Values{
+		"client_id": {clientID},
+		"scope":     {strings.Join(deviceScopes, " ")},
+	}, &code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request device code: %w", err)
+	}
+	return &code, nil
+}
+
+// PollDeviceToken polls until the user authorizes the device code and returns the access token
+func PollDeviceToken(ctx context.Context, host, clientID string, code *DeviceCode) (string, error) {
+	interval := time.Duration(code.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(code.ExpiresIn)*time.Second)
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return "", errors.New("device code expired before it was authorized")
+		case <-time.After(interval):
+		}
+
+		var resp struct {
+			AccessToken string `json:"access_token"`
+			Error       string `json:"error"`
+			Description string `json:"error_description"`
+		}
+		err := postOAuthForm(ctx, host, "/login/oauth/access_token", url.Values{
+			"client_id":   {clientID},
+			"device_code": {code.DeviceCode},
+			"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+		}, &resp)
+		if err != nil {
+			return "", fmt.Errorf("failed to poll for access token: %w", err)
+		}
+
+		switch resp.Error {
+		case "":
+			return resp.AccessToken, nil
+		case "authorization_pending":
+			// The user has not entered the code yet
+		case "slow_down":
+			interval += 5 * time.Second
+		default:
+			return "", fmt.Errorf("device flow login failed: %s", resp.Description)
+		}
+	}
+}
+
+// postOAuthForm posts form to an OAuth endpoint of host and decodes the JSON response into out
+func postOAuthForm(ctx context.Context, host, path string, form url.Values, out any) error {
+	if host == "" {
+		host = "github.com"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+host+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
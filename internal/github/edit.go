@@ -0,0 +1,51 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// CreateComment starts a new thread with a review comment on a line of a file changed by a pull request. The
+// draft sets the comment's body, commit, path, line and side, and optionally its start line and start side.
+func (c *Client) CreateComment(ctx context.Context, owner, repo string, number int, draft *github.PullRequestComment) (*github.PullRequestComment, error) {
+	comment, _, err := c.gh.PullRequests.CreateComment(ctx, owner, repo, number, draft)
+	return comment, err
+}
+
+// EditComment replaces the body of a review comment, a conversation comment or a review body of a pull
+// request, returning the edited comment
+func (c *Client) EditComment(ctx context.Context, owner, repo string, number int, comment *github.PullRequestComment, body string) (*github.PullRequestComment, error) {
+	switch {
+	case ReviewState(comment) != "":
+		review, _, err := c.gh.PullRequests.UpdateReview(ctx, owner, repo, number, comment.GetID(), body)
+		if err != nil {
+			return nil, err
+		}
+		return reviewComment(review), nil
+	case IsConversation(comment):
+		edited, _, err := c.gh.Issues.EditComment(ctx, owner, repo, comment.GetID(), &github.IssueComment{Body: &body})
+		if err != nil {
+			return nil, err
+		}
+		return conversationComment(edited), nil
+	}
+
+	edited, _, err := c.gh.PullRequests.EditComment(ctx, owner, repo, comment.GetID(), &github.PullRequestComment{Body: &body})
+	return edited, err
+}
+
+// DeleteComment deletes a review comment or a conversation comment. Submitted reviews cannot be deleted.
+func (c *Client) DeleteComment(ctx context.Context, owner, repo string, comment *github.PullRequestComment) error {
+	switch {
+	case ReviewState(comment) != "":
+		return fmt.Errorf("deleting submitted reviews: %w", ErrUnsupported)
+	case IsConversation(comment):
+		_, err := c.gh.Issues.DeleteComment(ctx, owner, repo, comment.GetID())
+		return err
+	}
+
+	_, err := c.gh.PullRequests.DeleteComment(ctx, owner, repo, comment.GetID())
+	return err
+}
@@ -0,0 +1,90 @@
+package github
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+)
+
+// etagCacheSize bounds the responses kept for revalidation; the cache starts over once it is full
+const etagCacheSize = 1000
+
+// etagResponse is a response kept for revalidation with its entity tag
+type etagResponse struct {
+	etag   string
+	header http.Header
+	body   []byte
+}
+
+// etagTransport makes GET requests conditional on the entity tag of their last response: the API answers
+// 304 Not Modified for unchanged resources, which does not count against the rate limit, and the kept
+// response is served instead. Responses are kept in memory, for the session.
+type etagTransport struct {
+	base http.RoundTripper
+
+	mu        sync.Mutex
+	responses map[string]etagResponse // By URL
+}
+
+// newETagTransport wraps base with conditional requests
+func newETagTransport(base http.RoundTripper) *etagTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &etagTransport{base: base, responses: make(map[string]etagResponse)}
+}
+
+// RoundTrip performs the request, conditionally when a response to it is kept
+func (t *etagTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("If-None-Match") != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	t.mu.Lock()
+	kept, ok := t.responses[key]
+	t.mu.Unlock()
+	if ok {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", kept.etag)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case ok && resp.StatusCode == http.StatusNotModified:
+		slog.DebugContext(req.Context(), "api response not modified", "url", key)
+		resp.Body.Close()
+		return &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         resp.Proto,
+			ProtoMajor:    resp.ProtoMajor,
+			ProtoMinor:    resp.ProtoMinor,
+			Header:        kept.header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(kept.body)),
+			ContentLength: int64(len(kept.body)),
+			Request:       req,
+		}, nil
+	case resp.StatusCode == http.StatusOK && resp.Header.Get("ETag") != "":
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+
+		t.mu.Lock()
+		if len(t.responses) >= etagCacheSize {
+			clear(t.responses)
+		}
+		t.responses[key] = etagResponse{etag: resp.Header.Get("ETag"), header: resp.Header.Clone(), body: body}
+		t.mu.Unlock()
+	}
+	return resp, nil
+}
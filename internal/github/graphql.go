@@ -0,0 +1,130 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/stefrushxyz/nitpick/internal/blame"
+)
+
+// graphQLRequest is the body of a GraphQL API request
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+// graphQLResponse is the envelope of a GraphQL API response
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// graphQL runs a GraphQL query or mutation and decodes its data into out
+func (c *Client) graphQL(ctx context.Context, query string, variables map[string]any, out any) error {
+	// The GraphQL endpoint is /graphql on GitHub.com and /api/graphql on GitHub Enterprise Server,
+	// a sibling of the REST base URL in both cases
+	req, err := c.gh.NewRequest("POST", "../graphql", graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return err
+	}
+
+	var resp graphQLResponse
+	if _, err := c.gh.Do(ctx, req, &resp); err != nil {
+		return err
+	}
+
+	if len(resp.Errors) > 0 {
+		messages := make([]string, len(resp.Errors))
+		for i, e := range resp.Errors {
+			messages[i] = e.Message
+		}
+		return fmt.Errorf("graphql: %s", strings.Join(messages, "; "))
+	}
+
+	if out == nil {
+		return nil
+	}
+	if len(resp.Data) == 0 {
+		return errors.New("graphql: empty response")
+	}
+
+	return json.Unmarshal(resp.Data, out)
+}
+
+// blameQuery fetches the blame of a file at a commit
+const blameQuery = `query($owner: String!, $repo: String!, $ref: String!, $path: String!) {
+  repository(owner: $owner, name: $repo) {
+    object(expression: $ref) {
+      ... on Commit {
+        blame(path: $path) {
+          ranges {
+            startingLine
+            endingLine
+            commit {
+              oid
+              messageHeadline
+              authoredDate
+              author { name user { login } }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// Blame returns the commits that last changed each line of the file at path as of ref
+func (c *Client) Blame(ctx context.Context, owner, repo, ref, path string) ([]blame.Range, error) {
+	var data struct {
+		Repository struct {
+			Object *struct {
+				Blame struct {
+					Ranges []struct {
+						StartingLine int `json:"startingLine"`
+						EndingLine   int `json:"endingLine"`
+						Commit       struct {
+							OID             string    `json:"oid"`
+							MessageHeadline string    `json:"messageHeadline"`
+							AuthoredDate    time.Time `json:"authoredDate"`
+							Author          struct {
+								Name string `json:"name"`
+								User *struct {
+									Login string `json:"login"`
+								} `json:"user"`
+							} `json:"author"`
+						} `json:"commit"`
+					} `json:"ranges"`
+				} `json:"blame"`
+			} `json:"object"`
+		} `json:"repository"`
+	}
+	variables := map[string]any{"owner": owner, "repo": repo, "ref": ref, "path": path}
+	if err := c.graphQL(ctx, blameQuery, variables, &data); err != nil {
+		return nil, err
+	}
+	if data.Repository.Object == nil {
+		return nil, fmt.Errorf("commit %s not found in %s/%s", ref, owner, repo)
+	}
+
+	ranges := make([]blame.Range, len(data.Repository.Object.Blame.Ranges))
+	for i, r := range data.Repository.Object.Blame.Ranges {
+		ranges[i] = blame.Range{
+			Start:   r.StartingLine,
+			End:     r.EndingLine,
+			Commit:  r.Commit.OID,
+			Author:  r.Commit.Author.Name,
+			Date:    r.Commit.AuthoredDate,
+			Summary: r.Commit.MessageHeadline,
+		}
+		if user := r.Commit.Author.User; user != nil {
+			ranges[i].Login = user.Login
+		}
+	}
+	return ranges, nil
+}
@@ -0,0 +1,55 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// closingPattern matches the keywords GitHub links a pull request to the issues it closes by, followed by an
+// issue reference: #N, or owner/name#N for an issue of another repository
+var closingPattern = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+(?:([\w.-]+)/([\w.-]+))?#([1-9][0-9]*)\b`)
+
+// IssueRef refers to an issue of a repository
+type IssueRef struct {
+	Owner  string
+	Repo   string
+	Number int
+}
+
+// ClosingRefs returns the distinct issues a pull request description closes with keywords such as
+// "Fixes #12" or "Closes acme/api#7", in order of appearance. References without a repository are to the
+// pull request's own repository, owner/name.
+func ClosingRefs(body, owner, name string) []IssueRef {
+	var refs []IssueRef
+	for _, match := range closingPattern.FindAllStringSubmatch(body, -1) {
+		ref := IssueRef{Owner: owner, Repo: name}
+		if match[1] != "" {
+			ref.Owner, ref.Repo = match[1], match[2]
+		}
+		ref.Number, _ = strconv.Atoi(match[3])
+		if !slices.Contains(refs, ref) {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// IssueLabel refers to an issue as #N, or as owner/name#N when it is not of the repository repo (as
+// owner/name)
+func IssueLabel(issue *github.Issue, repo string) string {
+	if r := issueRepo(issue); r != nil && r.GetFullName() != repo {
+		return fmt.Sprintf("%s#%d", r.GetFullName(), issue.GetNumber())
+	}
+	return fmt.Sprintf("#%d", issue.GetNumber())
+}
+
+// GetIssue fetches an issue of a repository
+func (c *Client) GetIssue(ctx context.Context, owner, repo string, number int) (*github.Issue, error) {
+	issue, _, err := c.gh.Issues.Get(ctx, owner, repo, number)
+	return issue, err
+}
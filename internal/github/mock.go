@@ -0,0 +1,122 @@
+package github
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+)
+
+// DemoFixtures names the fixtures built into nitpick, used in place of a fixtures directory
+const DemoFixtures = "demo"
+
+//go:embed fixtures/demo
+var demoFixtures embed.FS
+
+// mockTransport answers API requests from JSON fixtures instead of the network, for demos, screenshots and
+// end-to-end tests without a token. A GET of /repos/acme/api/pulls is answered with
+// repos/acme/api/pulls.json, whatever its query; GraphQL queries are answered from the graphql directory.
+// Writes are refused, as a read-only API would.
+type mockTransport struct {
+	fsys fs.FS
+}
+
+// newMockTransport returns the transport serving the fixtures of a directory, or the built-in ones for
+// DemoFixtures
+func newMockTransport(fixtures string) (http.RoundTripper, error) {
+	if fixtures == DemoFixtures {
+		fsys, err := fs.Sub(demoFixtures, "fixtures/demo")
+		if err != nil {
+			return nil, err
+		}
+		return mockTransport{fsys: fsys}, nil
+	}
+	info, err := os.Stat(fixtures)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open mock fixtures: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("mock fixtures %s are not a directory", fixtures)
+	}
+	return mockTransport{fsys: os.DirFS(fixtures)}, nil
+}
+
+// RoundTrip answers a request with its fixture
+func (t mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		defer req.Body.Close()
+	}
+
+	name, ok := "", false
+	switch {
+	case req.Method == http.MethodGet:
+		name, ok = restFixture(req.URL.Path), true
+	case req.Method == http.MethodPost && path.Base(req.URL.Path) == "graphql":
+		name, ok = graphQLFixture(req)
+	}
+	if !ok {
+		slog.Debug("mock refused write", "method", req.Method, "path", req.URL.Path)
+		return mockResponse(req, http.StatusForbidden, []byte(`{"message":"Read-only in mock mode"}`)), nil
+	}
+
+	content, err := fs.ReadFile(t.fsys, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		slog.Debug("mock fixture missing", "fixture", name)
+		return mockResponse(req, http.StatusNotFound, []byte(`{"message":"Not Found"}`)), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mockResponse(req, http.StatusOK, content), nil
+}
+
+// restFixture returns the fixture answering a GET of a REST API path
+func restFixture(urlPath string) string {
+	// GitHub Enterprise Server serves the API under /api/v3
+	urlPath = strings.TrimPrefix(urlPath, "/api/v3")
+	return strings.Trim(urlPath, "/") + ".json"
+}
+
+// graphQLFixture returns the fixture answering a GraphQL query: graphql/threads/<owner>/<repo>/<number>.json
+// for the review threads of a pull request and graphql/blame/<owner>/<repo>/<path>.json for the blame of a
+// file. It reports false for mutations.
+func graphQLFixture(req *http.Request) (string, bool) {
+	var body graphQLRequest
+	if req.Body == nil || json.NewDecoder(req.Body).Decode(&body) != nil {
+		return "", false
+	}
+	query, vars := strings.TrimSpace(body.Query), body.Variables
+	switch {
+	case strings.HasPrefix(query, "mutation"):
+		return "", false
+	case strings.Contains(query, "reviewThreads"):
+		return fmt.Sprintf("graphql/threads/%v/%v/%v.json", vars["owner"], vars["repo"], vars["number"]), true
+	case strings.Contains(query, "blame("):
+		return fmt.Sprintf("graphql/blame/%v/%v/%v.json", vars["owner"], vars["repo"], vars["path"]), true
+	default:
+		return "graphql/unknown.json", true
+	}
+}
+
+// mockResponse returns a JSON response to a request
+func mockResponse(req *http.Request, status int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json; charset=utf-8"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
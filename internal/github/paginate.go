@@ -0,0 +1,72 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// defaultPerPage is the page size used when ListOptions.PerPage is unset
+const defaultPerPage = 100
+
+// ListOptions controls how many results list calls fetch
+type ListOptions struct {
+	PerPage  int  // Results per page; defaults to the client's page size
+	Limit    int  // Maximum number of results; 0 means no limit
+	AllPages bool // Follow pagination past the first page
+	MaxPages int  // Maximum number of pages fetched when following pagination; 0 means no limit
+}
+
+// perPage returns the page size to request
+func (o ListOptions) perPage() int {
+	perPage := o.PerPage
+	if perPage <= 0 || perPage > defaultPerPage {
+		perPage = defaultPerPage
+	}
+	if o.Limit > 0 && o.Limit < perPage {
+		perPage = o.Limit
+	}
+	return perPage
+}
+
+// paginate calls fetch for successive pages until the options are satisfied, reporting each page to the
+// page hook of ctx
+func paginate[T any](ctx context.Context, opts ListOptions, fetch func(github.ListOptions) ([]T, *github.Response, error)) ([]T, error) {
+	listOpts := github.ListOptions{PerPage: opts.perPage()}
+
+	var all []T
+	for pages := 1; ; pages++ {
+		page, resp, err := fetch(listOpts)
+		if err != nil {
+			return nil, err
+		}
+		ReportPage(ctx, page)
+		all = append(all, page...)
+
+		if opts.Limit > 0 && len(all) >= opts.Limit {
+			return all[:opts.Limit], nil
+		}
+		if !opts.AllPages || resp == nil || resp.NextPage == 0 || pages == opts.MaxPages {
+			return all, nil
+		}
+		listOpts.Page = resp.NextPage
+	}
+}
+
+// pageHookKey is the context key of the page hook for results of type T
+type pageHookKey[T any] struct{}
+
+// WithPageHook returns a context under which paginated list calls pass each page of results of type T to
+// hook as it arrives, before returning the complete list, so callers can show results progressively. Pages
+// are raw: the complete list may still be sorted, deduplicated or cut to a limit. Calls whose pages hold
+// other types, e.g. a provider's own types converted once the list is complete, do not report them.
+func WithPageHook[T any](ctx context.Context, hook func(page []T)) context.Context {
+	return context.WithValue(ctx, pageHookKey[T]{}, hook)
+}
+
+// ReportPage passes a page of results to the page hook of ctx for their type, if any
+func ReportPage[T any](ctx context.Context, page []T) {
+	if hook, ok := ctx.Value(pageHookKey[T]{}).(func([]T)); ok && len(page) > 0 {
+		hook(page)
+	}
+}
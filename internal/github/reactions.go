@@ -0,0 +1,71 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// Reactions lists the contents of the reactions the Reactions API accepts, in the order GitHub shows them
+var Reactions = []string{"+1", "-1", "laugh", "hooray", "confused", "heart", "rocket", "eyes"}
+
+// React adds a reaction, one of Reactions, to a review comment or a conversation comment. It reports whether
+// the reaction is new: the API returns the user's existing reaction when they reacted with it before.
+// Review bodies cannot be reacted to through the API.
+func (c *Client) React(ctx context.Context, owner, repo string, comment *github.PullRequestComment, content string) (bool, error) {
+	if ReviewState(comment) != "" {
+		return false, fmt.Errorf("reacting to review summaries: %w", ErrUnsupported)
+	}
+
+	var resp *github.Response
+	var err error
+	if IsConversation(comment) {
+		_, resp, err = c.gh.Reactions.CreateIssueCommentReaction(ctx, owner, repo, comment.GetID(), content)
+	} else {
+		_, resp, err = c.gh.Reactions.CreatePullRequestCommentReaction(ctx, owner, repo, comment.GetID(), content)
+	}
+	if err != nil {
+		return false, err
+	}
+	slog.DebugContext(ctx, "reacted to comment", "repo", owner+"/"+repo, "comment", comment.GetID(), "content", content)
+	return resp.StatusCode == http.StatusCreated, nil
+}
+
+// AddReaction counts a new reaction in the reaction counts of a comment
+func AddReaction(comment *github.PullRequestComment, content string) {
+	if comment.Reactions == nil {
+		comment.Reactions = &github.Reactions{}
+	}
+	r := comment.Reactions
+
+	var count **int
+	switch content {
+	case "+1":
+		count = &r.PlusOne
+	case "-1":
+		count = &r.MinusOne
+	case "laugh":
+		count = &r.Laugh
+	case "hooray":
+		count = &r.Hooray
+	case "confused":
+		count = &r.Confused
+	case "heart":
+		count = &r.Heart
+	case "rocket":
+		count = &r.Rocket
+	case "eyes":
+		count = &r.Eyes
+	default:
+		return
+	}
+	n := 1
+	if *count != nil {
+		n += **count
+	}
+	*count = github.Int(n)
+	r.TotalCount = github.Int(r.GetTotalCount() + 1)
+}
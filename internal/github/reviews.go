@@ -0,0 +1,94 @@
+package github
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// subjectReview prefixes the subject type of review bodies, followed by the state of their review
+const subjectReview = "review:"
+
+// Review states of a pull request review
+const (
+	ReviewApproved         = "APPROVED"
+	ReviewChangesRequested = "CHANGES_REQUESTED"
+	ReviewCommented        = "COMMENTED"
+	ReviewDismissed        = "DISMISSED"
+)
+
+// Review events, the verdicts a submitted review gives
+const (
+	EventApprove        = "APPROVE"
+	EventRequestChanges = "REQUEST_CHANGES"
+	EventComment        = "COMMENT"
+)
+
+// SubmitReview submits a review of a pull request with a verdict, one of the review events, and a summary
+// body, returning the review as a review body. Requesting changes and commenting take a body; approving does
+// not.
+func (c *Client) SubmitReview(ctx context.Context, owner, repo string, number int, event, body string) (*github.PullRequestComment, error) {
+	review, _, err := c.gh.PullRequests.CreateReview(ctx, owner, repo, number, &github.PullRequestReviewRequest{
+		Body:  github.String(body),
+		Event: github.String(event),
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.DebugContext(ctx, "submitted review", "repo", owner+"/"+repo, "pr", number, "event", event)
+	return reviewComment(review), nil
+}
+
+// ListReviewBodies lists the summary bodies of the reviews of a pull request submitted since the given time,
+// or of all of them for the zero time, as review comments on no file, most recently submitted first. Reviews
+// submitted without a body are left out. Their IDs are the IDs of the reviews, not of review comments.
+func (c *Client) ListReviewBodies(ctx context.Context, owner, repo string, number int, since time.Time, opts ListOptions) ([]*github.PullRequestComment, error) {
+	reviews, err := paginate(ctx, c.listOptions(opts), func(listOpts github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+		return c.gh.PullRequests.ListReviews(ctx, owner, repo, number, &listOpts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.DebugContext(ctx, "listed reviews", "repo", owner+"/"+repo, "pr", number, "count", len(reviews), "since", since)
+
+	var comments []*github.PullRequestComment
+	for _, review := range reviews {
+		// Pending reviews are not submitted yet, and only listed to their author
+		if strings.TrimSpace(review.GetBody()) == "" || review.SubmittedAt == nil || review.SubmittedAt.Before(since) {
+			continue
+		}
+		comments = append(comments, reviewComment(review))
+	}
+	SortComments(comments)
+	return comments, nil
+}
+
+// reviewComment converts the body of a review into a review comment on no file
+func reviewComment(review *github.PullRequestReview) *github.PullRequestComment {
+	return &github.PullRequestComment{
+		ID:                  review.ID,
+		NodeID:              review.NodeID,
+		Body:                review.Body,
+		User:                review.User,
+		CreatedAt:           review.SubmittedAt,
+		UpdatedAt:           review.SubmittedAt,
+		CommitID:            review.CommitID,
+		PullRequestReviewID: review.ID,
+		AuthorAssociation:   review.AuthorAssociation,
+		HTMLURL:             review.HTMLURL,
+		SubjectType:         github.String(subjectReview + review.GetState()),
+	}
+}
+
+// ReviewState returns the state of the review whose body a comment is, such as ReviewApproved, or "" when
+// the comment is not a review body
+func ReviewState(comment *github.PullRequestComment) string {
+	state, ok := strings.CutPrefix(comment.GetSubjectType(), subjectReview)
+	if !ok {
+		return ""
+	}
+	return state
+}
@@ -0,0 +1,100 @@
+package github
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// SearchPRs lists the pull requests across repositories matching a search query, such as
+// "is:open review-requested:@me", most recently updated first. Search results only carry the fields pull
+// requests share with issues; their repository, with only its owner and name, is set as the base repository.
+func (c *Client) SearchPRs(ctx context.Context, query string, opts ListOptions) ([]*github.PullRequest, error) {
+	if !strings.Contains(query, "is:pr") {
+		query = "is:pr " + query
+	}
+	issues, err := paginate(ctx, c.listOptions(opts), func(listOpts github.ListOptions) ([]*github.Issue, *github.Response, error) {
+		result, resp, err := c.gh.Search.Issues(ctx, query, &github.SearchOptions{
+			Sort:        "updated",
+			Order:       "desc",
+			ListOptions: listOpts,
+		})
+		if err != nil {
+			return nil, resp, err
+		}
+		return result.Issues, resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	prs := make([]*github.PullRequest, 0, len(issues))
+	for _, issue := range issues {
+		prs = append(prs, issuePR(issue))
+	}
+	return prs, nil
+}
+
+// SearchRepos lists the repositories matching a name fragment, such as "backend" or "acme/back", best
+// matches first: a fragment with a slash matches the names of the repositories of the owner before it
+func (c *Client) SearchRepos(ctx context.Context, fragment string, opts ListOptions) ([]*github.Repository, error) {
+	query := strings.TrimSpace(fragment) + " in:name fork:true"
+	if owner, name, ok := strings.Cut(strings.TrimSpace(fragment), "/"); ok {
+		query = strings.TrimSpace(name + " in:name fork:true user:" + owner)
+	}
+
+	return paginate(ctx, c.listOptions(opts), func(listOpts github.ListOptions) ([]*github.Repository, *github.Response, error) {
+		result, resp, err := c.gh.Search.Repositories(ctx, query, &github.SearchOptions{ListOptions: listOpts})
+		if err != nil {
+			return nil, resp, err
+		}
+		return result.Repositories, resp, nil
+	})
+}
+
+// issuePR converts a pull request found by the issue search into a pull request
+func issuePR(issue *github.Issue) *github.PullRequest {
+	return &github.PullRequest{
+		Number:    issue.Number,
+		Title:     issue.Title,
+		Body:      issue.Body,
+		State:     issue.State,
+		Draft:     issue.Draft,
+		User:      issue.User,
+		Labels:    issue.Labels,
+		Assignees: issue.Assignees,
+		Comments:  issue.Comments,
+		HTMLURL:   issue.HTMLURL,
+		CreatedAt: issue.CreatedAt,
+		UpdatedAt: issue.UpdatedAt,
+		ClosedAt:  issue.ClosedAt,
+		Milestone: issue.Milestone,
+		Base:      &github.PullRequestBranch{Repo: issueRepo(issue)},
+	}
+}
+
+// issueRepo returns the repository of an issue search result, from its API and web URLs
+func issueRepo(issue *github.Issue) *github.Repository {
+	u, err := url.Parse(issue.GetRepositoryURL())
+	if err != nil {
+		return nil
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) < 2 {
+		return nil
+	}
+	owner, name := segments[len(segments)-2], segments[len(segments)-1]
+
+	repo := &github.Repository{
+		Name:     github.String(name),
+		FullName: github.String(owner + "/" + name),
+		Owner:    &github.User{Login: github.String(owner)},
+	}
+	// https://github.com/owner/name/pull/42
+	if htmlURL := issue.GetHTMLURL(); strings.Contains(htmlURL, "/pull/") {
+		repo.HTMLURL = github.String(htmlURL[:strings.LastIndex(htmlURL, "/pull/")])
+	}
+	return repo
+}
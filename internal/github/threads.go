@@ -0,0 +1,145 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// ErrThreadNotFound is returned when no review thread contains the requested comment
+var ErrThreadNotFound = errors.New("review thread not found")
+
+// ErrUnsupported is returned by providers for operations their API does not offer
+var ErrUnsupported = errors.New("not supported by this provider")
+
+// ReviewThread identifies a review thread and its resolution state
+type ReviewThread struct {
+	ID         string
+	IsResolved bool
+	IsOutdated bool // Whether the commented lines changed since; only set by providers that track it
+	CommentIDs []int64
+}
+
+const reviewThreadsQuery = `query($owner: String!, $repo: String!, $number: Int!, $cursor: String) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $number) {
+      reviewThreads(first: 100, after: $cursor) {
+        nodes {
+          id
+          isResolved
+          isOutdated
+          comments(first: 100) {
+            nodes { databaseId }
+          }
+        }
+        pageInfo { hasNextPage endCursor }
+      }
+    }
+  }
+}`
+
+const resolveThreadMutation = `mutation($id: ID!) {
+  resolveReviewThread(input: {threadId: $id}) { thread { id isResolved } }
+}`
+
+const unresolveThreadMutation = `mutation($id: ID!) {
+  unresolveReviewThread(input: {threadId: $id}) { thread { id isResolved } }
+}`
+
+// ListThreads lists the review threads of a pull request
+func (c *Client) ListThreads(ctx context.Context, owner, repo string, number int) ([]ReviewThread, error) {
+	var threads []ReviewThread
+	var cursor *string
+
+	for {
+		var data struct {
+			Repository struct {
+				PullRequest struct {
+					ReviewThreads struct {
+						Nodes []struct {
+							ID         string `json:"id"`
+							IsResolved bool   `json:"isResolved"`
+							IsOutdated bool   `json:"isOutdated"`
+							Comments   struct {
+								Nodes []struct {
+									DatabaseID int64 `json:"databaseId"`
+								} `json:"nodes"`
+							} `json:"comments"`
+						} `json:"nodes"`
+						PageInfo struct {
+							HasNextPage bool   `json:"hasNextPage"`
+							EndCursor   string `json:"endCursor"`
+						} `json:"pageInfo"`
+					} `json:"reviewThreads"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		}
+
+		variables := map[string]any{"owner": owner, "repo": repo, "number": number, "cursor": cursor}
+		if err := c.graphQL(ctx, reviewThreadsQuery, variables, &data); err != nil {
+			return nil, err
+		}
+
+		page := data.Repository.PullRequest.ReviewThreads
+		for _, node := range page.Nodes {
+			thread := ReviewThread{ID: node.ID, IsResolved: node.IsResolved, IsOutdated: node.IsOutdated}
+			for _, comment := range node.Comments.Nodes {
+				thread.CommentIDs = append(thread.CommentIDs, comment.DatabaseID)
+			}
+			threads = append(threads, thread)
+		}
+
+		if !page.PageInfo.HasNextPage {
+			return threads, nil
+		}
+		cursor = &page.PageInfo.EndCursor
+	}
+}
+
+// Resolve marks a review thread as resolved, or as unresolved if resolved is false
+func (c *Client) Resolve(ctx context.Context, _, _ string, _ int, threadID string, resolved bool) error {
+	mutation := resolveThreadMutation
+	if !resolved {
+		mutation = unresolveThreadMutation
+	}
+	return c.graphQL(ctx, mutation, map[string]any{"id": threadID}, nil)
+}
+
+// CommentThread is a top-level review comment together with its replies
+type CommentThread struct {
+	Root    *github.PullRequestComment
+	Replies []*github.PullRequestComment
+}
+
+// GroupCommentThreads groups review comments into threads.
+// Threads keep the order of their root comments, and replies are ordered oldest first.
+func GroupCommentThreads(comments []*github.PullRequestComment) []CommentThread {
+	var threads []CommentThread
+	index := make(map[int64]int)
+
+	for _, comment := range comments {
+		if comment.GetInReplyTo() == 0 {
+			index[comment.GetID()] = len(threads)
+			threads = append(threads, CommentThread{Root: comment})
+		}
+	}
+
+	for _, comment := range comments {
+		if comment.GetInReplyTo() == 0 {
+			continue
+		}
+		if i, ok := index[comment.GetInReplyTo()]; ok {
+			threads[i].Replies = append(threads[i].Replies, comment)
+		}
+	}
+
+	for _, thread := range threads {
+		sort.SliceStable(thread.Replies, func(i, j int) bool {
+			return thread.Replies[i].GetCreatedAt().Before(thread.Replies[j].GetCreatedAt().Time)
+		})
+	}
+
+	return threads
+}
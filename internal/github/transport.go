@@ -0,0 +1,57 @@
+package github
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// TransportOptions holds the proxy and TLS settings of the connections to the API, for corporate networks
+type TransportOptions struct {
+	Proxy              string // Proxy URL; empty for the proxy of the HTTPS_PROXY and HTTP_PROXY environment variables
+	CACert             string // PEM file of CA certificates trusted besides the system's
+	InsecureSkipVerify bool   // Whether the server's TLS certificate is accepted without verification
+}
+
+// newBaseTransport returns the transport connecting to the API with the given settings, or nil for the
+// default transport when none is set
+func newBaseTransport(opts TransportOptions) (http.RoundTripper, error) {
+	if opts == (TransportOptions{}) {
+		return nil, nil
+	}
+	t := http.DefaultTransport.(*http.Transport).Clone()
+
+	if opts.Proxy != "" {
+		proxy, err := url.Parse(opts.Proxy)
+		if err != nil || proxy.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", opts.Proxy)
+		}
+		t.Proxy = http.ProxyURL(proxy)
+	}
+
+	if opts.CACert != "" || opts.InsecureSkipVerify {
+		t.TLSClientConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			// Only ever set explicitly, to get past a TLS-intercepting proxy whose CA is not at hand
+			InsecureSkipVerify: opts.InsecureSkipVerify,
+		}
+	}
+	if opts.CACert != "" {
+		pem, err := os.ReadFile(opts.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificates: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificates found in %s", opts.CACert)
+		}
+		t.TLSClientConfig.RootCAs = pool
+	}
+	return t, nil
+}
@@ -0,0 +1,338 @@
+package gitlab
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/rest"
+)
+
+// DefaultHost is the GitLab host used when none is configured
+const DefaultHost = "gitlab.com"
+
+// defaultPerPage is the page size used when Options.PageSize is unset; GitLab allows at most 100
+const defaultPerPage = 100
+
+// Client reads projects, merge requests and discussions from the GitLab REST API (v4), presenting them
+// as the GitHub repositories, pull requests and review comments the TUI and prompt generator work with
+type Client struct {
+	rest *rest.Client
+}
+
+// Options configures a Client. Token is a personal, group or project access token, Host defaults to
+// gitlab.com and BaseURL to https://<host>/api/v4.
+type Options = rest.Options
+
+// New creates a GitLab client
+func New(opts Options) (*Client, error) {
+	host := opts.Host
+	if host == "" {
+		host = DefaultHost
+	}
+	baseURL := opts.BaseURL
+	if baseURL == "" {
+		baseURL = fmt.Sprintf("https://%s/api/v4", host)
+	}
+
+	client, err := rest.New(rest.API{
+		Name:        "GitLab",
+		BaseURL:     baseURL,
+		PageSize:    defaultPerPage,
+		MaxPageSize: defaultPerPage,
+		Auth: func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer "+opts.Token)
+		},
+		Error: apiError,
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{rest: client}, nil
+}
+
+// Timeout returns the timeout for the requests of a single fetch
+func (c *Client) Timeout() time.Duration {
+	return c.rest.Timeout()
+}
+
+// get fetches an API path and decodes the JSON response into out, returning the next page number
+// reported by GitLab, or 0 on the last page
+func (c *Client) get(ctx context.Context, path string, query url.Values, out any) (int, error) {
+	return c.request(ctx, http.MethodGet, path, query, nil, out)
+}
+
+// request sends an API request with in, if not nil, as its JSON body and decodes the JSON response into
+// out, if not nil, returning the next page number reported by GitLab
+func (c *Client) request(ctx context.Context, method, path string, query url.Values, in, out any) (int, error) {
+	header, err := c.rest.Request(ctx, method, path, query, in, out)
+	if err != nil {
+		return 0, err
+	}
+	next, _ := strconv.Atoi(header.Get("X-Next-Page"))
+	return next, nil
+}
+
+// apiError describes an error response of the GitLab API, whose message is a string or, for
+// validation errors, an object
+func apiError(status int, body []byte) error {
+	var apiErr struct {
+		Message any    `json:"message"`
+		Error   string `json:"error"`
+	}
+	message := rest.Message(body)
+	if json.Unmarshal(body, &apiErr) == nil {
+		if apiErr.Message != nil {
+			message = fmt.Sprint(apiErr.Message)
+		} else if apiErr.Error != "" {
+			message = apiErr.Error
+		}
+	}
+	return &APIError{StatusCode: status, Message: message}
+}
+
+// APIError is an error response of the GitLab API
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+// Error describes the response
+func (e *APIError) Error() string {
+	return fmt.Sprintf("GitLab API error %d: %s", e.StatusCode, e.Message)
+}
+
+// getAll fetches the pages of a list within opts
+func getAll[T any](ctx context.Context, c *Client, path string, query url.Values, opts ghclient.ListOptions) ([]T, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("per_page", strconv.Itoa(c.rest.PageSize(opts)))
+
+	return rest.List(ctx, opts, func(cursor string) (rest.Page[T], error) {
+		query.Set("page", cmp.Or(cursor, "1"))
+		var results []T
+		next, err := c.get(ctx, path, query, &results)
+		if err != nil {
+			return rest.Page[T]{}, err
+		}
+		page := rest.Page[T]{Items: results}
+		if next != 0 {
+			page.Next = strconv.Itoa(next)
+		}
+		return page, nil
+	})
+}
+
+// projectPath returns the API path of a project given its namespace and name
+func projectPath(owner, name string) string {
+	return "/projects/" + url.PathEscape(owner+"/"+name)
+}
+
+// ListRepos lists the projects the user is a member of, most recently active first
+func (c *Client) ListRepos(ctx context.Context, opts ghclient.ListOptions) ([]*github.Repository, error) {
+	query := url.Values{
+		"membership": {"true"},
+		"order_by":   {"last_activity_at"},
+		"sort":       {"desc"},
+	}
+	projects, err := getAll[project](ctx, c, "/projects", query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	repos := make([]*github.Repository, len(projects))
+	for i, p := range projects {
+		repos[i] = p.toRepository()
+	}
+	return repos, nil
+}
+
+// GetRepo fetches a single project by namespace and name
+func (c *Client) GetRepo(ctx context.Context, owner, name string) (*github.Repository, error) {
+	var p project
+	if _, err := c.get(ctx, projectPath(owner, name), nil, &p); err != nil {
+		return nil, err
+	}
+	return p.toRepository(), nil
+}
+
+// ListChanges lists the merge requests of a project, highest number first
+func (c *Client) ListChanges(ctx context.Context, owner, name string, opts ghclient.PRListOptions) ([]*github.PullRequest, error) {
+	state := "opened"
+	switch opts.State {
+	case "closed":
+		state = "closed"
+	case "all":
+		state = "all"
+	}
+	query := url.Values{"state": {state}}
+	if opts.Base != "" {
+		query.Set("target_branch", opts.Base)
+	}
+
+	mrs, err := getAll[mergeRequest](ctx, c, projectPath(owner, name)+"/merge_requests", query, opts.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	prs := make([]*github.PullRequest, len(mrs))
+	for i, mr := range mrs {
+		prs[i] = mr.toPullRequest()
+	}
+	sort.Slice(prs, func(i, j int) bool {
+		return prs[i].GetNumber() > prs[j].GetNumber()
+	})
+	return prs, nil
+}
+
+// GetChange fetches a single merge request by its project-scoped number (IID)
+func (c *Client) GetChange(ctx context.Context, owner, name string, number int) (*github.PullRequest, error) {
+	var mr mergeRequest
+	path := fmt.Sprintf("%s/merge_requests/%d", projectPath(owner, name), number)
+	if _, err := c.get(ctx, path, nil, &mr); err != nil {
+		return nil, err
+	}
+	return mr.toPullRequest(), nil
+}
+
+// listDiscussions lists every discussion of a merge request
+func (c *Client) listDiscussions(ctx context.Context, owner, name string, number int) ([]discussion, error) {
+	path := fmt.Sprintf("%s/merge_requests/%d/discussions", projectPath(owner, name), number)
+	return getAll[discussion](ctx, c, path, nil, ghclient.ListOptions{AllPages: true})
+}
+
+// listDiffs returns the diff of each file changed by a merge request, keyed by new and old path
+func (c *Client) listDiffs(ctx context.Context, owner, name string, number int) (map[string]string, error) {
+	path := fmt.Sprintf("%s/merge_requests/%d/diffs", projectPath(owner, name), number)
+	diffs, err := getAll[fileDiff](ctx, c, path, nil, ghclient.ListOptions{AllPages: true})
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		// GitLab before 15.7 only serves the diffs as part of the merge request's changes
+		var changes struct {
+			Changes []fileDiff `json:"changes"`
+		}
+		path = fmt.Sprintf("%s/merge_requests/%d/changes", projectPath(owner, name), number)
+		if _, err = c.get(ctx, path, nil, &changes); err == nil {
+			diffs = changes.Changes
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	byPath := make(map[string]string, len(diffs))
+	for _, d := range diffs {
+		byPath[d.OldPath] = d.Diff
+		byPath[d.NewPath] = d.Diff
+	}
+	return byPath, nil
+}
+
+// ListComments lists the notes of a merge request's unresolved discussions as review comments, with the
+// diff context of notes on the diff, most recently updated first. Discussions are listed in full; opts only
+// limits how many notes are kept.
+func (c *Client) ListComments(ctx context.Context, owner, name string, number int, opts ghclient.ListOptions) ([]*github.PullRequestComment, error) {
+	mr, err := c.GetChange(ctx, owner, name, number)
+	if err != nil {
+		return nil, err
+	}
+	discussions, err := c.listDiscussions(ctx, owner, name, number)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs map[string]string
+	var comments []*github.PullRequestComment
+	for _, d := range discussions {
+		if len(d.Notes) == 0 || d.Notes[0].System || !d.Notes[0].Resolvable || d.Notes[0].Resolved {
+			continue
+		}
+		if diffs == nil && d.Notes[0].Position != nil {
+			// Diffs are only needed, and fetched once, when a note is on the diff
+			if diffs, err = c.listDiffs(ctx, owner, name, number); err != nil {
+				slog.WarnContext(ctx, "failed to fetch merge request diffs", "project", owner+"/"+name, "mr", number, "err", err)
+				diffs = map[string]string{}
+			}
+		}
+		comments = append(comments, d.toComments(mr, diffs)...)
+	}
+	slog.DebugContext(ctx, "listed merge request notes", "project", owner+"/"+name, "mr", number, "count", len(comments))
+
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].GetUpdatedAt().After(comments[j].GetUpdatedAt().Time)
+	})
+	if opts.Limit > 0 && len(comments) > opts.Limit {
+		comments = comments[:opts.Limit]
+	}
+	return comments, nil
+}
+
+// ListThreads lists the resolvable discussions of a merge request with their resolution state
+func (c *Client) ListThreads(ctx context.Context, owner, name string, number int) ([]ghclient.ReviewThread, error) {
+	discussions, err := c.listDiscussions(ctx, owner, name, number)
+	if err != nil {
+		return nil, err
+	}
+
+	var threads []ghclient.ReviewThread
+	for _, d := range discussions {
+		if len(d.Notes) == 0 || !d.Notes[0].Resolvable {
+			continue
+		}
+		thread := ghclient.ReviewThread{ID: d.ID, IsResolved: d.Notes[0].Resolved}
+		for _, n := range d.Notes {
+			thread.CommentIDs = append(thread.CommentIDs, n.ID)
+		}
+		threads = append(threads, thread)
+	}
+	return threads, nil
+}
+
+// Reply adds a note to the discussion of the given note
+func (c *Client) Reply(ctx context.Context, owner, name string, number int, commentID int64, body string) (*github.PullRequestComment, error) {
+	mr, err := c.GetChange(ctx, owner, name, number)
+	if err != nil {
+		return nil, err
+	}
+	discussions, err := c.listDiscussions(ctx, owner, name, number)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range discussions {
+		for _, n := range d.Notes {
+			if n.ID != commentID {
+				continue
+			}
+			var created note
+			path := fmt.Sprintf("%s/merge_requests/%d/discussions/%s/notes", projectPath(owner, name), number, url.PathEscape(d.ID))
+			if _, err := c.request(ctx, http.MethodPost, path, nil, map[string]string{"body": body}, &created); err != nil {
+				return nil, err
+			}
+			d.Notes = append(d.Notes, created)
+			reply := d.toComments(mr, nil)
+			return reply[len(reply)-1], nil
+		}
+	}
+	return nil, fmt.Errorf("%w for note %d", ghclient.ErrThreadNotFound, commentID)
+}
+
+// Resolve resolves or unresolves a discussion
+func (c *Client) Resolve(ctx context.Context, owner, name string, number int, threadID string, resolved bool) error {
+	path := fmt.Sprintf("%s/merge_requests/%d/discussions/%s", projectPath(owner, name), number, url.PathEscape(threadID))
+	query := url.Values{"resolved": {strconv.FormatBool(resolved)}}
+	_, err := c.request(ctx, http.MethodPut, path, query, nil, nil)
+	return err
+}
@@ -0,0 +1,216 @@
+package gitlab
+
+import (
+	"cmp"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/stefrushxyz/nitpick/internal/diff"
+)
+
+// project is a GitLab project
+type project struct {
+	ID                int64      `json:"id"`
+	Path              string     `json:"path"`
+	PathWithNamespace string     `json:"path_with_namespace"`
+	Description       string     `json:"description"`
+	WebURL            string     `json:"web_url"`
+	Visibility        string     `json:"visibility"`
+	DefaultBranch     string     `json:"default_branch"`
+	LastActivityAt    *time.Time `json:"last_activity_at"`
+	ForkedFrom        *struct{}  `json:"forked_from_project"`
+	Namespace         struct {
+		FullPath string `json:"full_path"`
+	} `json:"namespace"`
+}
+
+// toRepository presents the project as a repository owned by its (possibly nested) namespace
+func (p project) toRepository() *github.Repository {
+	owner := p.Namespace.FullPath
+	if owner == "" {
+		owner = path.Dir(p.PathWithNamespace)
+	}
+	return &github.Repository{
+		ID:            github.Int64(p.ID),
+		Name:          github.String(p.Path),
+		FullName:      github.String(p.PathWithNamespace),
+		Owner:         &github.User{Login: github.String(owner)},
+		Description:   github.String(p.Description),
+		HTMLURL:       github.String(p.WebURL),
+		Private:       github.Bool(p.Visibility != "public"),
+		Fork:          github.Bool(p.ForkedFrom != nil),
+		DefaultBranch: github.String(p.DefaultBranch),
+		UpdatedAt:     timestamp(p.LastActivityAt),
+	}
+}
+
+// user is a GitLab user as embedded in other resources
+type user struct {
+	Username string `json:"username"`
+	WebURL   string `json:"web_url"`
+	Bot      bool   `json:"bot"`
+}
+
+// toUser presents the user as a GitHub user
+func (u user) toUser() *github.User {
+	kind := "User"
+	if u.Bot {
+		kind = "Bot"
+	}
+	return &github.User{Login: github.String(u.Username), HTMLURL: github.String(u.WebURL), Type: github.String(kind)}
+}
+
+// mergeRequest is a GitLab merge request
+type mergeRequest struct {
+	ID           int64      `json:"id"`
+	IID          int        `json:"iid"`
+	Title        string     `json:"title"`
+	Description  string     `json:"description"`
+	State        string     `json:"state"`
+	Draft        bool       `json:"draft"`
+	WebURL       string     `json:"web_url"`
+	SourceBranch string     `json:"source_branch"`
+	TargetBranch string     `json:"target_branch"`
+	SHA          string     `json:"sha"`
+	Author       user       `json:"author"`
+	CreatedAt    *time.Time `json:"created_at"`
+	UpdatedAt    *time.Time `json:"updated_at"`
+	MergedAt     *time.Time `json:"merged_at"`
+}
+
+// toPullRequest presents the merge request as a pull request numbered by its IID
+func (mr mergeRequest) toPullRequest() *github.PullRequest {
+	state := "open"
+	if mr.State != "opened" {
+		state = "closed"
+	}
+	return &github.PullRequest{
+		ID:        github.Int64(mr.ID),
+		Number:    github.Int(mr.IID),
+		Title:     github.String(mr.Title),
+		Body:      github.String(mr.Description),
+		State:     github.String(state),
+		Draft:     github.Bool(mr.Draft),
+		Merged:    github.Bool(mr.State == "merged"),
+		HTMLURL:   github.String(mr.WebURL),
+		User:      mr.Author.toUser(),
+		CreatedAt: timestamp(mr.CreatedAt),
+		UpdatedAt: timestamp(mr.UpdatedAt),
+		MergedAt:  timestamp(mr.MergedAt),
+		Head:      &github.PullRequestBranch{Ref: github.String(mr.SourceBranch), SHA: github.String(mr.SHA)},
+		Base:      &github.PullRequestBranch{Ref: github.String(mr.TargetBranch)},
+	}
+}
+
+// discussion is a thread of notes on a merge request
+type discussion struct {
+	ID    string `json:"id"`
+	Notes []note `json:"notes"`
+}
+
+// note is a comment in a discussion
+type note struct {
+	ID         int64      `json:"id"`
+	Body       string     `json:"body"`
+	Author     user       `json:"author"`
+	System     bool       `json:"system"`
+	Resolvable bool       `json:"resolvable"`
+	Resolved   bool       `json:"resolved"`
+	CreatedAt  *time.Time `json:"created_at"`
+	UpdatedAt  *time.Time `json:"updated_at"`
+	Position   *position  `json:"position"`
+}
+
+// position locates a note on the diff
+type position struct {
+	HeadSHA   string `json:"head_sha"`
+	OldPath   string `json:"old_path"`
+	NewPath   string `json:"new_path"`
+	OldLine   int    `json:"old_line"`
+	NewLine   int    `json:"new_line"`
+	LineRange *struct {
+		Start struct {
+			OldLine int `json:"old_line"`
+			NewLine int `json:"new_line"`
+		} `json:"start"`
+	} `json:"line_range"`
+}
+
+// fileDiff is the diff of one file changed by a merge request
+type fileDiff struct {
+	OldPath string `json:"old_path"`
+	NewPath string `json:"new_path"`
+	Diff    string `json:"diff"`
+}
+
+// toComments presents the notes of a discussion as review comments; replies point at the first note.
+// diffs holds the diff of each changed file, used for the diff context of notes on the diff.
+func (d discussion) toComments(mr *github.PullRequest, diffs map[string]string) []*github.PullRequestComment {
+	var comments []*github.PullRequestComment
+	var root int64
+	var rootPosition *position
+	for _, n := range d.Notes {
+		if n.System {
+			continue
+		}
+		comment := &github.PullRequestComment{
+			ID:             github.Int64(n.ID),
+			Body:           github.String(n.Body),
+			User:           n.Author.toUser(),
+			CreatedAt:      timestamp(n.CreatedAt),
+			UpdatedAt:      timestamp(n.UpdatedAt),
+			HTMLURL:        github.String(fmt.Sprintf("%s#note_%d", mr.GetHTMLURL(), n.ID)),
+			PullRequestURL: github.String(mr.GetHTMLURL()),
+		}
+		if root == 0 {
+			root, rootPosition = n.ID, n.Position
+		} else {
+			comment.InReplyTo = github.Int64(root)
+		}
+		// Replies share the location of the note they answer, as on GitHub
+		if p := cmp.Or(n.Position, rootPosition); p != nil {
+			p.apply(comment, diffs)
+		}
+		comments = append(comments, comment)
+	}
+	return comments
+}
+
+// apply sets the location and diff context of a review comment from the position
+func (p *position) apply(comment *github.PullRequestComment, diffs map[string]string) {
+	filePath, line, side := p.NewPath, p.NewLine, "RIGHT"
+	if p.NewLine == 0 {
+		// A note on a removed line
+		filePath, line, side = p.OldPath, p.OldLine, "LEFT"
+	}
+
+	comment.Path = github.String(filePath)
+	comment.CommitID = github.String(p.HeadSHA)
+	comment.OriginalCommitID = github.String(p.HeadSHA)
+	comment.Line = github.Int(line)
+	comment.OriginalLine = github.Int(line)
+	comment.Side = github.String(side)
+	if r := p.LineRange; r != nil {
+		start := r.Start.NewLine
+		if side == "LEFT" {
+			start = r.Start.OldLine
+		}
+		if start != 0 && start != line {
+			comment.StartLine = github.Int(start)
+			comment.OriginalStartLine = github.Int(start)
+		}
+	}
+	if hunk := diff.Hunk(diffs[filePath], line, side == "LEFT"); hunk != "" {
+		comment.DiffHunk = github.String(hunk)
+	}
+}
+
+// timestamp converts an optional time to a GitHub timestamp
+func timestamp(t *time.Time) *github.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return &github.Timestamp{Time: *t}
+}
@@ -0,0 +1,74 @@
+package gitrepo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stefrushxyz/nitpick/internal/blame"
+)
+
+// HasCommit reports whether the checkout's object database holds the given commit
+func (c *Checkout) HasCommit(sha string) bool {
+	_, err := git(c.Root, "cat-file", "-e", sha+"^{commit}")
+	return err == nil
+}
+
+// FileAt returns the content of the file at path as of commit
+func (c *Checkout) FileAt(commit, path string) ([]byte, error) {
+	return gitOutput(c.Root, "show", commit+":"+path)
+}
+
+// Blame returns the commits that last changed lines start to end of the file at path as of commit
+func (c *Checkout) Blame(commit, path string, start, end int) ([]blame.Range, error) {
+	out, err := git(c.Root, "blame", "--line-porcelain", "-L", fmt.Sprintf("%d,%d", start, end), commit, "--", path)
+	if err != nil {
+		return nil, err
+	}
+	return parseBlame(out), nil
+}
+
+// parseBlame parses the output of git blame --line-porcelain into ranges of consecutive lines with the
+// same commit
+func parseBlame(out string) []blame.Range {
+	var ranges []blame.Range
+	var current blame.Range
+	for _, line := range strings.Split(out, "\n") {
+		key, value, _ := strings.Cut(line, " ")
+		switch {
+		case strings.HasPrefix(line, "\t"):
+			// The line's content ends its entry
+			if n := len(ranges); n > 0 && ranges[n-1].Commit == current.Commit && ranges[n-1].End == current.Start-1 {
+				ranges[n-1].End = current.Start
+			} else {
+				ranges = append(ranges, current)
+			}
+		case isSHA(key):
+			// <sha> <original line> <final line> [<lines in group>]
+			fields := strings.Fields(value)
+			if len(fields) < 2 {
+				continue
+			}
+			n, _ := strconv.Atoi(fields[1])
+			current = blame.Range{Start: n, End: n, Commit: key}
+		case key == "author":
+			current.Author = value
+		case key == "author-time":
+			if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+				current.Date = time.Unix(seconds, 0)
+			}
+		case key == "summary":
+			current.Summary = value
+		}
+	}
+	return ranges
+}
+
+// isSHA reports whether s is a full SHA-1 or SHA-256 object name
+func isSHA(s string) bool {
+	if len(s) != 40 && len(s) != 64 {
+		return false
+	}
+	return strings.Trim(s, "0123456789abcdef") == ""
+}
@@ -0,0 +1,111 @@
+package gitrepo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"path"
+	"strings"
+)
+
+// Checkout describes the local git checkout nitpick was started in
+type Checkout struct {
+	Host   string // Host of the origin remote
+	Owner  string // Owner of the repository, e.g. a user, organization, group path or Azure DevOps project
+	Name   string // Name of the repository
+	Branch string // Current branch; empty on a detached HEAD
+	Commit string // SHA of the HEAD commit
+	Root   string // Top-level directory of the working tree
+}
+
+// Detect inspects the git checkout containing dir: its origin remote, current branch and HEAD commit.
+// It fails when git is not installed, dir is not inside a checkout, or there is no origin remote.
+func Detect(dir string) (*Checkout, error) {
+	remote, err := git(dir, "remote", "get-url", "origin")
+	if err != nil {
+		return nil, err
+	}
+	checkout, err := ParseRemote(remote)
+	if err != nil {
+		return nil, err
+	}
+
+	checkout.Commit, err = git(dir, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	checkout.Root, err = git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	// On a detached HEAD, e.g. a change checked out from Gerrit, only the commit is known
+	if branch, err := git(dir, "symbolic-ref", "--quiet", "--short", "HEAD"); err == nil {
+		checkout.Branch = branch
+	}
+
+	return checkout, nil
+}
+
+// Is reports whether the checkout is of the repository owner/name
+func (c *Checkout) Is(owner, name string) bool {
+	return strings.EqualFold(c.Owner, owner) && strings.EqualFold(c.Name, name)
+}
+
+// ParseRemote parses a remote URL, e.g. https://github.com/owner/name.git, git@github.com:owner/name.git
+// or ssh://git@host:29418/owner/name, into the host, owner and name of its repository
+func ParseRemote(remote string) (*Checkout, error) {
+	var host, repoPath string
+	if u, err := url.Parse(remote); err == nil && u.Scheme != "" && u.Host != "" {
+		host, repoPath = u.Hostname(), u.Path
+	} else if at, colon := strings.Index(remote, "@"), strings.Index(remote, ":"); colon > at {
+		// scp-like syntax: [user@]host:path
+		host, repoPath = remote[at+1:colon], remote[colon+1:]
+	} else {
+		return nil, fmt.Errorf("unsupported remote URL %q", remote)
+	}
+
+	repoPath = strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git")
+	segments := strings.Split(repoPath, "/")
+	switch {
+	case strings.HasPrefix(host, "ssh.") && segments[0] == "v3" && len(segments) == 4:
+		// Azure DevOps SSH: ssh.dev.azure.com:v3/organization/project/repo
+		host = strings.TrimPrefix(host, "ssh.")
+		segments = segments[2:]
+	case len(segments) >= 4 && segments[len(segments)-2] == "_git":
+		// Azure DevOps HTTPS: dev.azure.com/organization/project/_git/repo
+		segments = []string{segments[len(segments)-3], segments[len(segments)-1]}
+	}
+
+	owner, name := path.Split(strings.Join(segments, "/"))
+	owner = strings.TrimSuffix(owner, "/")
+	if owner == "" || name == "" {
+		return nil, fmt.Errorf("remote URL %q does not name an owner/repository", remote)
+	}
+
+	return &Checkout{Host: host, Owner: owner, Name: name}, nil
+}
+
+// git runs a git command in dir and returns its trimmed output
+func git(dir string, args ...string) (string, error) {
+	out, err := gitOutput(dir, args...)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// gitOutput runs a git command in dir and returns its output as is
+func gitOutput(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(bytes.TrimSpace(exitErr.Stderr)) > 0 {
+			return nil, fmt.Errorf("git %s failed: %s", strings.Join(args, " "), bytes.TrimSpace(exitErr.Stderr))
+		}
+		return nil, fmt.Errorf("git %s failed: %w", strings.Join(args, " "), err)
+	}
+	return out, nil
+}
@@ -0,0 +1,30 @@
+package gitrepo
+
+import (
+	"fmt"
+	"os"
+)
+
+// AddWorktree checks out branch in a new worktree at dir. Unless the branch exists locally, it is created
+// from ref, fetched from origin. A directory that already exists at dir is assumed to be the worktree and
+// reused, in which case created is false.
+func (c *Checkout) AddWorktree(dir, branch, ref string) (created bool, err error) {
+	if _, err := os.Stat(dir); err == nil {
+		return false, nil
+	}
+
+	if _, err := git(c.Root, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err == nil {
+		if _, err := git(c.Root, "worktree", "add", dir, branch); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	if _, err := git(c.Root, "fetch", "origin", ref); err != nil {
+		return false, fmt.Errorf("failed to fetch %s: %w", ref, err)
+	}
+	if _, err := git(c.Root, "worktree", "add", "-b", branch, dir, "FETCH_HEAD"); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,121 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/stefrushxyz/nitpick/internal/logging"
+)
+
+// Issue is a Jira ticket
+type Issue struct {
+	Key         string
+	Summary     string
+	Description string // Description in Jira's wiki markup
+	Status      string
+	Type        string
+	URL         string // Browse URL of the issue
+}
+
+// Client fetches issues from a Jira Cloud, Server or Data Center site
+type Client struct {
+	baseURL string
+	email   string
+	token   string
+	client  *http.Client
+}
+
+// New creates a client for the Jira site at baseURL. With an email, token is sent as a Jira Cloud API
+// token; without one, as a Server or Data Center personal access token.
+func New(baseURL, email, token string, timeout time.Duration) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf("invalid Jira base URL %q", baseURL)
+	}
+	return &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		email:   email,
+		token:   token,
+		client:  &http.Client{Timeout: timeout, Transport: logging.Transport(nil)},
+	}, nil
+}
+
+// issueResponse is the part of the issue resource nitpick reads
+type issueResponse struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary     string `json:"summary"`
+		Description string `json:"description"`
+		Status      struct {
+			Name string `json:"name"`
+		} `json:"status"`
+		IssueType struct {
+			Name string `json:"name"`
+		} `json:"issuetype"`
+	} `json:"fields"`
+}
+
+// GetIssue fetches an issue by key. It returns nil without an error when the issue does not exist or is
+// not visible to the account, as text that merely looks like a key (e.g. UTF-8) is common.
+func (c *Client) GetIssue(ctx context.Context, key string) (*Issue, error) {
+	endpoint := fmt.Sprintf("%s/rest/api/2/issue/%s?fields=summary,description,status,issuetype", c.baseURL, url.PathEscape(key))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	switch {
+	case c.email != "":
+		req.SetBasicAuth(c.email, c.token)
+	case c.token != "":
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("jira request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, nil
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("jira returned %s for %s: %s", resp.Status, key, strings.TrimSpace(string(body)))
+	}
+
+	var issue issueResponse
+	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
+		return nil, fmt.Errorf("failed to decode jira issue %s: %w", key, err)
+	}
+	return &Issue{
+		Key:         issue.Key,
+		Summary:     issue.Fields.Summary,
+		Description: strings.TrimSpace(issue.Fields.Description),
+		Status:      issue.Fields.Status.Name,
+		Type:        issue.Fields.IssueType.Name,
+		URL:         c.baseURL + "/browse/" + issue.Key,
+	}, nil
+}
+
+// Issues fetches the issues with the given keys, leaving out those that do not exist
+func (c *Client) Issues(ctx context.Context, keys []string) ([]*Issue, error) {
+	var issues []*Issue
+	for _, key := range keys {
+		issue, err := c.GetIssue(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if issue != nil {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
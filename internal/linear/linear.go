@@ -0,0 +1,124 @@
+package linear
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/stefrushxyz/nitpick/internal/logging"
+)
+
+// Endpoint is the URL of Linear's GraphQL API
+const Endpoint = "https://api.linear.app/graphql"
+
+// issueQuery fetches an issue by its identifier, e.g. ENG-123
+const issueQuery = `query Issue($id: String!) {
+  issue(id: $id) {
+    identifier
+    title
+    description
+    url
+    state { name }
+    team { name }
+  }
+}`
+
+// Issue is a Linear issue
+type Issue struct {
+	Identifier  string `json:"identifier"`
+	Title       string `json:"title"`
+	Description string `json:"description"` // Description in markdown
+	URL         string `json:"url"`
+	State       struct {
+		Name string `json:"name"`
+	} `json:"state"`
+	Team struct {
+		Name string `json:"name"`
+	} `json:"team"`
+}
+
+// Client fetches issues from Linear
+type Client struct {
+	apiKey string
+	client *http.Client
+}
+
+// New creates a client authenticating with a personal API key
+func New(apiKey string, timeout time.Duration) *Client {
+	return &Client{apiKey: apiKey, client: &http.Client{Timeout: timeout, Transport: logging.Transport(nil)}}
+}
+
+// graphQLResponse is the response to issueQuery
+type graphQLResponse struct {
+	Data struct {
+		Issue *Issue `json:"issue"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// GetIssue fetches an issue by identifier. It returns nil without an error when the issue does not exist
+// or is not visible to the account, as text that merely looks like an identifier is common.
+func (c *Client) GetIssue(ctx context.Context, identifier string) (*Issue, error) {
+	payload, err := json.Marshal(map[string]any{
+		"query":     issueQuery,
+		"variables": map[string]string{"id": identifier},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, Endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", c.apiKey)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("linear request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// GraphQL errors such as a missing issue come with 200 or 400 and are reported in the body
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusBadRequest {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("linear returned %s for %s: %s", resp.Status, identifier, strings.TrimSpace(string(body)))
+	}
+
+	var result graphQLResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode linear issue %s: %w", identifier, err)
+	}
+	if result.Data.Issue != nil {
+		return result.Data.Issue, nil
+	}
+	for _, e := range result.Errors {
+		if !strings.Contains(strings.ToLower(e.Message), "not found") {
+			return nil, fmt.Errorf("linear returned an error for %s: %s", identifier, e.Message)
+		}
+	}
+	return nil, nil
+}
+
+// Issues fetches the issues with the given identifiers, leaving out those that do not exist
+func (c *Client) Issues(ctx context.Context, identifiers []string) ([]*Issue, error) {
+	var issues []*Issue
+	for _, identifier := range identifiers {
+		issue, err := c.GetIssue(ctx, identifier)
+		if err != nil {
+			return nil, err
+		}
+		if issue != nil {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
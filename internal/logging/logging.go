@@ -0,0 +1,99 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// logFile is the file opened by Setup, closed by Close
+var logFile *os.File
+
+func init() {
+	// Logging is disabled until Setup is called with a file
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// ParseLevel parses a log level name: debug, info, warn or error
+func ParseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.ToLower(name))); err != nil {
+		return level, fmt.Errorf("invalid log level %q: expected debug, info, warn or error", name)
+	}
+	return level, nil
+}
+
+// Setup directs the default slog logger to the file at path, recording entries at or above level.
+// An empty path leaves logging disabled.
+func Setup(path string, level slog.Level) error {
+	if path == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	logFile = f
+	slog.SetDefault(slog.New(slog.NewTextHandler(f, &slog.HandlerOptions{Level: level})))
+	return nil
+}
+
+// Close closes the log file opened by Setup, if any
+func Close() error {
+	if logFile == nil {
+		return nil
+	}
+	err := logFile.Close()
+	logFile = nil
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	return err
+}
+
+// transport logs every HTTP request made through it
+type transport struct {
+	base http.RoundTripper
+}
+
+// Transport wraps base so each API call is logged with its status and duration, and counted in the
+// statistics served by ServePprof. Successful calls are logged at debug level, failures at warn level.
+func Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return transport{base: base}
+}
+
+// RoundTrip performs the request and logs its outcome
+func (t transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	duration := time.Since(start)
+
+	recordAPICall(req.URL.Host, duration, err != nil || resp.StatusCode >= 400)
+
+	ctx := req.Context()
+	switch {
+	case err != nil:
+		slog.WarnContext(ctx, "api request failed",
+			"method", req.Method, "url", req.URL.String(), "duration", duration, "error", err)
+	case resp.StatusCode >= 400:
+		slog.WarnContext(ctx, "api request",
+			"method", req.Method, "url", req.URL.String(), "status", resp.StatusCode, "duration", duration)
+	default:
+		slog.DebugContext(ctx, "api request",
+			"method", req.Method, "url", req.URL.String(), "status", resp.StatusCode, "duration", duration,
+			"rate_limit_remaining", resp.Header.Get("X-RateLimit-Remaining"))
+	}
+
+	return resp, err
+}
@@ -0,0 +1,59 @@
+package logging
+
+import (
+	"expvar"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"time"
+)
+
+// API call statistics, served at /debug/vars by ServePprof
+var (
+	apiRequests = expvar.NewMap("api_requests")   // Requests made, by host
+	apiTime     = expvar.NewMap("api_request_ms") // Total time spent in requests, by host
+	apiFailures = expvar.NewMap("api_failures")   // Requests that failed or returned an error status, by host
+	startedAt   = time.Now()
+)
+
+func init() {
+	expvar.Publish("uptime_seconds", expvar.Func(func() any { return time.Since(startedAt).Seconds() }))
+}
+
+// recordAPICall adds a request to host to the API call statistics
+func recordAPICall(host string, duration time.Duration, failed bool) {
+	apiRequests.Add(host, 1)
+	apiTime.Add(host, duration.Milliseconds())
+	if failed {
+		apiFailures.Add(host, 1)
+	}
+}
+
+// ServePprof serves the net/http/pprof profiles under /debug/pprof/ and the API call statistics under
+// /debug/vars at addr, e.g. localhost:6060, in the background. It returns the address listened on, which
+// differs from addr when its port is 0.
+func ServePprof(addr string) (string, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", fmt.Errorf("failed to serve pprof: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+
+	go func() {
+		if err := http.Serve(listener, mux); err != nil {
+			slog.Warn("pprof server stopped", "err", err)
+		}
+	}()
+
+	slog.Info("serving pprof", "addr", listener.Addr().String())
+	return listener.Addr().String(), nil
+}
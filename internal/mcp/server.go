@@ -0,0 +1,218 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+)
+
+// ProtocolVersion is the Model Context Protocol revision the server implements
+const ProtocolVersion = "2024-11-05"
+
+// JSON-RPC error codes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// Tool is a tool exposed to MCP clients
+type Tool struct {
+	Name        string
+	Description string
+	InputSchema map[string]any // JSON schema of the tool's arguments
+
+	// Handler runs the tool with its raw arguments. A string result is returned as is, any other value as
+	// indented JSON; an error is reported to the client as a failed tool call rather than a protocol error.
+	Handler func(ctx context.Context, args json.RawMessage) (any, error)
+}
+
+// Server serves tools over the Model Context Protocol, as newline-delimited JSON-RPC 2.0 messages
+type Server struct {
+	name    string
+	version string
+	tools   []Tool
+	mu      sync.Mutex // Serializes writes of responses
+}
+
+// NewServer creates a server that identifies itself to clients with the given name and version
+func NewServer(name, version string) *Server {
+	return &Server{name: name, version: version}
+}
+
+// AddTool registers a tool
+func (s *Server) AddTool(tool Tool) {
+	s.tools = append(s.tools, tool)
+}
+
+// request is a JSON-RPC request or, without an ID, a notification
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// response is a JSON-RPC response
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is the error of a failed JSON-RPC request
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Serve reads requests from r and writes responses to w until r is exhausted or ctx is canceled.
+// Tool calls run concurrently, so a slow API request does not hold up the client's other requests.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var req request
+		if err := json.Unmarshal(line, &req); err != nil {
+			s.write(w, response{ID: json.RawMessage("null"), Error: &rpcError{Code: codeParseError, Message: err.Error()}})
+			continue
+		}
+		if req.ID == nil {
+			// Notifications, e.g. notifications/initialized, need no response
+			slog.Debug("mcp notification", "method", req.Method)
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, rpcErr := s.handle(ctx, req)
+			s.write(w, response{ID: req.ID, Result: result, Error: rpcErr})
+		}()
+	}
+
+	return scanner.Err()
+}
+
+// handle dispatches a request to its method
+func (s *Server) handle(ctx context.Context, req request) (any, *rpcError) {
+	slog.Debug("mcp request", "method", req.Method)
+
+	switch req.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		_ = json.Unmarshal(req.Params, &params)
+		version := ProtocolVersion
+		if params.ProtocolVersion != "" && params.ProtocolVersion < version {
+			// Protocol revisions are dates, so an older client's revision is used if it asks for one
+			version = params.ProtocolVersion
+		}
+		return map[string]any{
+			"protocolVersion": version,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]any{"name": s.name, "version": s.version},
+		}, nil
+
+	case "ping":
+		return map[string]any{}, nil
+
+	case "tools/list":
+		tools := make([]map[string]any, len(s.tools))
+		for i, tool := range s.tools {
+			tools[i] = map[string]any{
+				"name":        tool.Name,
+				"description": tool.Description,
+				"inputSchema": tool.InputSchema,
+			}
+		}
+		return map[string]any{"tools": tools}, nil
+
+	case "tools/call":
+		var params struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		for _, tool := range s.tools {
+			if tool.Name == params.Name {
+				return s.call(ctx, tool, params.Arguments), nil
+			}
+		}
+		return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown tool %q", params.Name)}
+
+	case "":
+		return nil, &rpcError{Code: codeInvalidRequest, Message: "missing method"}
+
+	default:
+		return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %q not found", req.Method)}
+	}
+}
+
+// call runs a tool and wraps its result or error as tool call content
+func (s *Server) call(ctx context.Context, tool Tool, args json.RawMessage) map[string]any {
+	if len(args) == 0 {
+		args = json.RawMessage("{}")
+	}
+
+	result, err := tool.Handler(ctx, args)
+	if err != nil {
+		slog.Warn("mcp tool failed", "tool", tool.Name, "err", err)
+		return toolResult(err.Error(), true)
+	}
+
+	text, ok := result.(string)
+	if !ok {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return toolResult(fmt.Sprintf("failed to encode result: %v", err), true)
+		}
+		text = string(data)
+	}
+	return toolResult(text, false)
+}
+
+// toolResult builds the result of a tool call with a single text content
+func toolResult(text string, isError bool) map[string]any {
+	return map[string]any{
+		"content": []map[string]any{{"type": "text", "text": text}},
+		"isError": isError,
+	}
+}
+
+// write sends a response as a single line
+func (s *Server) write(w io.Writer, resp response) {
+	resp.JSONRPC = "2.0"
+	data, err := json.Marshal(resp)
+	if err != nil {
+		slog.Error("failed to encode mcp response", "err", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		slog.Error("failed to write mcp response", "err", err)
+	}
+}
@@ -0,0 +1,38 @@
+package notify
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"strconv"
+)
+
+// Bell rings the terminal bell by writing BEL to w
+func Bell(w io.Writer) error {
+	_, err := io.WriteString(w, "\a")
+	return err
+}
+
+// Desktop shows a desktop notification with notify-send on Linux and the BSDs, or osascript on macOS
+func Desktop(title, body string) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", strconv.Quote(body), strconv.Quote(title))
+		cmd = exec.Command("osascript", "-e", script)
+	case "linux", "freebsd", "openbsd", "netbsd":
+		if _, err := exec.LookPath("notify-send"); err != nil {
+			return fmt.Errorf("no notifier found (notify-send required)")
+		}
+		cmd = exec.Command("notify-send", "--app-name=nitpick", title, body)
+	default:
+		return fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+	}
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to show notification: %w: %s", err, out)
+	}
+	return nil
+}
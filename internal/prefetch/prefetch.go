@@ -0,0 +1,111 @@
+package prefetch
+
+import (
+	"context"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DefaultConcurrency is the number of background fetches run at once by default
+const DefaultConcurrency = 4
+
+// Job is a background fetch. It should give up when ctx is cancelled; its message is dropped then.
+type Job func(ctx context.Context) tea.Msg
+
+// Queue runs background fetches, e.g. prefetching the comments of listed pull requests or enriching a
+// comment with its blame, as bubbletea commands that wait for one of a bounded number of slots. Jobs belong
+// to a group, typically the view that wanted them, so leaving the view cancels the jobs still queued or
+// running for it.
+type Queue struct {
+	slots chan struct{}
+
+	mu      sync.Mutex
+	groups  map[string]*group
+	pending map[string]*group // Groups of the jobs queued or running, by key
+}
+
+// group holds the context shared by the jobs of a group
+type group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// New creates a queue running up to concurrency jobs at once; less than 1 uses DefaultConcurrency
+func New(concurrency int) *Queue {
+	if concurrency < 1 {
+		concurrency = DefaultConcurrency
+	}
+	return &Queue{
+		slots:   make(chan struct{}, concurrency),
+		groups:  make(map[string]*group),
+		pending: make(map[string]*group),
+	}
+}
+
+// Add queues a job of a group and returns the command running it once a slot is free. Jobs are
+// identified by key: it returns nil while a job with the same key is queued or running. A job cancelled
+// with its group returns no message.
+func (q *Queue) Add(groupName, key string, job Job) tea.Cmd {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, ok := q.pending[key]; ok {
+		return nil
+	}
+
+	g, ok := q.groups[groupName]
+	if !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		g = &group{ctx: ctx, cancel: cancel}
+		q.groups[groupName] = g
+	}
+	q.pending[key] = g
+
+	return func() tea.Msg {
+		defer q.done(key, g)
+
+		select {
+		case q.slots <- struct{}{}:
+		case <-g.ctx.Done():
+			return nil
+		}
+		defer func() { <-q.slots }()
+
+		if g.ctx.Err() != nil {
+			return nil
+		}
+		msg := job(g.ctx)
+		if g.ctx.Err() != nil {
+			return nil
+		}
+		return msg
+	}
+}
+
+// Cancel cancels the jobs of a group that are queued or running. Jobs added to the group later run as usual.
+func (q *Queue) Cancel(groupName string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	g, ok := q.groups[groupName]
+	if !ok {
+		return
+	}
+	g.cancel()
+	delete(q.groups, groupName)
+	for key, pending := range q.pending {
+		if pending == g {
+			delete(q.pending, key)
+		}
+	}
+}
+
+// done forgets a job of group g that finished, so it can be added again
+func (q *Queue) done(key string, g *group) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.pending[key] == g {
+		delete(q.pending, key)
+	}
+}
@@ -0,0 +1,142 @@
+package progress
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/stefrushxyz/nitpick/internal/config"
+)
+
+// Statuses of a marked review comment
+const (
+	Addressed = "addressed" // The feedback has been dealt with locally
+	Ignored   = "ignored"   // The feedback was deliberately skipped
+)
+
+// Mark is the local review progress of a single comment
+type Mark struct {
+	Status   string    `json:"status"`
+	Repo     string    `json:"repo"` // owner/name
+	PR       int       `json:"pr"`
+	MarkedAt time.Time `json:"marked_at"`
+}
+
+// Store holds the marks of review comments keyed by comment ID, saved in a JSON file
+type Store struct {
+	path  string
+	Marks map[int64]Mark
+}
+
+// DefaultPath returns the path of the progress file in the state directory
+func DefaultPath() (string, error) {
+	dir, err := config.StateDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate state directory: %w", err)
+	}
+	return filepath.Join(dir, "progress.json"), nil
+}
+
+// Load reads the marks stored at path, returning an empty store if the file does not exist
+func Load(path string) (*Store, error) {
+	store := &Store{path: path, Marks: make(map[int64]Mark)}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read review progress: %w", err)
+	}
+
+	if err := json.Unmarshal(content, &store.Marks); err != nil {
+		return nil, fmt.Errorf("failed to parse review progress %s: %w", path, err)
+	}
+	if store.Marks == nil {
+		store.Marks = make(map[int64]Mark)
+	}
+	return store, nil
+}
+
+// LoadDefault reads the marks from the default path
+func LoadDefault() (*Store, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return Load(path)
+}
+
+// Save writes the marks to the store's file
+func (s *Store) Save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	content, err := json.MarshalIndent(s.Marks, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a failed write never loses the existing marks
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), "progress-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write review progress: %w", err)
+	}
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write review progress: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write review progress: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write review progress: %w", err)
+	}
+	return nil
+}
+
+// Status returns the status of a comment, or an empty string if it is unmarked
+func (s *Store) Status(commentID int64) string {
+	return s.Marks[commentID].Status
+}
+
+// Toggle sets the status of a comment of the given pull request, or clears it if the comment already
+// has that status, and returns the new status
+func (s *Store) Toggle(commentID int64, repo string, pr int, status string) string {
+	if s.Status(commentID) == status {
+		delete(s.Marks, commentID)
+		return ""
+	}
+	s.Marks[commentID] = Mark{Status: status, Repo: repo, PR: pr, MarkedAt: time.Now()}
+	return status
+}
+
+// HasPR reports whether any comment of the given pull request is marked
+func (s *Store) HasPR(repo string, pr int) bool {
+	for _, mark := range s.Marks {
+		if mark.Repo == repo && mark.PR == pr {
+			return true
+		}
+	}
+	return false
+}
+
+// Prune drops the marks of the given comments, which no longer need tracking once their threads
+// are resolved upstream, and returns how many were dropped
+func (s *Store) Prune(commentIDs []int64) int {
+	pruned := 0
+	for _, id := range commentIDs {
+		if _, ok := s.Marks[id]; ok {
+			delete(s.Marks, id)
+			pruned++
+		}
+	}
+	return pruned
+}
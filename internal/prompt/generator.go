@@ -7,12 +7,25 @@ import (
 	"time"
 
 	"github.com/google/go-github/v57/github"
+	"github.com/stefrushxyz/nitpick/internal/blame"
+	"github.com/stefrushxyz/nitpick/internal/codeowners"
+	"github.com/stefrushxyz/nitpick/internal/suggestion"
+	"github.com/stefrushxyz/nitpick/internal/symbol"
 )
 
 // Generator handles creating prompts for GitHub Copilot
 type Generator struct {
-	fullTemplate   *template.Template
-	simpleTemplate *template.Template
+	fullTemplate      *template.Template
+	simpleTemplate    *template.Template
+	aggregateTemplate *template.Template
+	templateDir       string
+	tickets           []*TicketData
+	commits           []*CommitData
+	issues            []*IssueData
+	codeowners        *codeowners.File
+	blame             map[int64][]blame.Range
+	symbols           map[int64]*symbol.Symbol
+	contexts          map[int64]string
 }
 
 // TemplateData holds all the data needed for prompt generation
@@ -23,6 +36,14 @@ type TemplateData struct {
 	Generated   string
 }
 
+// AggregateTemplateData holds the data needed for a prompt covering several comments
+type AggregateTemplateData struct {
+	Repository  *RepositoryData
+	PullRequest *PullRequestData
+	Comments    []*CommentData
+	Generated   string
+}
+
 type RepositoryData struct {
 	FullName    string
 	Name        string
@@ -41,6 +62,38 @@ type PullRequestData struct {
 	Body         string
 	SourceBranch string
 	TargetBranch string
+	Tickets      []*TicketData
+	Commits      []*CommitData // Commits of the pull request, oldest first, when set with SetCommits
+	Issues       []*IssueData  // Issues the pull request closes, when set with SetIssues
+}
+
+// IssueData holds an issue the pull request closes
+type IssueData struct {
+	Ref    string // Reference to the issue, such as #12, or acme/api#7 for an issue of another repository
+	Title  string
+	State  string
+	Labels []string
+	Body   string
+	URL    string
+}
+
+// TicketData holds an issue tracker ticket linked to the pull request
+type TicketData struct {
+	Tracker     string
+	Key         string
+	Summary     string
+	Type        string
+	Status      string
+	Description string
+	URL         string
+}
+
+// CommitData holds a commit of the pull request
+type CommitData struct {
+	SHA     string
+	Author  string
+	Date    string
+	Summary string
 }
 
 type CommentData struct {
@@ -56,6 +109,36 @@ type CommentData struct {
 	DiffHunk          string
 	Body              string
 	HTMLURL           string
+	Owners            []string          // CODEOWNERS of the commented file, when set with SetCodeowners
+	Blame             []*BlameData      // Commits that last changed the commented lines, when set with SetBlame
+	Symbol            *SymbolData       // Declaration enclosing the commented lines, when set with SetSymbols
+	FileContext       string            // Lines of the file around the commented lines as a hunk, when set with SetContexts
+	Suggestions       []*SuggestionData // Suggested changes in the body
+}
+
+// BlameData holds a run of commented lines last changed by the same commit
+type BlameData struct {
+	Lines   string
+	Commit  string
+	Author  string
+	Date    string
+	Summary string
+}
+
+// SuggestionData holds a suggested change: the lines replacing the commented lines when it is applied
+type SuggestionData struct {
+	Lines string // Commented lines replaced, such as L10-12
+	Code  string // Suggested lines
+	Diff  string // Hunk replacing the commented lines with the suggested ones
+}
+
+// SymbolData holds the function, method or type declaration enclosing the commented lines
+type SymbolData struct {
+	Name     string
+	Kind     string
+	Lines    string
+	Language string
+	Source   string
 }
 
 const fullPromptTemplate = `# GitHub Copilot Request for Code Review Changes
@@ -88,6 +171,37 @@ const fullPromptTemplate = `# GitHub Copilot Request for Code Review Changes
 {{- if .PullRequest.TargetBranch}}
 - **Target Branch**: {{.PullRequest.TargetBranch}}
 {{- end}}
+{{- if .PullRequest.Tickets}}
+
+## Linked Tickets
+{{- range .PullRequest.Tickets}}
+- **{{.Key}}**: {{.Summary}}{{if .Status}} ({{.Status}}){{end}}
+{{- if .Description}}
+` + "```" + `
+{{.Description}}
+` + "```" + `
+{{- end}}
+{{- end}}
+{{- end}}
+{{- if .PullRequest.Issues}}
+
+## Linked Issues
+{{- range .PullRequest.Issues}}
+- **{{.Ref}}**: {{.Title}} ({{.State}}{{if .Labels}}; {{join .Labels ", "}}{{end}})
+{{- if .Body}}
+` + "```" + `
+{{.Body}}
+` + "```" + `
+{{- end}}
+{{- end}}
+{{- end}}
+{{- if .PullRequest.Commits}}
+
+## Commits
+{{- range .PullRequest.Commits}}
+- {{.SHA}} {{.Summary}} ({{.Author}}, {{.Date}})
+{{- end}}
+{{- end}}
 
 ## Review Comment Context
 - **Reviewer**: {{.Comment.Reviewer}}
@@ -102,6 +216,15 @@ const fullPromptTemplate = `# GitHub Copilot Request for Code Review Changes
 {{- if .Comment.OriginalLineRange}}
 - **Original Lines**: {{.Comment.OriginalLineRange}}
 {{- end}}
+{{- if .Comment.Owners}}
+- **Code Owners**: {{join .Comment.Owners ", "}}
+{{- end}}
+{{- if .Comment.Blame}}
+- **Last Changed**:
+{{- range .Comment.Blame}}
+  - {{.Lines}} by {{.Author}} in {{.Commit}} ({{.Date}}): {{.Summary}}
+{{- end}}
+{{- end}}
 {{- end}}
 {{- if .Comment.DiffHunk}}
 - **Code Context**:
@@ -109,6 +232,24 @@ const fullPromptTemplate = `# GitHub Copilot Request for Code Review Changes
 {{.Comment.DiffHunk}}
 ` + "```" + `
 {{- end}}
+{{- with .Comment.Symbol}}
+- **Enclosing {{.Kind}}** ` + "`{{.Name}}`" + ` ({{.Lines}}):
+` + "```{{.Language}}" + `
+{{.Source}}
+` + "```" + `
+{{- end}}
+{{- if .Comment.FileContext}}
+- **Surrounding Lines**:
+` + "```diff" + `
+{{.Comment.FileContext}}
+` + "```" + `
+{{- end}}
+{{- range .Comment.Suggestions}}
+- **Suggested Change** ({{.Lines}}):
+` + "```diff" + `
+{{.Diff}}
+` + "```" + `
+{{- end}}
 
 ## Review Comment/Requested Changes
 {{- if .Comment.Body}}
@@ -150,20 +291,136 @@ const simplePromptTemplate = `# Review Comment for {{.Repository.Name}} PR #{{.P
 {{.Comment.DiffHunk}}
 ` + "```" + `
 
+{{- end}}
+{{- with .Comment.Symbol}}
+**Enclosing {{.Kind}}** ` + "`{{.Name}}`" + `:
+` + "```{{.Language}}" + `
+{{.Source}}
+` + "```" + `
+
+{{- end}}
+{{- if .Comment.FileContext}}
+**Surrounding Lines**:
+` + "```diff" + `
+{{.Comment.FileContext}}
+` + "```" + `
+
+{{- end}}
+{{- range .Comment.Suggestions}}
+**Suggested Change** ({{.Lines}}):
+` + "```diff" + `
+{{.Diff}}
+` + "```" + `
+
 {{- end}}
 **Review Comment**:
 {{.Comment.Body}}
 
 **Please help me address this review feedback with specific code changes.**`
 
+const aggregatePromptTemplate = `# Review Comments for {{.Repository.FullName}} PR #{{.PullRequest.Number}}
+
+## Pull Request Context
+- **PR #{{.PullRequest.Number}}**: {{.PullRequest.Title}}
+- **Author**: {{.PullRequest.Author}}
+{{- if .PullRequest.SourceBranch}}
+- **Source Branch**: {{.PullRequest.SourceBranch}}
+{{- end}}
+{{- if .PullRequest.TargetBranch}}
+- **Target Branch**: {{.PullRequest.TargetBranch}}
+{{- end}}
+{{- if .PullRequest.Body}}
+- **Description**:
+` + "```" + `
+{{.PullRequest.Body}}
+` + "```" + `
+{{- end}}
+{{- range .PullRequest.Tickets}}
+- **Ticket {{.Key}}**: {{.Summary}}{{if .Status}} ({{.Status}}){{end}}
+{{- if .Description}}
+` + "```" + `
+{{.Description}}
+` + "```" + `
+{{- end}}
+{{- end}}
+{{- range .PullRequest.Issues}}
+- **Issue {{.Ref}}**: {{.Title}} ({{.State}})
+{{- if .Body}}
+` + "```" + `
+{{.Body}}
+` + "```" + `
+{{- end}}
+{{- end}}
+{{- if .PullRequest.Commits}}
+- **Commits**:
+{{- range .PullRequest.Commits}}
+  - {{.SHA}} {{.Summary}} ({{.Author}}, {{.Date}})
+{{- end}}
+{{- end}}
+{{range $i, $c := .Comments}}
+## Comment {{add $i 1}} of {{len $.Comments}} by {{$c.Reviewer}}
+{{- if $c.Path}}
+**File**: ` + "`{{$c.Path}}`" + `{{if $c.LineRange}} ({{$c.LineRange}}){{end}}
+{{- if $c.Owners}}
+**Code Owners**: {{join $c.Owners ", "}}
+{{- end}}
+{{- range $c.Blame}}
+**Last Changed**: {{.Lines}} by {{.Author}} in {{.Commit}} ({{.Date}}): {{.Summary}}
+{{- end}}
+{{- end}}
+{{- if $c.DiffHunk}}
+
+**Code Context**:
+` + "```diff" + `
+{{$c.DiffHunk}}
+` + "```" + `
+{{- end}}
+{{- with $c.Symbol}}
+
+**Enclosing {{.Kind}}** ` + "`{{.Name}}`" + ` ({{.Lines}}):
+` + "```{{.Language}}" + `
+{{.Source}}
+` + "```" + `
+{{- end}}
+{{- if $c.FileContext}}
+
+**Surrounding Lines**:
+` + "```diff" + `
+{{$c.FileContext}}
+` + "```" + `
+{{- end}}
+{{- range $c.Suggestions}}
+
+**Suggested Change** ({{.Lines}}):
+` + "```diff" + `
+{{.Diff}}
+` + "```" + `
+{{- end}}
+
+**Review Comment**:
+{{$c.Body}}
+{{- if $c.HTMLURL}}
+
+**Direct Link**: {{$c.HTMLURL}}
+{{- end}}
+{{end}}
+## Instructions
+Please address each of the {{len .Comments}} review comments above with specific code changes.
+Keep the changes consistent with the existing codebase and the PR's overall objectives, and
+explain briefly how each change resolves the corresponding comment.
+
+Generated: {{.Generated}}`
+
 // New creates a new prompt generator
 func New() *Generator {
-	fullTmpl := template.Must(template.New("full").Parse(fullPromptTemplate))
-	simpleTmpl := template.Must(template.New("simple").Parse(simplePromptTemplate))
+	fullTmpl := template.Must(template.New(TemplateFull).Funcs(templateFuncs).Parse(fullPromptTemplate))
+	simpleTmpl := template.Must(template.New(TemplateSimple).Funcs(templateFuncs).Parse(simplePromptTemplate))
+	aggregateTmpl := template.Must(template.New(TemplateAggregate).Funcs(templateFuncs).Parse(aggregatePromptTemplate))
 
 	return &Generator{
-		fullTemplate:   fullTmpl,
-		simpleTemplate: simpleTmpl,
+		fullTemplate:      fullTmpl,
+		simpleTemplate:    simpleTmpl,
+		aggregateTemplate: aggregateTmpl,
 	}
 }
 
@@ -193,72 +450,134 @@ func (g *Generator) GenerateSimplePrompt(repo *github.Repository, pr *github.Pul
 	return buf.String()
 }
 
+// GenerateAggregatePrompt creates a single combined prompt covering every given comment on a PR
+func (g *Generator) GenerateAggregatePrompt(repo *github.Repository, pr *github.PullRequest, comments []*github.PullRequestComment) string {
+	data := g.buildAggregateTemplateData(repo, pr, comments)
+
+	var buf bytes.Buffer
+	if err := g.aggregateTemplate.Execute(&buf, data); err != nil {
+		// Fallback to error message if template execution fails
+		return fmt.Sprintf("Error generating prompt: %v", err)
+	}
+
+	return buf.String()
+}
+
 // buildTemplateData converts GitHub API structs to template-friendly data
 func (g *Generator) buildTemplateData(repo *github.Repository, pr *github.PullRequest, comment *github.PullRequestComment) *TemplateData {
-	data := &TemplateData{
-		Repository: &RepositoryData{
-			FullName:    repo.GetFullName(),
-			Name:        repo.GetName(),
-			Description: repo.GetDescription(),
-			Language:    repo.GetLanguage(),
-		},
-		PullRequest: &PullRequestData{
-			Number:   pr.GetNumber(),
-			Title:    pr.GetTitle(),
-			Author:   pr.GetUser().GetLogin(),
-			State:    pr.GetState(),
-			IsDraft:  pr.GetDraft(),
-			IsMerged: pr.GetMerged(),
-			Body:     pr.GetBody(),
-		},
-		Comment: &CommentData{
-			Reviewer:          comment.GetUser().GetLogin(),
-			Path:              comment.GetPath(),
-			Line:              comment.GetLine(),
-			StartLine:         comment.GetStartLine(),
-			OriginalLine:      comment.GetOriginalLine(),
-			OriginalStartLine: comment.GetOriginalStartLine(),
-			DiffHunk:          comment.GetDiffHunk(),
-			Body:              comment.GetBody(),
-			HTMLURL:           comment.GetHTMLURL(),
-		},
-		Generated: time.Now().Format("2006-01-02 15:04:05"),
+	return &TemplateData{
+		Repository:  g.buildRepositoryData(repo),
+		PullRequest: g.buildPullRequestData(pr),
+		Comment:     g.buildCommentData(comment),
+		Generated:   time.Now().Format("2006-01-02 15:04:05"),
+	}
+}
+
+// buildRepositoryData converts a GitHub repository to template-friendly data
+func (g *Generator) buildRepositoryData(repo *github.Repository) *RepositoryData {
+	return &RepositoryData{
+		FullName:    repo.GetFullName(),
+		Name:        repo.GetName(),
+		Description: repo.GetDescription(),
+		Language:    repo.GetLanguage(),
+	}
+}
+
+// buildPullRequestData converts a GitHub pull request to template-friendly data
+func (g *Generator) buildPullRequestData(pr *github.PullRequest) *PullRequestData {
+	data := &PullRequestData{
+		Number:   pr.GetNumber(),
+		Title:    pr.GetTitle(),
+		Author:   pr.GetUser().GetLogin(),
+		State:    pr.GetState(),
+		IsDraft:  pr.GetDraft(),
+		IsMerged: pr.GetMerged(),
+		Body:     pr.GetBody(),
 	}
 
 	// Format dates
 	if pr.CreatedAt != nil {
-		data.PullRequest.Created = pr.CreatedAt.Format("2006-01-02 15:04")
-	}
-	if comment.CreatedAt != nil {
-		data.Comment.Date = comment.CreatedAt.Format("2006-01-02 15:04")
+		data.Created = pr.CreatedAt.Format("2006-01-02 15:04")
 	}
 
 	// Format branch names
 	if pr.GetHead() != nil {
-		data.PullRequest.SourceBranch = pr.GetHead().GetRef()
+		data.SourceBranch = pr.GetHead().GetRef()
 	}
 	if pr.GetBase() != nil {
-		data.PullRequest.TargetBranch = pr.GetBase().GetRef()
+		data.TargetBranch = pr.GetBase().GetRef()
+	}
+
+	data.Tickets = g.tickets
+	data.Commits = g.commits
+	data.Issues = g.issues
+
+	return data
+}
+
+// buildCommentData converts a GitHub review comment to template-friendly data
+func (g *Generator) buildCommentData(comment *github.PullRequestComment) *CommentData {
+	data := &CommentData{
+		Reviewer:          comment.GetUser().GetLogin(),
+		Path:              comment.GetPath(),
+		Line:              comment.GetLine(),
+		StartLine:         comment.GetStartLine(),
+		OriginalLine:      comment.GetOriginalLine(),
+		OriginalStartLine: comment.GetOriginalStartLine(),
+		DiffHunk:          comment.GetDiffHunk(),
+		Body:              comment.GetBody(),
+		HTMLURL:           comment.GetHTMLURL(),
+		Owners:            g.codeowners.Owners(comment.GetPath()),
+		FileContext:       g.contexts[comment.GetID()],
+	}
+
+	for _, r := range g.blame[comment.GetID()] {
+		data.Blame = append(data.Blame, &BlameData{
+			Lines:   r.Lines(),
+			Commit:  r.ShortCommit(),
+			Author:  r.Who(),
+			Date:    r.Date.Format("2006-01-02"),
+			Summary: r.Summary,
+		})
+	}
+
+	for _, s := range suggestion.Find(comment) {
+		data.Suggestions = append(data.Suggestions, &SuggestionData{Lines: s.Lines(), Code: s.Code(), Diff: s.Diff()})
+	}
+
+	if sym := g.symbols[comment.GetID()]; sym != nil {
+		data.Symbol = &SymbolData{
+			Name:     sym.Name,
+			Kind:     sym.Kind,
+			Lines:    sym.Lines(),
+			Language: sym.Language,
+			Source:   sym.Source,
+		}
+	}
+
+	// Format dates
+	if comment.CreatedAt != nil {
+		data.Date = comment.CreatedAt.Format("2006-01-02 15:04")
 	}
 
 	// Format line ranges
-	if data.Comment.Line != 0 {
-		if data.Comment.StartLine != 0 && data.Comment.StartLine != data.Comment.Line {
+	if data.Line != 0 {
+		if data.StartLine != 0 && data.StartLine != data.Line {
 			// Multi-line comment
-			data.Comment.LineRange = fmt.Sprintf("L%d-%d", data.Comment.StartLine, data.Comment.Line)
+			data.LineRange = fmt.Sprintf("L%d-%d", data.StartLine, data.Line)
 		} else {
 			// Single line comment
-			data.Comment.LineRange = fmt.Sprintf("L%d", data.Comment.Line)
+			data.LineRange = fmt.Sprintf("L%d", data.Line)
 		}
 	}
 
-	if data.Comment.OriginalLine != 0 && data.Comment.OriginalLine != data.Comment.Line {
-		if data.Comment.OriginalStartLine != 0 && data.Comment.OriginalStartLine != data.Comment.OriginalLine {
+	if data.OriginalLine != 0 && data.OriginalLine != data.Line {
+		if data.OriginalStartLine != 0 && data.OriginalStartLine != data.OriginalLine {
 			// Multi-line original comment
-			data.Comment.OriginalLineRange = fmt.Sprintf("L%d-%d", data.Comment.OriginalStartLine, data.Comment.OriginalLine)
+			data.OriginalLineRange = fmt.Sprintf("L%d-%d", data.OriginalStartLine, data.OriginalLine)
 		} else {
 			// Single line original comment
-			data.Comment.OriginalLineRange = fmt.Sprintf("L%d", data.Comment.OriginalLine)
+			data.OriginalLineRange = fmt.Sprintf("L%d", data.OriginalLine)
 		}
 	}
 
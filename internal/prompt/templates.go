@@ -0,0 +1,204 @@
+package prompt
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/stefrushxyz/nitpick/internal/blame"
+	"github.com/stefrushxyz/nitpick/internal/codeowners"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/symbol"
+	"github.com/stefrushxyz/nitpick/internal/tickets"
+)
+
+// Names of the built-in templates
+const (
+	TemplateFull      = "full"
+	TemplateSimple    = "simple"
+	TemplateAggregate = "aggregate"
+)
+
+// templateExt is the file extension of user templates
+const templateExt = ".tmpl"
+
+// templateFuncs are the helper functions available to every template
+var templateFuncs = template.FuncMap{
+	"add":  func(a, b int) int { return a + b },
+	"join": strings.Join,
+}
+
+// SetTemplateDir sets the directory searched for user templates by name
+func (g *Generator) SetTemplateDir(dir string) {
+	g.templateDir = dir
+}
+
+// SetTickets sets the issue tracker tickets linked to the pull request of the next prompts; nil leaves them out
+func (g *Generator) SetTickets(linked []*tickets.Ticket) {
+	g.tickets = nil
+	for _, ticket := range linked {
+		g.tickets = append(g.tickets, &TicketData{
+			Tracker:     ticket.Tracker,
+			Key:         ticket.Key,
+			Summary:     ticket.Summary,
+			Type:        ticket.Type,
+			Status:      ticket.Status,
+			Description: ticket.Description,
+			URL:         ticket.URL,
+		})
+	}
+}
+
+// SetCommits sets the commits of the pull request of the next prompts, oldest first; nil leaves them out
+func (g *Generator) SetCommits(commits []*github.RepositoryCommit) {
+	g.commits = nil
+	for _, commit := range commits {
+		sha := commit.GetSHA()
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		author := commit.GetAuthor().GetLogin()
+		if author == "" {
+			author = commit.GetCommit().GetAuthor().GetName()
+		}
+		summary, _, _ := strings.Cut(commit.GetCommit().GetMessage(), "\n")
+		g.commits = append(g.commits, &CommitData{
+			SHA:     sha,
+			Author:  author,
+			Date:    commit.GetCommit().GetAuthor().GetDate().Format("2006-01-02"),
+			Summary: strings.TrimSpace(summary),
+		})
+	}
+}
+
+// SetIssues sets the issues the pull request of the next prompts closes, from the repository repo (as
+// owner/name); nil leaves them out
+func (g *Generator) SetIssues(repo string, issues []*github.Issue) {
+	g.issues = nil
+	for _, issue := range issues {
+		var labels []string
+		for _, label := range issue.Labels {
+			labels = append(labels, label.GetName())
+		}
+		g.issues = append(g.issues, &IssueData{
+			Ref:    ghclient.IssueLabel(issue, repo),
+			Title:  issue.GetTitle(),
+			State:  issue.GetState(),
+			Labels: labels,
+			Body:   strings.TrimSpace(issue.GetBody()),
+			URL:    issue.GetHTMLURL(),
+		})
+	}
+}
+
+// SetCodeowners sets the CODEOWNERS file naming the owners of commented files in the next prompts; nil
+// leaves them out
+func (g *Generator) SetCodeowners(f *codeowners.File) {
+	g.codeowners = f
+}
+
+// SetBlame sets the commits that last changed the lines of each comment, by comment ID, for the next
+// prompts; nil leaves them out
+func (g *Generator) SetBlame(blames map[int64][]blame.Range) {
+	g.blame = blames
+}
+
+// SetSymbols sets the declaration enclosing the lines of each comment, by comment ID, for the next
+// prompts; nil leaves them out
+func (g *Generator) SetSymbols(symbols map[int64]*symbol.Symbol) {
+	g.symbols = symbols
+}
+
+// SetContexts sets the lines of the file around the lines of each comment, by comment ID, for the next
+// prompts; nil leaves them out
+func (g *Generator) SetContexts(contexts map[int64]string) {
+	g.contexts = contexts
+}
+
+// Template resolves a template by built-in name, user template name, or path to a .tmpl file.
+// Single-comment templates receive TemplateData; templates used with GenerateAggregate receive AggregateTemplateData.
+func (g *Generator) Template(name string) (*template.Template, error) {
+	switch name {
+	case TemplateFull:
+		return g.fullTemplate, nil
+	case TemplateSimple:
+		return g.simpleTemplate, nil
+	case TemplateAggregate:
+		return g.aggregateTemplate, nil
+	}
+
+	// Anything that looks like a path is loaded directly
+	if strings.HasSuffix(name, templateExt) || strings.ContainsRune(name, filepath.Separator) {
+		return parseTemplateFile(name)
+	}
+
+	path := filepath.Join(g.templateDir, name+templateExt)
+	if _, err := os.Stat(path); g.templateDir == "" || err != nil {
+		return nil, fmt.Errorf("unknown template %q (built-in: %s, %s, %s; user templates are read from %s)",
+			name, TemplateFull, TemplateSimple, TemplateAggregate, g.templateDir)
+	}
+
+	return parseTemplateFile(path)
+}
+
+// Generate executes the given template for a single comment
+func (g *Generator) Generate(tmpl *template.Template, repo *github.Repository, pr *github.PullRequest, comment *github.PullRequestComment) (string, error) {
+	data := g.buildTemplateData(repo, pr, comment)
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute template %q: %w", tmpl.Name(), err)
+	}
+
+	return buf.String(), nil
+}
+
+// GenerateAggregate executes the given template for several comments on the same PR
+func (g *Generator) GenerateAggregate(tmpl *template.Template, repo *github.Repository, pr *github.PullRequest, comments []*github.PullRequestComment) (string, error) {
+	data := g.buildAggregateTemplateData(repo, pr, comments)
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute template %q: %w", tmpl.Name(), err)
+	}
+
+	return buf.String(), nil
+}
+
+// parseTemplateFile parses a user template from disk
+func parseTemplateFile(path string) (*template.Template, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+
+	return ParseTemplate(filepath.Base(path), string(content))
+}
+
+// ParseTemplate parses an ad-hoc template with the helper functions available to built-in templates
+func ParseTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+
+	return tmpl, nil
+}
+
+// buildAggregateTemplateData converts GitHub API structs to template-friendly data for several comments
+func (g *Generator) buildAggregateTemplateData(repo *github.Repository, pr *github.PullRequest, comments []*github.PullRequestComment) *AggregateTemplateData {
+	data := &AggregateTemplateData{
+		Repository:  g.buildRepositoryData(repo),
+		PullRequest: g.buildPullRequestData(pr),
+		Generated:   time.Now().Format("2006-01-02 15:04:05"),
+	}
+	for _, comment := range comments {
+		data.Comments = append(data.Comments, g.buildCommentData(comment))
+	}
+	return data
+}
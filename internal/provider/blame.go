@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v57/github"
+	"github.com/stefrushxyz/nitpick/internal/blame"
+	"github.com/stefrushxyz/nitpick/internal/gitrepo"
+	"github.com/stefrushxyz/nitpick/internal/prefetch"
+)
+
+// BlameMsg is a message containing the commits that last changed the lines of a review comment
+type BlameMsg struct {
+	CommentID int64
+	Ranges    []blame.Range
+	Err       error
+}
+
+// blamer is implemented by providers that blame repository files
+type blamer interface {
+	Blame(ctx context.Context, owner, name, ref, path string) ([]blame.Range, error)
+}
+
+// CommentedLines returns the commit and lines a review comment was made on: the commit the comment was
+// originally made on for comments on new code, or the change's base for comments on removed lines. ok is
+// false for comments not on lines of a file.
+func CommentedLines(pr *github.PullRequest, comment *github.PullRequestComment) (commit string, start, end int, ok bool) {
+	commit, start, end = comment.GetOriginalCommitID(), comment.GetOriginalStartLine(), comment.GetOriginalLine()
+	if end == 0 {
+		commit, start, end = comment.GetCommitID(), comment.GetStartLine(), comment.GetLine()
+	}
+	if comment.GetSide() == "LEFT" {
+		commit = pr.GetBase().GetSHA()
+	}
+	if start == 0 || start > end {
+		start = end
+	}
+	if comment.GetPath() == "" || end == 0 || commit == "" {
+		return "", 0, 0, false
+	}
+	return commit, start, end, true
+}
+
+// FindBlame returns the commits that last changed the lines of a review comment: from the local checkout
+// when it is given and has the commit, and otherwise through the API where the provider supports it. It
+// returns nil without an error when neither can blame the comment.
+func FindBlame(ctx context.Context, p Provider, owner, name string, pr *github.PullRequest, comment *github.PullRequestComment, checkout *gitrepo.Checkout) ([]blame.Range, error) {
+	commit, start, end, ok := CommentedLines(pr, comment)
+	if !ok {
+		return nil, nil
+	}
+
+	if checkout != nil && checkout.HasCommit(commit) {
+		return checkout.Blame(commit, comment.GetPath(), start, end)
+	}
+
+	b, ok := p.(blamer)
+	if !ok {
+		return nil, nil
+	}
+	ranges, err := b.Blame(ctx, owner, name, commit, comment.GetPath())
+	if err != nil {
+		return nil, err
+	}
+	return blame.Clip(ranges, start, end), nil
+}
+
+// BlameJob returns a job fetching the commits that last changed the lines of a review comment, from the
+// local checkout when it is given and has the comment's commit
+func (s *Source) BlameJob(repo *github.Repository, pr *github.PullRequest, comment *github.PullRequestComment, checkout *gitrepo.Checkout) prefetch.Job {
+	return func(parent context.Context) tea.Msg {
+		ctx, cancel := s.within(parent)
+		defer cancel()
+
+		ranges, err := FindBlame(ctx, s.provider, repo.GetOwner().GetLogin(), repo.GetName(), pr, comment, checkout)
+		return BlameMsg{CommentID: comment.GetID(), Ranges: ranges, Err: err}
+	}
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v57/github"
+	"github.com/stefrushxyz/nitpick/internal/diff"
+	"github.com/stefrushxyz/nitpick/internal/gitrepo"
+	"github.com/stefrushxyz/nitpick/internal/prefetch"
+)
+
+// ContextMsg is a message containing the lines of the commented file around the lines of a review comment
+type ContextMsg struct {
+	CommentID int64
+	Context   string
+	Err       error
+}
+
+// FindContext returns the lines of the commented file around the lines of a review comment, n above and n
+// below, as an unchanged hunk of the file as of the comment's commit. The file is read from the local
+// checkout when it is given and has the commit, and otherwise through the API where the provider supports
+// it. It returns an empty string without an error when the file cannot be fetched.
+func FindContext(ctx context.Context, p Provider, owner, name string, pr *github.PullRequest, comment *github.PullRequestComment, checkout *gitrepo.Checkout, n int) (string, error) {
+	commit, start, end, ok := CommentedLines(pr, comment)
+	if !ok {
+		return "", nil
+	}
+
+	content, err := FindFile(ctx, p, owner, name, comment.GetPath(), commit, checkout)
+	if err != nil || content == nil {
+		return "", err
+	}
+	return diff.Around(string(content), start, end, n), nil
+}
+
+// ContextJob returns a job fetching the n lines of the commented file above and below the lines of a review
+// comment, reading the file from the local checkout when it is given and has the comment's commit
+func (s *Source) ContextJob(repo *github.Repository, pr *github.PullRequest, comment *github.PullRequestComment, checkout *gitrepo.Checkout, n int) prefetch.Job {
+	return func(parent context.Context) tea.Msg {
+		ctx, cancel := s.within(parent)
+		defer cancel()
+
+		lines, err := FindContext(ctx, s.provider, repo.GetOwner().GetLogin(), repo.GetName(), pr, comment, checkout, n)
+		return ContextMsg{CommentID: comment.GetID(), Context: lines, Err: err}
+	}
+}
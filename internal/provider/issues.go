@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v57/github"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/prefetch"
+)
+
+// LinkedIssuesMsg is a message containing the issues a pull request closes
+type LinkedIssuesMsg struct {
+	Repo   string
+	PR     int
+	Issues []*github.Issue
+	Err    error
+}
+
+// issueGetter is implemented by providers that fetch issues
+type issueGetter interface {
+	GetIssue(ctx context.Context, owner, name string, number int) (*github.Issue, error)
+}
+
+// FindLinkedIssues fetches the issues a pull request's description closes with keywords such as "Fixes #12",
+// or returns none where the provider does not fetch issues. Issues that could be fetched are returned along
+// with the errors of the others.
+func FindLinkedIssues(ctx context.Context, p Provider, owner, name string, pr *github.PullRequest) ([]*github.Issue, error) {
+	refs := ghclient.ClosingRefs(pr.GetBody(), owner, name)
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	getter, ok := p.(issueGetter)
+	if !ok {
+		return nil, nil
+	}
+
+	var issues []*github.Issue
+	var errs []error
+	for _, ref := range refs {
+		issue, err := getter.GetIssue(ctx, ref.Owner, ref.Repo, ref.Number)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s/%s#%d: %w", ref.Owner, ref.Repo, ref.Number, err))
+			continue
+		}
+		issues = append(issues, issue)
+	}
+	return issues, errors.Join(errs...)
+}
+
+// LinkedIssuesJob returns a job fetching the issues a pull request closes
+func (s *Source) LinkedIssuesJob(repo *github.Repository, pr *github.PullRequest) prefetch.Job {
+	return func(parent context.Context) tea.Msg {
+		ctx, cancel := s.within(parent)
+		defer cancel()
+
+		issues, err := FindLinkedIssues(ctx, s.provider, repo.GetOwner().GetLogin(), repo.GetName(), pr)
+		return LinkedIssuesMsg{Repo: repo.GetFullName(), PR: pr.GetNumber(), Issues: issues, Err: err}
+	}
+}
@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/stefrushxyz/nitpick/internal/azuredevops"
+	"github.com/stefrushxyz/nitpick/internal/bitbucket"
+	"github.com/stefrushxyz/nitpick/internal/codeowners"
+	"github.com/stefrushxyz/nitpick/internal/config"
+	"github.com/stefrushxyz/nitpick/internal/gerrit"
+	"github.com/stefrushxyz/nitpick/internal/gitea"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/gitlab"
+	"github.com/stefrushxyz/nitpick/internal/rest"
+)
+
+// Provider is a code review service. It lists repositories, their changes (pull or merge requests) and
+// the review comments on them, and replies to and resolves comment threads. Providers present their data
+// as GitHub types, so the TUI, the headless commands and the prompt generator work with any of them.
+type Provider interface {
+	// Timeout returns the timeout for the requests of a single fetch
+	Timeout() time.Duration
+
+	// ListRepos lists the repositories accessible to the user
+	ListRepos(ctx context.Context, opts ghclient.ListOptions) ([]*github.Repository, error)
+	// GetRepo fetches a single repository by owner and name
+	GetRepo(ctx context.Context, owner, name string) (*github.Repository, error)
+
+	// ListChanges lists the changes of a repository, highest number first
+	ListChanges(ctx context.Context, owner, name string, opts ghclient.PRListOptions) ([]*github.PullRequest, error)
+	// GetChange fetches a single change by number
+	GetChange(ctx context.Context, owner, name string, number int) (*github.PullRequest, error)
+
+	// ListComments lists the review comments of a change, most recently updated first
+	ListComments(ctx context.Context, owner, name string, number int, opts ghclient.ListOptions) ([]*github.PullRequestComment, error)
+	// ListThreads lists the comment threads of a change with their resolution state
+	ListThreads(ctx context.Context, owner, name string, number int) ([]ghclient.ReviewThread, error)
+
+	// Reply posts a reply in the thread of a review comment
+	Reply(ctx context.Context, owner, name string, number int, commentID int64, body string) (*github.PullRequestComment, error)
+	// Resolve marks a comment thread as resolved, or as unresolved if resolved is false
+	Resolve(ctx context.Context, owner, name string, number int, threadID string, resolved bool) error
+}
+
+// New creates the provider selected by the configuration
+func New(cfg *config.Config) (Provider, error) {
+	if cfg.Provider == config.ProviderGitHub || cfg.Provider == "" {
+		return ghclient.New(ghclient.OptionsFromConfig(cfg))
+	}
+
+	opts := rest.Options{
+		Token:    cfg.Token,
+		Host:     cfg.Host,
+		BaseURL:  cfg.BaseURL,
+		PageSize: cfg.PageSize,
+		Timeout:  cfg.Timeout,
+		Retry:    ghclient.RetryPolicy(cfg),
+	}
+	switch cfg.Provider {
+	case config.ProviderGitLab:
+		return gitlab.New(opts)
+	case config.ProviderBitbucket:
+		return bitbucket.New(opts)
+	case config.ProviderGitea:
+		return gitea.New(opts)
+	case config.ProviderAzureDevOps:
+		return azuredevops.New(opts)
+	case config.ProviderGerrit:
+		return gerrit.New(opts)
+	default:
+		return nil, fmt.Errorf("unknown provider %q", cfg.Provider)
+	}
+}
+
+// commentGetter is implemented by providers that fetch single review comments by ID
+type commentGetter interface {
+	GetComment(ctx context.Context, owner, name string, id int64) (*github.PullRequestComment, error)
+}
+
+// commentsSinceLister is implemented by providers that list only the review comments of a change
+// updated since a time
+type commentsSinceLister interface {
+	ListCommentsSince(ctx context.Context, owner, name string, number int, since time.Time, opts ghclient.ListOptions) ([]*github.PullRequestComment, error)
+}
+
+// conversationLister is implemented by providers that list the conversation comments of a change, posted
+// on the change as a whole, apart from its review comments
+type conversationLister interface {
+	ListConversation(ctx context.Context, owner, name string, number int, since time.Time, opts ghclient.ListOptions) ([]*github.PullRequestComment, error)
+}
+
+// reviewBodyLister is implemented by providers that list the summary bodies of the reviews of a change
+type reviewBodyLister interface {
+	ListReviewBodies(ctx context.Context, owner, name string, number int, since time.Time, opts ghclient.ListOptions) ([]*github.PullRequestComment, error)
+}
+
+// fileGetter is implemented by providers that fetch the content of repository files
+type fileGetter interface {
+	GetFile(ctx context.Context, owner, name, path, ref string) ([]byte, error)
+}
+
+// fileLister is implemented by providers that list the files changed by a change, with their patches
+type fileLister interface {
+	ListFiles(ctx context.Context, owner, name string, number int, opts ghclient.ListOptions) ([]*github.CommitFile, error)
+}
+
+// commitLister is implemented by providers that list the commits of a change
+type commitLister interface {
+	ListCommits(ctx context.Context, owner, name string, number int, opts ghclient.ListOptions) ([]*github.RepositoryCommit, error)
+}
+
+// prSearcher is implemented by providers that search pull requests across repositories
+type prSearcher interface {
+	SearchPRs(ctx context.Context, query string, opts ghclient.ListOptions) ([]*github.PullRequest, error)
+}
+
+// repoSearcher is implemented by providers that search repositories by name
+type repoSearcher interface {
+	SearchRepos(ctx context.Context, fragment string, opts ghclient.ListOptions) ([]*github.Repository, error)
+}
+
+// ciStatusGetter is implemented by providers that report the CI status of commits
+type ciStatusGetter interface {
+	GetCIStatus(ctx context.Context, owner, name, ref string) (*ghclient.CIStatus, error)
+}
+
+// viewerGetter is implemented by providers that fetch the user they are authenticated as
+type viewerGetter interface {
+	CurrentUser(ctx context.Context) (*github.User, error)
+}
+
+// commentCreator is implemented by providers that start review comment threads on lines of changed files
+type commentCreator interface {
+	CreateComment(ctx context.Context, owner, name string, number int, draft *github.PullRequestComment) (*github.PullRequestComment, error)
+}
+
+// reviewSubmitter is implemented by providers that submit reviews of changes with a verdict
+type reviewSubmitter interface {
+	SubmitReview(ctx context.Context, owner, name string, number int, event, body string) (*github.PullRequestComment, error)
+}
+
+// commentEditor is implemented by providers that edit and delete comments
+type commentEditor interface {
+	EditComment(ctx context.Context, owner, name string, number int, comment *github.PullRequestComment, body string) (*github.PullRequestComment, error)
+	DeleteComment(ctx context.Context, owner, name string, comment *github.PullRequestComment) error
+}
+
+// reactor is implemented by providers that add reactions to comments
+type reactor interface {
+	React(ctx context.Context, owner, name string, comment *github.PullRequestComment, content string) (bool, error)
+}
+
+// FindCodeowners loads the CODEOWNERS file of a repository: from the local checkout at root when it is
+// given and has one, and otherwise at ref through the API where the provider supports it. It returns nil
+// without an error when the repository has none.
+func FindCodeowners(ctx context.Context, p Provider, owner, name, ref, root string) (*codeowners.File, error) {
+	if root != "" {
+		if file, err := codeowners.Load(root); err != nil || file != nil {
+			return file, err
+		}
+	}
+
+	getter, ok := p.(fileGetter)
+	if !ok {
+		return nil, nil
+	}
+	for _, location := range codeowners.Locations {
+		content, err := getter.GetFile(ctx, owner, name, location, ref)
+		if err != nil {
+			return nil, err
+		}
+		if content != nil {
+			return codeowners.Parse(content), nil
+		}
+	}
+	return nil, nil
+}
+
+// FindComment fetches a review comment of a change by ID, directly where the provider supports it and
+// otherwise from the change's comments
+func FindComment(ctx context.Context, p Provider, owner, name string, number int, id int64) (*github.PullRequestComment, error) {
+	if getter, ok := p.(commentGetter); ok {
+		return getter.GetComment(ctx, owner, name, id)
+	}
+
+	comments, err := p.ListComments(ctx, owner, name, number, ghclient.ListOptions{AllPages: true})
+	if err != nil {
+		return nil, err
+	}
+	for _, comment := range comments {
+		if comment.GetID() == id {
+			return comment, nil
+		}
+	}
+	return nil, fmt.Errorf("comment %d not found on %s/%s#%d", id, owner, name, number)
+}
+
+// FindThread finds the comment thread of a change that contains the given comment
+func FindThread(ctx context.Context, p Provider, owner, name string, number int, commentID int64) (*ghclient.ReviewThread, error) {
+	threads, err := p.ListThreads(ctx, owner, name, number)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, thread := range threads {
+		for _, id := range thread.CommentIDs {
+			if id == commentID {
+				return &threads[i], nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w for comment %d", ghclient.ErrThreadNotFound, commentID)
+}
+
+// FindCheckoutChange finds the open change of a repository whose source branch is branch or whose head
+// commit is commit, as for a local checkout of it; it returns nil if there is none
+func FindCheckoutChange(ctx context.Context, p Provider, owner, name, branch, commit string) (*github.PullRequest, error) {
+	changes, err := p.ListChanges(ctx, owner, name, ghclient.PRListOptions{
+		State:       "open",
+		ListOptions: ghclient.ListOptions{AllPages: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, change := range changes {
+		head := change.GetHead()
+		if (branch != "" && head.GetRef() == branch) || (commit != "" && head.GetSHA() == commit) {
+			return change, nil
+		}
+	}
+	return nil, nil
+}
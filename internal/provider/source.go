@@ -0,0 +1,844 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v57/github"
+	"github.com/stefrushxyz/nitpick/internal/cache"
+	"github.com/stefrushxyz/nitpick/internal/codeowners"
+	"github.com/stefrushxyz/nitpick/internal/config"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/prefetch"
+)
+
+// Messages for async operations
+type ReposMsg struct {
+	Repos []*github.Repository
+	Err   error
+	More  tea.Cmd // Set on partial results of a fetch spanning pages: yields its next message
+}
+
+// PRsMsg is a message containing pull requests
+type PRsMsg struct {
+	Repo  string // Repository the pull requests belong to, as owner/name
+	State string // State the pull requests were listed with
+	PRs   []*github.PullRequest
+	Err   error
+	More  tea.Cmd // Set on partial results of a fetch spanning pages: yields its next message
+}
+
+// RepoSearchMsg is a message containing the repositories matching a name fragment
+type RepoSearchMsg struct {
+	Query string
+	Repos []*github.Repository
+	Err   error
+}
+
+// RepoMsg is a message containing a single repository
+type RepoMsg struct {
+	Repo *github.Repository
+	Err  error
+}
+
+// PRMsg is a message containing a single pull request
+type PRMsg struct {
+	PR  *github.PullRequest
+	Err error
+}
+
+// CheckoutPRMsg is a message containing the open pull request of the local git checkout, if any
+type CheckoutPRMsg struct {
+	Repo *github.Repository
+	PR   *github.PullRequest
+	Err  error
+}
+
+// CommentsMsg is a message containing pull request comments
+type CommentsMsg struct {
+	Repo      string
+	PR        int
+	Comments  []*github.PullRequestComment
+	FetchedAt time.Time // When the comments were fetched from the provider; zero when read from the cache
+	Err       error
+	More      tea.Cmd // Set on partial results of a fetch spanning pages: yields its next message
+}
+
+// CommentsPrefetchedMsg is a message reporting that the review comments of a pull request were fetched
+// into the cache ahead of opening it
+type CommentsPrefetchedMsg struct {
+	Repo string
+	PR   int
+	Err  error
+}
+
+// ReviewThreadsMsg is a message containing the review threads of a pull request
+type ReviewThreadsMsg struct {
+	Repo    string
+	PR      int
+	Threads []ghclient.ReviewThread
+	Err     error
+}
+
+// CIStatusMsg is a message containing the CI status of the head commit of a pull request, nil when the
+// provider does not report one
+type CIStatusMsg struct {
+	Repo   string
+	PR     int
+	Status *ghclient.CIStatus
+	Err    error
+}
+
+// FilesMsg is a message containing the files changed by a pull request
+type FilesMsg struct {
+	Repo  string
+	PR    int
+	Files []*github.CommitFile
+	Err   error
+}
+
+// CommitsMsg is a message containing the commits of a pull request
+type CommitsMsg struct {
+	Repo    string
+	PR      int
+	Commits []*github.RepositoryCommit
+	Err     error
+}
+
+// ResolveMsg reports a comment thread of a pull request marked as resolved, or as unresolved if Resolved
+// is false
+type ResolveMsg struct {
+	Repo     string
+	PR       int
+	ThreadID string
+	Resolved bool
+	Err      error
+}
+
+// ViewerMsg is a message containing the login of the user the provider is authenticated as, empty when
+// the provider cannot tell
+type ViewerMsg struct {
+	Login string
+	Err   error
+}
+
+// CommentMsg is a message containing a review comment starting a new thread
+type CommentMsg struct {
+	Repo    string
+	PR      int
+	Comment *github.PullRequestComment
+	Err     error
+}
+
+// ReviewMsg is a message containing a review submitted with a verdict, as a review body
+type ReviewMsg struct {
+	Repo   string
+	PR     int
+	Event  string
+	Review *github.PullRequestComment
+	Err    error
+}
+
+// EditMsg is a message containing a comment with its body edited
+type EditMsg struct {
+	Comment *github.PullRequestComment
+	Edited  *github.PullRequestComment
+	Err     error
+}
+
+// DeleteMsg reports a comment deleted
+type DeleteMsg struct {
+	Comment *github.PullRequestComment
+	Err     error
+}
+
+// ReplyMsg is a message containing a reply posted in the thread of a review comment
+type ReplyMsg struct {
+	Comment *github.PullRequestComment
+	Reply   *github.PullRequestComment
+	Err     error
+}
+
+// ReactMsg reports the outcome of adding a reaction to a comment; Added is false when the user had already
+// reacted with it
+type ReactMsg struct {
+	Comment *github.PullRequestComment
+	Content string
+	Added   bool
+	Err     error
+}
+
+// CodeownersMsg is a message containing the CODEOWNERS file of a repository, nil when it has none
+type CodeownersMsg struct {
+	Repo   string
+	Ref    string
+	Owners *codeowners.File
+	Err    error
+}
+
+// Limits holds how much of each list the TUI fetches; the zero value fetches the first page
+type Limits struct {
+	Repos    ghclient.ListOptions
+	PRs      ghclient.ListOptions
+	Comments ghclient.ListOptions
+}
+
+// CacheTTLs holds how long cached responses of each kind are reused; 0 disables caching
+type CacheTTLs struct {
+	Repos    time.Duration
+	PRs      time.Duration
+	Comments time.Duration
+}
+
+// Source runs a provider's fetches for the TUI as bubbletea commands, within the configured limits and
+// through the API response cache
+type Source struct {
+	provider Provider
+	limits   Limits
+	cache    *cache.Cache // nil disables caching
+	cacheTTL CacheTTLs
+	scope    string // Prefix of cache keys, distinguishing providers, hosts and tokens
+
+	conversation bool // Whether conversation comments are listed with the review comments
+}
+
+// NewSource creates the source of the provider selected by the configuration
+func NewSource(cfg *config.Config) (*Source, error) {
+	p, err := New(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var c *cache.Cache
+	if dir := cfg.APICacheDir(); dir != "" {
+		c = cache.New(dir)
+	}
+
+	return &Source{
+		provider: p,
+		limits: Limits{
+			Repos:    ghclient.ListOptionsFromLimits(cfg.Limits.Repos),
+			PRs:      ghclient.ListOptionsFromLimits(cfg.Limits.PRs),
+			Comments: ghclient.ListOptionsFromLimits(cfg.Limits.Comments),
+		},
+		cache: c,
+		cacheTTL: CacheTTLs{
+			Repos:    cfg.CacheTTL.Repos,
+			PRs:      cfg.CacheTTL.PRs,
+			Comments: cfg.CacheTTL.Comments,
+		},
+		scope:        cacheScope(cfg),
+		conversation: cfg.Conversation,
+	}, nil
+}
+
+// Provider returns the provider the source fetches from
+func (s *Source) Provider() Provider {
+	return s.provider
+}
+
+// cacheScope derives the cache key prefix of a configuration's provider, host and token, without storing
+// the token itself
+func cacheScope(cfg *config.Config) string {
+	sum := sha256.Sum256([]byte(cfg.Token))
+	return cfg.Provider + "|" + cfg.Host + "|" + cfg.BaseURL + "|" + hex.EncodeToString(sum[:8])
+}
+
+// cacheKey builds the cache key of a fetch from its kind and parameters
+func (s *Source) cacheKey(kind string, params ...any) string {
+	return fmt.Sprintf("%s|%s|%v", s.scope, kind, params)
+}
+
+// storeCached stores a fetched value unless caching is disabled for it. Failures only cost a refetch.
+func (s *Source) storeCached(key string, ttl time.Duration, v any) {
+	if ttl > 0 {
+		_ = s.cache.Put(key, v)
+	}
+}
+
+// withTimeout returns a context bounded by the provider's timeout
+func (s *Source) withTimeout() (context.Context, context.CancelFunc) {
+	return s.within(context.Background())
+}
+
+// within returns a context bounded by the provider's timeout and cancelled with parent
+func (s *Source) within(parent context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, s.provider.Timeout())
+}
+
+// stream returns a command running fetch and yielding its messages: when opts may span pages, a partial
+// message per page, sent by fetch with more as its More, then the complete one returned by fetch. A message
+// not received yet is superseded by the next, so a busy UI skips intermediate pages.
+func stream(opts ghclient.ListOptions, fetch func(more tea.Cmd, partial func(tea.Msg)) tea.Msg) tea.Cmd {
+	if !opts.AllPages {
+		return func() tea.Msg {
+			return fetch(nil, func(tea.Msg) {})
+		}
+	}
+
+	// Only fetch sends, so after draining a pending message the send never blocks
+	msgs := make(chan tea.Msg, 1)
+	send := func(msg tea.Msg) {
+		select {
+		case <-msgs:
+		default:
+		}
+		msgs <- msg
+	}
+	more := func() tea.Msg {
+		return <-msgs
+	}
+
+	return func() tea.Msg {
+		go func() {
+			send(fetch(more, send))
+		}()
+		return more()
+	}
+}
+
+// FetchRepos fetches the user's repositories within the configured limits, yielding them page by page
+// when the limits span pages
+func (s *Source) FetchRepos() tea.Cmd {
+	return stream(s.limits.Repos, func(more tea.Cmd, partial func(tea.Msg)) tea.Msg {
+		key := s.cacheKey("repos")
+		var repos []*github.Repository
+		if s.cache.Get(key, s.cacheTTL.Repos, &repos) {
+			return ReposMsg{Repos: repos}
+		}
+
+		ctx, cancel := s.withTimeout()
+		defer cancel()
+
+		var fetched []*github.Repository
+		ctx = ghclient.WithPageHook(ctx, func(page []*github.Repository) {
+			fetched = append(fetched, page...)
+			partial(ReposMsg{Repos: DedupRepos(fetched), More: more})
+		})
+
+		start := time.Now()
+		repos, err := s.provider.ListRepos(ctx, s.limits.Repos)
+		if err != nil {
+			return ReposMsg{Err: err}
+		}
+		// Repositories of the user's organizations are listed both as the user's and as the organization's
+		repos = DedupRepos(repos)
+		slog.Debug("fetched repositories", "count", len(repos), "duration", time.Since(start))
+		s.storeCached(key, s.cacheTTL.Repos, repos)
+
+		return ReposMsg{Repos: repos}
+	})
+}
+
+// DedupRepos returns the repositories listed more than once by full name only once, at their first place,
+// keeping the most recently updated of their entries
+func DedupRepos(repos []*github.Repository) []*github.Repository {
+	index := make(map[string]int, len(repos))
+	deduped := make([]*github.Repository, 0, len(repos))
+	for _, repo := range repos {
+		name := strings.ToLower(repo.GetFullName())
+		i, seen := index[name]
+		if !seen {
+			index[name] = len(deduped)
+			deduped = append(deduped, repo)
+			continue
+		}
+		if repo.GetUpdatedAt().After(deduped[i].GetUpdatedAt().Time) {
+			deduped[i] = repo
+		}
+	}
+	return deduped
+}
+
+// SearchRepos searches the repositories whose names match a fragment such as "back" or "acme/back", for
+// repositories beyond those listed. It returns nil for providers without repository search.
+func (s *Source) SearchRepos(query string) tea.Cmd {
+	searcher, ok := s.provider.(repoSearcher)
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx, cancel := s.withTimeout()
+		defer cancel()
+
+		repos, err := searcher.SearchRepos(ctx, query, ghclient.ListOptions{})
+		return RepoSearchMsg{Query: query, Repos: repos, Err: err}
+	}
+}
+
+// FetchRepo fetches a single repository by owner and name
+func (s *Source) FetchRepo(owner, name string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := s.withTimeout()
+		defer cancel()
+
+		repo, err := s.provider.GetRepo(ctx, owner, name)
+		if err != nil {
+			return RepoMsg{Err: err}
+		}
+
+		return RepoMsg{Repo: repo}
+	}
+}
+
+// FetchPRs fetches the changes of the given repository, within the configured limits unless opts sets its
+// own, yielding them page by page when the limits span pages
+func (s *Source) FetchPRs(repo *github.Repository, opts ghclient.PRListOptions) tea.Cmd {
+	opts = s.prListOptions(opts)
+
+	return stream(opts.ListOptions, func(more tea.Cmd, partial func(tea.Msg)) tea.Msg {
+		if repo == nil {
+			return PRsMsg{State: opts.State, Err: fmt.Errorf("no repository provided")}
+		}
+
+		msg := PRsMsg{Repo: repo.GetFullName(), State: opts.State}
+		key := s.cacheKey("prs", repo.GetFullName(), opts)
+		var prs []*github.PullRequest
+		if s.cache.Get(key, s.cacheTTL.PRs, &prs) {
+			msg.PRs = prs
+			return msg
+		}
+
+		ctx, cancel := s.withTimeout()
+		defer cancel()
+
+		var fetched []*github.PullRequest
+		ctx = ghclient.WithPageHook(ctx, func(page []*github.PullRequest) {
+			fetched = append(fetched, page...)
+			pageMsg := msg
+			pageMsg.PRs, pageMsg.More = slices.Clone(fetched), more
+			partial(pageMsg)
+		})
+
+		start := time.Now()
+		prs, err := s.provider.ListChanges(ctx, repo.GetOwner().GetLogin(), repo.GetName(), opts)
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+		slog.Debug("fetched pull requests", "repo", repo.GetFullName(), "count", len(prs), "duration", time.Since(start))
+		s.storeCached(key, s.cacheTTL.PRs, prs)
+
+		msg.PRs = prs
+		return msg
+	})
+}
+
+// prListOptions fills in the configured limits of pull request lists unless opts sets its own
+func (s *Source) prListOptions(opts ghclient.PRListOptions) ghclient.PRListOptions {
+	if opts.ListOptions == (ghclient.ListOptions{}) {
+		opts.ListOptions = s.limits.PRs
+	}
+	return opts
+}
+
+// ForgetRepos drops the cached repositories, so the next FetchRepos fetches them from the provider
+func (s *Source) ForgetRepos() {
+	if err := s.cache.Delete(s.cacheKey("repos")); err != nil {
+		slog.Warn("failed to drop cached repositories", "err", err)
+	}
+}
+
+// ForgetPRs drops the cached changes of a repository listed with opts, so the next FetchPRs with them
+// fetches them from the provider
+func (s *Source) ForgetPRs(repo *github.Repository, opts ghclient.PRListOptions) {
+	if err := s.cache.Delete(s.cacheKey("prs", repo.GetFullName(), s.prListOptions(opts))); err != nil {
+		slog.Warn("failed to drop cached pull requests", "repo", repo.GetFullName(), "err", err)
+	}
+}
+
+// FetchPR fetches a single change by number
+func (s *Source) FetchPR(repo *github.Repository, number int) tea.Cmd {
+	return func() tea.Msg {
+		if repo == nil {
+			return PRMsg{Err: fmt.Errorf("no repository provided")}
+		}
+
+		ctx, cancel := s.withTimeout()
+		defer cancel()
+
+		pr, err := s.provider.GetChange(ctx, repo.GetOwner().GetLogin(), repo.GetName(), number)
+		if err != nil {
+			return PRMsg{Err: err}
+		}
+
+		return PRMsg{PR: pr}
+	}
+}
+
+// FetchFiles fetches the files changed by the given change with their patches, where the provider lists them
+func (s *Source) FetchFiles(repo *github.Repository, pr *github.PullRequest) tea.Cmd {
+	return func() tea.Msg {
+		lister, ok := s.provider.(fileLister)
+		if !ok {
+			return FilesMsg{Repo: repo.GetFullName(), PR: pr.GetNumber(), Err: fmt.Errorf("listing changed files: %w", ghclient.ErrUnsupported)}
+		}
+
+		ctx, cancel := s.withTimeout()
+		defer cancel()
+
+		files, err := lister.ListFiles(ctx, repo.GetOwner().GetLogin(), repo.GetName(), pr.GetNumber(), ghclient.ListOptions{AllPages: true})
+		return FilesMsg{Repo: repo.GetFullName(), PR: pr.GetNumber(), Files: files, Err: err}
+	}
+}
+
+// FetchCommits fetches the commits of the given change, oldest first, where the provider lists them
+func (s *Source) FetchCommits(repo *github.Repository, pr *github.PullRequest) tea.Cmd {
+	return func() tea.Msg {
+		lister, ok := s.provider.(commitLister)
+		if !ok {
+			return CommitsMsg{Repo: repo.GetFullName(), PR: pr.GetNumber(), Err: fmt.Errorf("listing commits: %w", ghclient.ErrUnsupported)}
+		}
+
+		ctx, cancel := s.withTimeout()
+		defer cancel()
+
+		commits, err := lister.ListCommits(ctx, repo.GetOwner().GetLogin(), repo.GetName(), pr.GetNumber(), ghclient.ListOptions{AllPages: true})
+		return CommitsMsg{Repo: repo.GetFullName(), PR: pr.GetNumber(), Commits: commits, Err: err}
+	}
+}
+
+// Resolve marks a comment thread of a change as resolved, or as unresolved if resolved is false
+func (s *Source) Resolve(repo *github.Repository, pr *github.PullRequest, threadID string, resolved bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := s.withTimeout()
+		defer cancel()
+
+		err := s.provider.Resolve(ctx, repo.GetOwner().GetLogin(), repo.GetName(), pr.GetNumber(), threadID, resolved)
+		return ResolveMsg{Repo: repo.GetFullName(), PR: pr.GetNumber(), ThreadID: threadID, Resolved: resolved, Err: err}
+	}
+}
+
+// Reply posts a reply in the thread of a review comment
+func (s *Source) Reply(repo *github.Repository, pr *github.PullRequest, comment *github.PullRequestComment, body string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := s.withTimeout()
+		defer cancel()
+
+		reply, err := s.provider.Reply(ctx, repo.GetOwner().GetLogin(), repo.GetName(), pr.GetNumber(), comment.GetID(), body)
+		return ReplyMsg{Comment: comment, Reply: reply, Err: err}
+	}
+}
+
+// FetchViewer fetches the login of the user the provider is authenticated as, where it tells
+func (s *Source) FetchViewer() tea.Cmd {
+	return func() tea.Msg {
+		getter, ok := s.provider.(viewerGetter)
+		if !ok {
+			return ViewerMsg{}
+		}
+
+		ctx, cancel := s.withTimeout()
+		defer cancel()
+
+		user, err := getter.CurrentUser(ctx)
+		return ViewerMsg{Login: user.GetLogin(), Err: err}
+	}
+}
+
+// CreateComment starts a new thread with a review comment on a line of a changed file, as set by the draft
+func (s *Source) CreateComment(repo *github.Repository, pr *github.PullRequest, draft *github.PullRequestComment) tea.Cmd {
+	return func() tea.Msg {
+		creator, ok := s.provider.(commentCreator)
+		if !ok {
+			return CommentMsg{Repo: repo.GetFullName(), PR: pr.GetNumber(), Err: fmt.Errorf("commenting on lines: %w", ghclient.ErrUnsupported)}
+		}
+
+		ctx, cancel := s.withTimeout()
+		defer cancel()
+
+		comment, err := creator.CreateComment(ctx, repo.GetOwner().GetLogin(), repo.GetName(), pr.GetNumber(), draft)
+		return CommentMsg{Repo: repo.GetFullName(), PR: pr.GetNumber(), Comment: comment, Err: err}
+	}
+}
+
+// SubmitReview submits a review of a change with a verdict, one of the review events, and a summary body
+func (s *Source) SubmitReview(repo *github.Repository, pr *github.PullRequest, event, body string) tea.Cmd {
+	return func() tea.Msg {
+		submitter, ok := s.provider.(reviewSubmitter)
+		if !ok {
+			return ReviewMsg{Repo: repo.GetFullName(), PR: pr.GetNumber(), Event: event, Err: fmt.Errorf("submitting reviews: %w", ghclient.ErrUnsupported)}
+		}
+
+		ctx, cancel := s.withTimeout()
+		defer cancel()
+
+		review, err := submitter.SubmitReview(ctx, repo.GetOwner().GetLogin(), repo.GetName(), pr.GetNumber(), event, body)
+		return ReviewMsg{Repo: repo.GetFullName(), PR: pr.GetNumber(), Event: event, Review: review, Err: err}
+	}
+}
+
+// EditComment replaces the body of a comment
+func (s *Source) EditComment(repo *github.Repository, pr *github.PullRequest, comment *github.PullRequestComment, body string) tea.Cmd {
+	return func() tea.Msg {
+		editor, ok := s.provider.(commentEditor)
+		if !ok {
+			return EditMsg{Comment: comment, Err: fmt.Errorf("editing comments: %w", ghclient.ErrUnsupported)}
+		}
+
+		ctx, cancel := s.withTimeout()
+		defer cancel()
+
+		edited, err := editor.EditComment(ctx, repo.GetOwner().GetLogin(), repo.GetName(), pr.GetNumber(), comment, body)
+		return EditMsg{Comment: comment, Edited: edited, Err: err}
+	}
+}
+
+// DeleteComment deletes a comment
+func (s *Source) DeleteComment(repo *github.Repository, comment *github.PullRequestComment) tea.Cmd {
+	return func() tea.Msg {
+		editor, ok := s.provider.(commentEditor)
+		if !ok {
+			return DeleteMsg{Comment: comment, Err: fmt.Errorf("deleting comments: %w", ghclient.ErrUnsupported)}
+		}
+
+		ctx, cancel := s.withTimeout()
+		defer cancel()
+
+		err := editor.DeleteComment(ctx, repo.GetOwner().GetLogin(), repo.GetName(), comment)
+		return DeleteMsg{Comment: comment, Err: err}
+	}
+}
+
+// React adds a reaction to a comment
+func (s *Source) React(repo *github.Repository, comment *github.PullRequestComment, content string) tea.Cmd {
+	return func() tea.Msg {
+		r, ok := s.provider.(reactor)
+		if !ok {
+			return ReactMsg{Comment: comment, Content: content, Err: fmt.Errorf("reacting to comments: %w", ghclient.ErrUnsupported)}
+		}
+
+		ctx, cancel := s.withTimeout()
+		defer cancel()
+
+		added, err := r.React(ctx, repo.GetOwner().GetLogin(), repo.GetName(), comment, content)
+		return ReactMsg{Comment: comment, Content: content, Added: added, Err: err}
+	}
+}
+
+// FetchCheckoutPR finds the open change of a repository for the branch or commit of a local checkout
+func (s *Source) FetchCheckoutPR(owner, name, branch, commit string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := s.withTimeout()
+		defer cancel()
+
+		repo, err := s.provider.GetRepo(ctx, owner, name)
+		if err != nil {
+			return CheckoutPRMsg{Err: err}
+		}
+		pr, err := FindCheckoutChange(ctx, s.provider, owner, name, branch, commit)
+		if err != nil {
+			return CheckoutPRMsg{Err: err}
+		}
+
+		return CheckoutPRMsg{Repo: repo, PR: pr}
+	}
+}
+
+// FetchComments fetches the review comments of the given change within the configured limits, yielding
+// them page by page when the limits span pages
+func (s *Source) FetchComments(repo *github.Repository, pr *github.PullRequest) tea.Cmd {
+	return stream(s.limits.Comments, func(more tea.Cmd, partial func(tea.Msg)) tea.Msg {
+		if repo == nil || pr == nil {
+			return CommentsMsg{Err: fmt.Errorf("no repository or PR provided")}
+		}
+
+		repoName, number := repo.GetFullName(), pr.GetNumber()
+		var fetched []*github.PullRequestComment
+		ctx := ghclient.WithPageHook(context.Background(), func(page []*github.PullRequestComment) {
+			fetched = append(fetched, page...)
+			partial(CommentsMsg{Repo: repoName, PR: number, Comments: slices.Clone(fetched), More: more})
+		})
+
+		fetchedAt := time.Now()
+		comments, cached, err := s.comments(ctx, repo, pr)
+		if cached {
+			fetchedAt = time.Time{}
+		}
+		return CommentsMsg{Repo: repoName, PR: number, Comments: comments, FetchedAt: fetchedAt, Err: err}
+	})
+}
+
+// RefreshComments fetches the review comments of a change updated since fetchedAt and merges them into
+// comments, its comments as of then, where the provider supports it; deleted comments stay until the next
+// full fetch. Otherwise, or without a fetchedAt, it fetches all comments again, bypassing the cache.
+func (s *Source) RefreshComments(repo *github.Repository, pr *github.PullRequest, comments []*github.PullRequestComment, fetchedAt time.Time) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := s.withTimeout()
+		defer cancel()
+
+		owner, name, number := repo.GetOwner().GetLogin(), repo.GetName(), pr.GetNumber()
+		now := time.Now()
+		var refreshed []*github.PullRequestComment
+		if lister, ok := s.provider.(commentsSinceLister); ok && !fetchedAt.IsZero() {
+			// A margin covers clock skew; comments seen again are merged by ID
+			since := fetchedAt.Add(-time.Minute)
+			updates, err := lister.ListCommentsSince(ctx, owner, name, number, since, s.limits.Comments)
+			if err != nil {
+				return CommentsMsg{Repo: repo.GetFullName(), PR: number, Err: err}
+			}
+			general, err := s.generalComments(ctx, owner, name, number, since)
+			if err != nil {
+				return CommentsMsg{Repo: repo.GetFullName(), PR: number, Err: err}
+			}
+			updates = append(updates, general...)
+			slog.Debug("refreshed comments", "repo", repo.GetFullName(), "pr", number, "updated", len(updates))
+			refreshed = ghclient.MergeComments(comments, updates)
+		} else {
+			all, err := s.provider.ListComments(ctx, owner, name, number, s.limits.Comments)
+			if err != nil {
+				return CommentsMsg{Repo: repo.GetFullName(), PR: number, Err: err}
+			}
+			general, err := s.generalComments(ctx, owner, name, number, time.Time{})
+			if err != nil {
+				return CommentsMsg{Repo: repo.GetFullName(), PR: number, Err: err}
+			}
+			refreshed = append(all, general...)
+			ghclient.SortComments(refreshed)
+		}
+
+		s.storeCached(s.commentsKey(repo, pr), s.cacheTTL.Comments, refreshed)
+		return CommentsMsg{Repo: repo.GetFullName(), PR: number, Comments: refreshed, FetchedAt: now}
+	}
+}
+
+// PrefetchComments returns a job fetching the review comments of a change into the cache, so opening it
+// later is instant. It returns nil when comments are not cached.
+func (s *Source) PrefetchComments(repo *github.Repository, pr *github.PullRequest) prefetch.Job {
+	if s.cache == nil || s.cacheTTL.Comments <= 0 {
+		return nil
+	}
+	return func(ctx context.Context) tea.Msg {
+		_, _, err := s.comments(ctx, repo, pr)
+		return CommentsPrefetchedMsg{Repo: repo.GetFullName(), PR: pr.GetNumber(), Err: err}
+	}
+}
+
+// commentsKey returns the cache key of the review comments of a change
+func (s *Source) commentsKey(repo *github.Repository, pr *github.PullRequest) string {
+	return s.cacheKey("comments", repo.GetFullName(), pr.GetNumber(), s.limits.Comments, s.conversation)
+}
+
+// comments returns the review comments of a change within the configured limits, from the cache when
+// fresh, and whether they were read from it
+func (s *Source) comments(parent context.Context, repo *github.Repository, pr *github.PullRequest) ([]*github.PullRequestComment, bool, error) {
+	key := s.commentsKey(repo, pr)
+	var comments []*github.PullRequestComment
+	if s.cache.Get(key, s.cacheTTL.Comments, &comments) {
+		return comments, true, nil
+	}
+
+	ctx, cancel := s.within(parent)
+	defer cancel()
+
+	start := time.Now()
+	owner, name, number := repo.GetOwner().GetLogin(), repo.GetName(), pr.GetNumber()
+	comments, err := s.provider.ListComments(ctx, owner, name, number, s.limits.Comments)
+	if err != nil {
+		return nil, false, err
+	}
+	general, err := s.generalComments(ctx, owner, name, number, time.Time{})
+	if err != nil {
+		return nil, false, err
+	}
+	if len(general) > 0 {
+		comments = append(comments, general...)
+		ghclient.SortComments(comments)
+	}
+	slog.Debug("fetched comments", "repo", repo.GetFullName(), "pr", pr.GetNumber(), "count", len(comments), "duration", time.Since(start))
+	s.storeCached(key, s.cacheTTL.Comments, comments)
+
+	return comments, false, nil
+}
+
+// generalComments lists the comments on a change as a whole updated since the given time, or all of them for
+// the zero time, where the provider has them: the summary bodies of its reviews and, when listed with the
+// review comments, its conversation comments
+func (s *Source) generalComments(ctx context.Context, owner, name string, number int, since time.Time) ([]*github.PullRequestComment, error) {
+	var comments []*github.PullRequestComment
+	if lister, ok := s.provider.(reviewBodyLister); ok {
+		reviews, err := lister.ListReviewBodies(ctx, owner, name, number, since, s.limits.Comments)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, reviews...)
+	}
+	if lister, ok := s.provider.(conversationLister); ok && s.conversation {
+		conversation, err := lister.ListConversation(ctx, owner, name, number, since, s.limits.Comments)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, conversation...)
+	}
+	return comments, nil
+}
+
+// FetchReviewThreads fetches the comment threads of the given change
+func (s *Source) FetchReviewThreads(repo *github.Repository, pr *github.PullRequest) tea.Cmd {
+	return func() tea.Msg {
+		if repo == nil || pr == nil {
+			return ReviewThreadsMsg{Err: fmt.Errorf("no repository or PR provided")}
+		}
+
+		ctx, cancel := s.withTimeout()
+		defer cancel()
+
+		threads, err := s.provider.ListThreads(ctx, repo.GetOwner().GetLogin(), repo.GetName(), pr.GetNumber())
+		return ReviewThreadsMsg{Repo: repo.GetFullName(), PR: pr.GetNumber(), Threads: threads, Err: err}
+	}
+}
+
+// FetchCIStatus fetches the CI status of the head commit of the given change, where the provider reports one
+func (s *Source) FetchCIStatus(repo *github.Repository, pr *github.PullRequest) tea.Cmd {
+	return func() tea.Msg {
+		getter, ok := s.provider.(ciStatusGetter)
+		if !ok {
+			return CIStatusMsg{Repo: repo.GetFullName(), PR: pr.GetNumber()}
+		}
+
+		ctx, cancel := s.withTimeout()
+		defer cancel()
+
+		return s.ciStatus(ctx, getter, repo, pr)
+	}
+}
+
+// CIStatusJob returns a job fetching the CI status of the head commit of the given change, or nil when the
+// provider reports none
+func (s *Source) CIStatusJob(repo *github.Repository, pr *github.PullRequest) prefetch.Job {
+	getter, ok := s.provider.(ciStatusGetter)
+	if !ok {
+		return nil
+	}
+	return func(parent context.Context) tea.Msg {
+		ctx, cancel := s.within(parent)
+		defer cancel()
+
+		return s.ciStatus(ctx, getter, repo, pr)
+	}
+}
+
+// ciStatus fetches the CI status of the head commit of a change
+func (s *Source) ciStatus(ctx context.Context, getter ciStatusGetter, repo *github.Repository, pr *github.PullRequest) CIStatusMsg {
+	status, err := getter.GetCIStatus(ctx, repo.GetOwner().GetLogin(), repo.GetName(), pr.GetHead().GetSHA())
+	return CIStatusMsg{Repo: repo.GetFullName(), PR: pr.GetNumber(), Status: status, Err: err}
+}
+
+// CodeownersJob returns a job loading the CODEOWNERS file of a repository at ref, from the local checkout
+// at root when it is given and has one
+func (s *Source) CodeownersJob(repo *github.Repository, ref, root string) prefetch.Job {
+	return func(parent context.Context) tea.Msg {
+		ctx, cancel := s.within(parent)
+		defer cancel()
+
+		owners, err := FindCodeowners(ctx, s.provider, repo.GetOwner().GetLogin(), repo.GetName(), ref, root)
+		return CodeownersMsg{Repo: repo.GetFullName(), Ref: ref, Owners: owners, Err: err}
+	}
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v57/github"
+	"github.com/stefrushxyz/nitpick/internal/gitrepo"
+	"github.com/stefrushxyz/nitpick/internal/prefetch"
+	"github.com/stefrushxyz/nitpick/internal/symbol"
+)
+
+// SymbolMsg is a message containing the declaration enclosing the lines of a review comment
+type SymbolMsg struct {
+	CommentID int64
+	Symbol    *symbol.Symbol
+	Err       error
+}
+
+// FindFile returns the content of a repository file at commit: from the local checkout when it is given
+// and has the commit, and otherwise through the API where the provider supports it. It returns nil without
+// an error when neither can fetch the file.
+func FindFile(ctx context.Context, p Provider, owner, name, path, commit string, checkout *gitrepo.Checkout) ([]byte, error) {
+	if checkout != nil && checkout.HasCommit(commit) {
+		return checkout.FileAt(commit, path)
+	}
+
+	getter, ok := p.(fileGetter)
+	if !ok {
+		return nil, nil
+	}
+	return getter.GetFile(ctx, owner, name, path, commit)
+}
+
+// FindSymbol returns the function, method or type declaration enclosing the lines of a review comment. It
+// returns nil without an error when the file cannot be fetched or no declaration encloses the lines.
+func FindSymbol(ctx context.Context, p Provider, owner, name string, pr *github.PullRequest, comment *github.PullRequestComment, checkout *gitrepo.Checkout) (*symbol.Symbol, error) {
+	commit, start, end, ok := CommentedLines(pr, comment)
+	if !ok {
+		return nil, nil
+	}
+
+	content, err := FindFile(ctx, p, owner, name, comment.GetPath(), commit, checkout)
+	if err != nil || content == nil {
+		return nil, err
+	}
+	return symbol.Enclosing(comment.GetPath(), content, start, end), nil
+}
+
+// SymbolJob returns a job fetching the declaration enclosing the lines of a review comment, reading the
+// file from the local checkout when it is given and has the comment's commit
+func (s *Source) SymbolJob(repo *github.Repository, pr *github.PullRequest, comment *github.PullRequestComment, checkout *gitrepo.Checkout) prefetch.Job {
+	return func(parent context.Context) tea.Msg {
+		ctx, cancel := s.within(parent)
+		defer cancel()
+
+		sym, err := FindSymbol(ctx, s.provider, repo.GetOwner().GetLogin(), repo.GetName(), pr, comment, checkout)
+		return SymbolMsg{CommentID: comment.GetID(), Symbol: sym, Err: err}
+	}
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-github/v57/github"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/workboard"
+)
+
+// BoardMsg is a message containing the cards of the workboard
+type BoardMsg struct {
+	Cards []workboard.Card
+	Err   error
+}
+
+// FetchBoard fetches the cards of the workboard
+func (s *Source) FetchBoard() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := s.withTimeout()
+		defer cancel()
+
+		cards, err := FindBoard(ctx, s.provider, s.limits.PRs)
+		return BoardMsg{Cards: cards, Err: err}
+	}
+}
+
+// LaneMsg is a message containing the cards of a single workboard lane
+type LaneMsg struct {
+	Lane  workboard.Lane
+	Cards []workboard.Card
+	Err   error
+}
+
+// FetchLane fetches the cards of a single workboard lane
+func (s *Source) FetchLane(lane workboard.Lane) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := s.withTimeout()
+		defer cancel()
+
+		cards, err := FindLane(ctx, s.provider, lane, s.limits.PRs)
+		return LaneMsg{Lane: lane, Cards: cards, Err: err}
+	}
+}
+
+// FindLane searches the pull requests of a workboard lane across repositories, within opts, and lays them
+// out as cards. It fails for providers without pull request search.
+func FindLane(ctx context.Context, p Provider, lane workboard.Lane, opts ghclient.ListOptions) ([]workboard.Card, error) {
+	searcher, ok := p.(prSearcher)
+	if !ok {
+		return nil, fmt.Errorf("%q searches pull requests across repositories: %w", lane.Name, ghclient.ErrUnsupported)
+	}
+
+	prs, err := searcher.SearchPRs(ctx, lane.Query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search %q pull requests: %w", lane.Name, err)
+	}
+	return workboard.Cards(lane, prs), nil
+}
+
+// FindBoard searches the pull requests of each workboard lane across repositories, within opts each, and
+// lays them out as cards. It fails for providers without pull request search.
+func FindBoard(ctx context.Context, p Provider, opts ghclient.ListOptions) ([]workboard.Card, error) {
+	searcher, ok := p.(prSearcher)
+	if !ok {
+		return nil, fmt.Errorf("the workboard searches pull requests across repositories: %w", ghclient.ErrUnsupported)
+	}
+
+	results := make([][]*github.PullRequest, len(workboard.Lanes))
+	for i, lane := range workboard.Lanes {
+		prs, err := searcher.SearchPRs(ctx, lane.Query, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search %q pull requests: %w", lane.Name, err)
+		}
+		results[i] = prs
+	}
+	return workboard.Build(results), nil
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/stefrushxyz/nitpick/internal/config"
+	"github.com/stefrushxyz/nitpick/internal/gitrepo"
+)
+
+// WorktreeResult is the outcome of creating the worktree of a change
+type WorktreeResult struct {
+	Path    string // Directory of the worktree
+	Branch  string // Branch checked out in it
+	Created bool   // False when an existing worktree was reused
+}
+
+// headRef returns the local branch for a change and the ref that fetches its head from origin. Changes from
+// forks are fetched from the refs the provider keeps for them, on a pr-N branch.
+func headRef(providerName string, pr *github.PullRequest) (branch, ref string, err error) {
+	head, base := pr.GetHead(), pr.GetBase()
+	fork := head.GetRepo().GetFullName() != "" && head.GetRepo().GetFullName() != base.GetRepo().GetFullName()
+	if head.GetRef() != "" && !fork {
+		return head.GetRef(), "refs/heads/" + head.GetRef(), nil
+	}
+
+	branch = fmt.Sprintf("pr-%d", pr.GetNumber())
+	switch providerName {
+	case config.ProviderGitHub, config.ProviderGitea:
+		return branch, fmt.Sprintf("refs/pull/%d/head", pr.GetNumber()), nil
+	case config.ProviderGitLab:
+		return branch, fmt.Sprintf("refs/merge-requests/%d/head", pr.GetNumber()), nil
+	default:
+		return "", "", fmt.Errorf("cannot determine the branch of #%d to check out", pr.GetNumber())
+	}
+}
+
+// CreateWorktree checks out the head of a change in a dedicated worktree of checkout, named after the
+// repository and change, in dir or else next to the checkout
+func CreateWorktree(cfg *config.Config, checkout *gitrepo.Checkout, pr *github.PullRequest) (WorktreeResult, error) {
+	branch, ref, err := headRef(cfg.Provider, pr)
+	if err != nil {
+		return WorktreeResult{}, err
+	}
+
+	parent := cfg.WorktreeDir
+	if parent == "" {
+		parent = filepath.Dir(checkout.Root)
+	}
+	path := filepath.Join(parent, fmt.Sprintf("%s-pr-%d", checkout.Name, pr.GetNumber()))
+
+	created, err := checkout.AddWorktree(path, branch, ref)
+	if err != nil {
+		return WorktreeResult{}, err
+	}
+	return WorktreeResult{Path: path, Branch: branch, Created: created}, nil
+}
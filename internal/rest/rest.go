@@ -0,0 +1,238 @@
+// Package rest is the HTTP client shared by the providers served by plain REST APIs: GitLab, Gitea,
+// Bitbucket, Azure DevOps and Gerrit. It sends JSON requests with the retrying, logging transport and
+// follows pagination; each provider supplies how requests are authenticated, how error responses read
+// and where the next page is, and maps the responses to the GitHub types the TUI works with.
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/logging"
+	"github.com/stefrushxyz/nitpick/internal/retry"
+)
+
+// defaultTimeout bounds the requests of a single fetch when Options.Timeout is unset
+const defaultTimeout = 30 * time.Second
+
+// maxMessage is the length error messages read from response bodies are cut to
+const maxMessage = 200
+
+// Options configures the client of a provider
+type Options struct {
+	Token    string        // Credentials, in the form the provider expects
+	Host     string        // Hostname of the service, from which providers derive their API base URL
+	BaseURL  string        // API base URL; overrides the one derived from Host
+	PageSize int           // Results per page; defaults to the provider's page size
+	Timeout  time.Duration // Timeout for the requests of a single fetch; defaults to 30s
+	Retry    retry.Policy  // Retries of requests failing transiently
+}
+
+// API describes the REST API of a provider
+type API struct {
+	Name        string                              // Name of the service in error messages, e.g. GitLab
+	BaseURL     string                              // URL the paths of requests are relative to
+	PageSize    int                                 // Results per page when Options.PageSize is unset
+	MaxPageSize int                                 // Largest page size the API allows; 0 for no limit
+	Auth        func(req *http.Request)             // Adds the credentials to a request; nil for anonymous requests
+	Error       func(status int, body []byte) error // Describes an error response; nil for the status and body
+}
+
+// Client sends requests to the REST API of a provider
+type Client struct {
+	http     *http.Client
+	api      API
+	pageSize int
+	timeout  time.Duration
+}
+
+// New creates a client for api, configured by opts
+func New(api API, opts Options) (*Client, error) {
+	api.BaseURL = strings.TrimSuffix(api.BaseURL, "/")
+	if _, err := url.Parse(api.BaseURL); err != nil {
+		return nil, fmt.Errorf("invalid API base URL %q: %w", api.BaseURL, err)
+	}
+
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = api.PageSize
+	}
+	if api.MaxPageSize > 0 && pageSize > api.MaxPageSize {
+		pageSize = api.MaxPageSize
+	}
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return &Client{
+		http:     &http.Client{Transport: retry.Transport(logging.Transport(nil), opts.Retry)},
+		api:      api,
+		pageSize: pageSize,
+		timeout:  timeout,
+	}, nil
+}
+
+// Timeout returns the timeout for the requests of a single fetch
+func (c *Client) Timeout() time.Duration {
+	return c.timeout
+}
+
+// BaseURL returns the URL the paths of requests are relative to
+func (c *Client) BaseURL() string {
+	return c.api.BaseURL
+}
+
+// PageSize returns the page size to request within opts
+func (c *Client) PageSize(opts ghclient.ListOptions) int {
+	perPage := c.pageSize
+	if opts.PerPage > 0 && opts.PerPage < perPage {
+		perPage = opts.PerPage
+	}
+	if opts.Limit > 0 && opts.Limit < perPage {
+		perPage = opts.Limit
+	}
+	return perPage
+}
+
+// Response is a successful response, read in full
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Do sends a request for an API path or absolute URL, with in, if not nil, as its JSON body, accepting
+// a JSON response. Responses with an error status are returned as errors described by the API.
+func (c *Client) Do(ctx context.Context, method, path string, query url.Values, in any) (*Response, error) {
+	return c.send(ctx, method, path, query, in, "application/json")
+}
+
+// Raw fetches an API path or absolute URL whose response is not JSON, e.g. a diff
+func (c *Client) Raw(ctx context.Context, path string, query url.Values) ([]byte, error) {
+	resp, err := c.send(ctx, http.MethodGet, path, query, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// send sends a request with the given Accept header, if not empty
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, in any, accept string) (*Response, error) {
+	u := path
+	if !strings.Contains(u, "://") {
+		u = c.api.BaseURL + path
+	}
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	var payload io.Reader
+	if in != nil {
+		content, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		payload = bytes.NewReader(content)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, payload)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.api.Auth != nil {
+		c.api.Auth(req)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		if c.api.Error != nil {
+			return nil, c.api.Error(resp.StatusCode, body)
+		}
+		return nil, fmt.Errorf("%s API error %d: %s", c.api.Name, resp.StatusCode, Message(body))
+	}
+	return &Response{StatusCode: resp.StatusCode, Header: resp.Header, Body: body}, nil
+}
+
+// Request sends a request like Do and decodes the JSON response into out, if not nil, returning the
+// response headers
+func (c *Client) Request(ctx context.Context, method, path string, query url.Values, in, out any) (http.Header, error) {
+	resp, err := c.Do(ctx, method, path, query, in)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Decode(resp.Body, out); err != nil {
+		return nil, err
+	}
+	return resp.Header, nil
+}
+
+// Decode decodes a JSON response body into out, if not nil
+func (c *Client) Decode(body []byte, out any) error {
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", c.api.Name, err)
+	}
+	return nil
+}
+
+// Message returns the body of an error response as a message, cut to a readable length
+func Message(body []byte) string {
+	message := strings.TrimSpace(string(body))
+	if len(message) > maxMessage {
+		message = message[:maxMessage]
+	}
+	return message
+}
+
+// Page is a page of a list with the cursor of the next page, empty on the last page
+type Page[T any] struct {
+	Items []T
+	Next  string
+}
+
+// List calls fetch for successive pages of a list until opts are satisfied, passing the cursor of the
+// page to fetch, empty for the first one, and reporting each page to the page hook of ctx
+func List[T any](ctx context.Context, opts ghclient.ListOptions, fetch func(cursor string) (Page[T], error)) ([]T, error) {
+	var all []T
+	cursor := ""
+	for pages := 1; ; pages++ {
+		page, err := fetch(cursor)
+		if err != nil {
+			return nil, err
+		}
+		ghclient.ReportPage(ctx, page.Items)
+		all = append(all, page.Items...)
+
+		if opts.Limit > 0 && len(all) >= opts.Limit {
+			return all[:opts.Limit], nil
+		}
+		if !opts.AllPages || page.Next == "" || pages == opts.MaxPages {
+			return all, nil
+		}
+		cursor = page.Next
+	}
+}
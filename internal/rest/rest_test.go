@@ -0,0 +1,96 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+)
+
+func TestList(t *testing.T) {
+	// Three pages of two items each, the cursor being the page number
+	fetch := func(cursors *[]string) func(string) (Page[int], error) {
+		return func(cursor string) (Page[int], error) {
+			*cursors = append(*cursors, cursor)
+			n, _ := strconv.Atoi(cursor)
+			page := Page[int]{Items: []int{2 * n, 2*n + 1}}
+			if n < 2 {
+				page.Next = strconv.Itoa(n + 1)
+			}
+			return page, nil
+		}
+	}
+
+	tests := []struct {
+		name    string
+		opts    ghclient.ListOptions
+		want    []int
+		cursors []string
+	}{
+		{"first page", ghclient.ListOptions{}, []int{0, 1}, []string{""}},
+		{"all pages", ghclient.ListOptions{AllPages: true}, []int{0, 1, 2, 3, 4, 5}, []string{"", "1", "2"}},
+		{"limit", ghclient.ListOptions{AllPages: true, Limit: 3}, []int{0, 1, 2}, []string{"", "1"}},
+		{"max pages", ghclient.ListOptions{AllPages: true, MaxPages: 2}, []int{0, 1, 2, 3}, []string{"", "1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cursors []string
+			got, err := List(context.Background(), tt.opts, fetch(&cursors))
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("List() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(cursors, tt.cursors) {
+				t.Errorf("fetched cursors %q, want %q", cursors, tt.cursors)
+			}
+		})
+	}
+}
+
+func TestRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, "  401 Unauthorized\n")
+			return
+		}
+		w.Header().Set("X-Next-Page", "2")
+		fmt.Fprintf(w, `{"path":%q}`, r.URL.RequestURI())
+	}))
+	defer server.Close()
+
+	for _, token := range []string{"secret", "wrong"} {
+		client, err := New(API{
+			Name:    "Forge",
+			BaseURL: server.URL + "/api/",
+			Auth:    func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+token) },
+		}, Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var out struct{ Path string }
+		header, err := client.Request(context.Background(), http.MethodGet, "/items", url.Values{"page": {"1"}}, nil, &out)
+		if token == "wrong" {
+			if want := "Forge API error 401: 401 Unauthorized"; err == nil || err.Error() != want {
+				t.Errorf("Request() error = %v, want %s", err, want)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Request() error = %v", err)
+		}
+		if out.Path != "/api/items?page=1" || header.Get("X-Next-Page") != "2" {
+			t.Errorf("Request() = %q with next page %q, want /api/items?page=1 with 2", out.Path, header.Get("X-Next-Page"))
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// Policy sets how requests failing transiently are retried
+type Policy struct {
+	Attempts int           // Attempts per request, the first included; 1 or less disables retries
+	Backoff  time.Duration // Delay before the first retry, doubled before each next one, with jitter
+}
+
+// maxBackoff caps the delay between attempts
+const maxBackoff = 10 * time.Second
+
+// transport retries the requests of its base transport
+type transport struct {
+	base   http.RoundTripper
+	policy Policy
+}
+
+// Transport wraps base so requests failing transiently, with a 5xx server error, a network timeout or a
+// dropped connection, are retried as policy sets. Only GET and HEAD requests are retried: others may have
+// taken effect before failing. Retries stop when the request's context is done.
+func Transport(base http.RoundTripper, policy Policy) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if policy.Attempts <= 1 {
+		return base
+	}
+	return transport{base: base, policy: policy}
+}
+
+// RoundTrip performs the request, retrying it while it fails transiently
+func (t transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx := req.Context()
+	delay := t.policy.Backoff
+	for attempt := 1; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if attempt == t.policy.Attempts || !transient(ctx, resp, err) {
+			return resp, err
+		}
+
+		// Full jitter keeps clients that failed together from retrying together
+		wait := delay/2 + rand.N(delay/2+1)
+		slog.DebugContext(ctx, "retrying api request", "url", req.URL.String(), "attempt", attempt, "wait", wait,
+			"status", status(resp), "error", err)
+		if resp != nil {
+			resp.Body.Close()
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+		delay = min(2*delay, maxBackoff)
+	}
+}
+
+// transient reports whether a request failed in a way worth retrying
+func transient(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		// The request's own deadline or cancellation is final
+		if ctx.Err() != nil {
+			return false
+		}
+		var netErr net.Error
+		return (errors.As(err, &netErr) && netErr.Timeout()) || errors.Is(err, syscall.ECONNRESET)
+	}
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// status returns the status code of a response, or 0 without one
+func status(resp *http.Response) int {
+	if resp == nil {
+		return 0
+	}
+	return resp.StatusCode
+}
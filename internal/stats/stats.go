@@ -0,0 +1,165 @@
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/stefrushxyz/nitpick/internal/config"
+)
+
+// Event is a kind of activity counted in the usage stats
+type Event string
+
+// Events recorded in the usage stats
+const (
+	EventPrompt         Event = "prompt"          // A prompt was generated
+	EventResolve        Event = "resolve"         // A review thread was resolved
+	EventReply          Event = "reply"           // A reply was posted
+	EventCommentsViewed Event = "comments_viewed" // Review comments were loaded for a pull request
+)
+
+// Counts holds the number of times each activity happened
+type Counts struct {
+	Prompts        int `json:"prompts"`
+	Resolved       int `json:"resolved"`
+	Replies        int `json:"replies"`
+	CommentsViewed int `json:"comments_viewed"`
+}
+
+// RepoStats holds the activity of one repository
+type RepoStats struct {
+	Counts
+	LastActivity time.Time `json:"last_activity"`
+}
+
+// Stats holds the local usage statistics. They never leave the machine.
+type Stats struct {
+	Since time.Time             `json:"since"`
+	Total Counts                `json:"total"`
+	Repos map[string]*RepoStats `json:"repos"`
+}
+
+// RepoEntry pairs a repository name with its stats
+type RepoEntry struct {
+	Repo string
+	*RepoStats
+}
+
+// Path returns the path of the stats file in the state directory
+func Path() (string, error) {
+	dir, err := config.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "stats.json"), nil
+}
+
+// Load reads the usage stats, returning empty stats if none are recorded yet
+func Load() (*Stats, error) {
+	stats := &Stats{Repos: map[string]*RepoStats{}}
+
+	path, err := Path()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate stats file: %w", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return stats, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stats file: %w", err)
+	}
+
+	if err := json.Unmarshal(content, stats); err != nil {
+		return nil, fmt.Errorf("failed to parse stats file %s: %w", path, err)
+	}
+	if stats.Repos == nil {
+		stats.Repos = map[string]*RepoStats{}
+	}
+	return stats, nil
+}
+
+// Save writes the usage stats
+func (s *Stats) Save() error {
+	path, err := Path()
+	if err != nil {
+		return fmt.Errorf("failed to locate stats file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("failed to write stats file: %w", err)
+	}
+	return nil
+}
+
+// Add counts n occurrences of event for repo (owner/name)
+func (s *Stats) Add(event Event, repo string, n int) {
+	now := time.Now()
+	if s.Since.IsZero() {
+		s.Since = now
+	}
+
+	entry := s.Repos[repo]
+	if entry == nil {
+		entry = &RepoStats{}
+		s.Repos[repo] = entry
+	}
+	entry.LastActivity = now
+
+	for _, counts := range []*Counts{&s.Total, &entry.Counts} {
+		switch event {
+		case EventPrompt:
+			counts.Prompts += n
+		case EventResolve:
+			counts.Resolved += n
+		case EventReply:
+			counts.Replies += n
+		case EventCommentsViewed:
+			counts.CommentsViewed += n
+		}
+	}
+}
+
+// ByActivity returns the repositories ordered by the number of review comments viewed, then by name
+func (s *Stats) ByActivity() []RepoEntry {
+	entries := make([]RepoEntry, 0, len(s.Repos))
+	for repo, repoStats := range s.Repos {
+		entries = append(entries, RepoEntry{Repo: repo, RepoStats: repoStats})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].CommentsViewed != entries[j].CommentsViewed {
+			return entries[i].CommentsViewed > entries[j].CommentsViewed
+		}
+		return entries[i].Repo < entries[j].Repo
+	})
+	return entries
+}
+
+// Record adds n occurrences of event for repo to the stored stats. Stats are best effort,
+// so failures are logged rather than returned.
+func Record(event Event, repo string, n int) {
+	stats, err := Load()
+	if err != nil {
+		slog.Warn("failed to record usage stats", "error", err)
+		return
+	}
+	stats.Add(event, repo, n)
+	if err := stats.Save(); err != nil {
+		slog.Warn("failed to record usage stats", "error", err)
+	}
+}
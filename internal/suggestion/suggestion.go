@@ -0,0 +1,122 @@
+package suggestion
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// Suggestion is a suggested change of a review comment: a ```suggestion block whose lines replace the
+// commented lines when the suggestion is applied
+type Suggestion struct {
+	Start  int      // First commented line replaced
+	End    int      // Last commented line replaced
+	Before []string // Commented lines, from the end of the comment's diff hunk; nil when it lacks them
+	After  []string // Lines replacing them; empty to delete them
+}
+
+// Find returns the suggested changes in the body of a review comment, in order
+func Find(comment *github.PullRequestComment) []Suggestion {
+	blocks := Parse(comment.GetBody())
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	// The diff hunk of a comment ends at its line as of the commit it was made on
+	start, end := comment.GetOriginalStartLine(), comment.GetOriginalLine()
+	if end == 0 {
+		start, end = comment.GetStartLine(), comment.GetLine()
+	}
+	if start == 0 || start > end {
+		start = end
+	}
+	before := commentedLines(comment.GetDiffHunk(), end-start+1)
+
+	suggestions := make([]Suggestion, len(blocks))
+	for i, after := range blocks {
+		suggestions[i] = Suggestion{Start: start, End: end, Before: before, After: after}
+	}
+	return suggestions
+}
+
+// Parse returns the lines of each ```suggestion block of a comment body, in order
+func Parse(body string) [][]string {
+	var blocks [][]string
+	var block []string
+	fence := ""
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		trimmed := strings.TrimSpace(line)
+		if fence == "" {
+			if marker, info, ok := cutFence(trimmed); ok && info == "suggestion" {
+				fence, block = marker, []string{}
+			}
+			continue
+		}
+		// A fence is closed by a fence of the same character at least as long, with nothing after it
+		if marker, info, ok := cutFence(trimmed); ok && info == "" && marker[0] == fence[0] && len(marker) >= len(fence) {
+			blocks = append(blocks, block)
+			fence = ""
+			continue
+		}
+		block = append(block, line)
+	}
+	return blocks
+}
+
+// Code returns the suggested lines
+func (s Suggestion) Code() string {
+	return strings.Join(s.After, "\n")
+}
+
+// Lines returns the replaced lines, such as "L10" or "L10-12"
+func (s Suggestion) Lines() string {
+	if s.Start == s.End {
+		return fmt.Sprintf("L%d", s.End)
+	}
+	return fmt.Sprintf("L%d-%d", s.Start, s.End)
+}
+
+// Diff returns the suggestion as a hunk replacing the commented lines with the suggested ones; without the
+// commented lines, it only adds the suggested ones
+func (s Suggestion) Diff() string {
+	hunk := []string{fmt.Sprintf("@@ -%d,%d +%d,%d @@", s.Start, len(s.Before), s.Start, len(s.After))}
+	for _, line := range s.Before {
+		hunk = append(hunk, "-"+line)
+	}
+	for _, line := range s.After {
+		hunk = append(hunk, "+"+line)
+	}
+	return strings.Join(hunk, "\n")
+}
+
+// cutFence splits a line opening or closing a fenced code block into its fence of three or more backticks
+// or tildes and the info string after it
+func cutFence(line string) (fence, info string, ok bool) {
+	if !strings.HasPrefix(line, "```") && !strings.HasPrefix(line, "~~~") {
+		return "", "", false
+	}
+	n := len(line) - len(strings.TrimLeft(line, line[:1]))
+	return line[:n], strings.TrimSpace(line[n:]), true
+}
+
+// commentedLines returns the last n lines of the new side of a diff hunk, without their diff markers, or nil
+// when the hunk has fewer
+func commentedLines(hunk string, n int) []string {
+	var lines []string
+	for _, text := range strings.Split(hunk, "\n") {
+		switch {
+		case strings.HasPrefix(text, "@@"):
+			lines = nil
+		case strings.HasPrefix(text, "-"), strings.HasPrefix(text, `\`):
+			// Deleted lines and "\ No newline at end of file" are not on the new side
+		case text != "":
+			lines = append(lines, strings.TrimSuffix(text[1:], "\r"))
+		}
+	}
+	if n <= 0 || len(lines) < n {
+		return nil
+	}
+	return lines[len(lines)-n:]
+}
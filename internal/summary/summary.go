@@ -0,0 +1,128 @@
+package summary
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+)
+
+// Summary is a triage overview of the review of a pull request
+type Summary struct {
+	Reviewers  []Reviewer // Requested reviewers and commenters, most comments first
+	Files      []File     // Files with threads, most threads first
+	Threads    int        // Number of comment threads
+	Unresolved int        // Number of unresolved threads; -1 when resolution is unknown
+	Outdated   int        // Number of threads on lines changed since
+
+	// Unanswered is the latest comment of the unresolved thread waiting longest for an answer from the pull
+	// request's author, or nil when the author had the last word everywhere
+	Unanswered *github.PullRequestComment
+	// Waiting is the number of unresolved threads whose last comment is not by the pull request's author
+	Waiting int
+}
+
+// Reviewer is a reviewer of a pull request
+type Reviewer struct {
+	Login     string
+	Comments  int  // Number of review comments posted
+	Requested bool // Whether their review is requested
+}
+
+// File is a file commented on in a pull request
+type File struct {
+	Path    string // Empty for comments not on a file
+	Threads int
+}
+
+// New summarizes the review comments of a pull request. threads, the resolution state of the comment threads,
+// may be nil when unknown.
+func New(pr *github.PullRequest, comments []*github.PullRequestComment, threads []ghclient.ReviewThread) *Summary {
+	author := pr.GetUser().GetLogin()
+	summary := &Summary{Unresolved: -1}
+
+	resolved := make(map[int64]bool)
+	outdated := make(map[int64]bool)
+	if threads != nil {
+		summary.Unresolved = 0
+		for _, thread := range threads {
+			for _, id := range thread.CommentIDs {
+				resolved[id] = thread.IsResolved
+				outdated[id] = thread.IsOutdated
+			}
+		}
+	}
+
+	reviewers := make(map[string]*Reviewer)
+	reviewer := func(login string) *Reviewer {
+		key := strings.ToLower(login)
+		if reviewers[key] == nil {
+			reviewers[key] = &Reviewer{Login: login}
+		}
+		return reviewers[key]
+	}
+	for _, user := range pr.RequestedReviewers {
+		reviewer(user.GetLogin()).Requested = true
+	}
+	for _, comment := range comments {
+		if login := comment.GetUser().GetLogin(); login != "" && !strings.EqualFold(login, author) {
+			reviewer(login).Comments++
+		}
+	}
+	for _, r := range reviewers {
+		summary.Reviewers = append(summary.Reviewers, *r)
+	}
+	sort.Slice(summary.Reviewers, func(i, j int) bool {
+		a, b := summary.Reviewers[i], summary.Reviewers[j]
+		if a.Comments != b.Comments {
+			return a.Comments > b.Comments
+		}
+		return strings.ToLower(a.Login) < strings.ToLower(b.Login)
+	})
+
+	files := make(map[string]int)
+	for _, thread := range ghclient.GroupCommentThreads(comments) {
+		root := thread.Root
+		if ghclient.IsConversation(root) || ghclient.ReviewState(root) != "" {
+			// Conversation comments and review bodies form no threads to resolve or answer
+			continue
+		}
+		summary.Threads++
+		files[root.GetPath()]++
+
+		// Comments on lines changed since lose their line, keeping the original one
+		if outdated[root.GetID()] || (root.GetLine() == 0 && root.GetOriginalLine() != 0) {
+			summary.Outdated++
+		}
+		if resolved[root.GetID()] {
+			continue
+		}
+		if threads != nil {
+			summary.Unresolved++
+		}
+
+		last := root
+		if n := len(thread.Replies); n > 0 {
+			last = thread.Replies[n-1]
+		}
+		if strings.EqualFold(last.GetUser().GetLogin(), author) {
+			continue
+		}
+		summary.Waiting++
+		if summary.Unanswered == nil || last.GetCreatedAt().Before(summary.Unanswered.GetCreatedAt().Time) {
+			summary.Unanswered = last
+		}
+	}
+	for path, n := range files {
+		summary.Files = append(summary.Files, File{Path: path, Threads: n})
+	}
+	sort.Slice(summary.Files, func(i, j int) bool {
+		if summary.Files[i].Threads != summary.Files[j].Threads {
+			return summary.Files[i].Threads > summary.Files[j].Threads
+		}
+		return summary.Files[i].Path < summary.Files[j].Path
+	})
+
+	return summary
+}
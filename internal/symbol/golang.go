@@ -0,0 +1,83 @@
+package symbol
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+)
+
+// enclosingGo finds the innermost function, method or type declaration of Go source containing lines
+// start to end
+func enclosingGo(content []byte, start, end int) *Symbol {
+	fset := token.NewFileSet()
+	// A file with syntax errors still yields the declarations before them
+	file, _ := parser.ParseFile(fset, "", content, parser.ParseComments|parser.SkipObjectResolution)
+	if file == nil {
+		return nil
+	}
+
+	var best *Symbol
+	consider := func(node ast.Node, name, kind string) {
+		first, last := fset.Position(node.Pos()).Line, fset.Position(node.End()).Line
+		if first > start || last < end {
+			return
+		}
+		if best == nil || last-first < best.End-best.Start {
+			best = &Symbol{Name: name, Kind: kind, Start: first, End: last}
+		}
+	}
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			kind, name := "function", d.Name.Name
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				kind, name = "method", "("+receiverType(d.Recv.List[0].Type)+")."+name
+			}
+			// Doc comments belong to the declaration
+			var node ast.Node = d
+			if d.Doc != nil {
+				node = &span{d.Doc.Pos(), d.End()}
+			}
+			consider(node, name, kind)
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				if ts, ok := spec.(*ast.TypeSpec); ok {
+					consider(ts, ts.Name.Name, "type")
+				}
+			}
+		}
+	}
+	return best
+}
+
+// span is a node covering the source between two positions
+type span struct {
+	pos, end token.Pos
+}
+
+func (s *span) Pos() token.Pos { return s.pos }
+func (s *span) End() token.Pos { return s.end }
+
+// receiverType renders the type of a method receiver, e.g. *Client or List[T]
+func receiverType(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return "*" + receiverType(t.X)
+	case *ast.Ident:
+		return t.Name
+	case *ast.IndexExpr:
+		return receiverType(t.X) + "[" + receiverType(t.Index) + "]"
+	case *ast.IndexListExpr:
+		params := ""
+		for i, index := range t.Indices {
+			if i > 0 {
+				params += ", "
+			}
+			params += receiverType(index)
+		}
+		return receiverType(t.X) + "[" + params + "]"
+	default:
+		return "?"
+	}
+}
@@ -0,0 +1,153 @@
+package symbol
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// indentedDecl matches a Python or Ruby function, class or module declaration
+	indentedDecl = regexp.MustCompile(`^(\s*)(?:async\s+)?(def|class|module)\s+([A-Za-z_][\w.?!]*)`)
+	// bracedKeywordDecl matches a declaration introduced by a keyword in C-like languages
+	bracedKeywordDecl = regexp.MustCompile(`\b(function\*?|fn|func|fun|class|interface|struct|enum|trait|impl|object|record)\s+([A-Za-z_$][\w$]*)`)
+	// arrowDecl matches a JavaScript function assigned to a variable
+	arrowDecl = regexp.MustCompile(`\b(?:const|let|var)\s+([A-Za-z_$][\w$]*)\s*=\s*(?:async\s+)?(?:function\b|\([^)]*\)\s*=>|[A-Za-z_$][\w$]*\s*=>)`)
+	// bracedSignature matches the name and opening parenthesis of a method or function signature
+	bracedSignature = regexp.MustCompile(`([A-Za-z_$][\w$]*)\s*(?:<[^>]*>)?\s*\(`)
+	// controlStatement matches lines that open blocks but declare nothing
+	controlStatement = regexp.MustCompile(`^\s*(?:\}\s*)?(?:if|else|for|foreach|while|do|switch|case|catch|try|finally|return|with|using|lock|synchronized|unsafe|loop|match|when)\b`)
+)
+
+// enclosingIndented finds the innermost def, class or module containing lines start to end by indentation
+func enclosingIndented(lines []string, start, end int) *Symbol {
+	for i := start - 1; i >= 0; i-- {
+		match := indentedDecl.FindStringSubmatch(lines[i])
+		if match == nil {
+			continue
+		}
+
+		indent := len(match[1])
+		last := i + 1
+		for j := i + 1; j < len(lines); j++ {
+			if strings.TrimSpace(lines[j]) == "" {
+				continue
+			}
+			if indentation(lines[j]) <= indent {
+				// Ruby closes the block with an end at the declaration's indentation
+				if strings.HasPrefix(strings.TrimSpace(lines[j]), "end") {
+					last = j + 1
+				}
+				break
+			}
+			last = j + 1
+		}
+		if last < end {
+			continue
+		}
+
+		kind := "function"
+		if match[2] != "def" {
+			kind = "class"
+		}
+		return &Symbol{Name: match[3], Kind: kind, Start: i + 1, End: last}
+	}
+	return nil
+}
+
+// enclosingBraced finds the innermost function, method or type declaration containing lines start to
+// end in a language delimiting blocks with braces
+func enclosingBraced(lines []string, start, end int) *Symbol {
+	for i := start - 1; i >= 0; i-- {
+		line := lines[i]
+		if controlStatement.MatchString(line) {
+			continue
+		}
+
+		var name, kind string
+		if match := bracedKeywordDecl.FindStringSubmatch(line); match != nil {
+			name, kind = match[2], "function"
+			if !strings.HasPrefix(match[1], "f") {
+				kind = "class"
+			}
+		} else if match := arrowDecl.FindStringSubmatch(line); match != nil {
+			name, kind = match[1], "function"
+		} else if match := bracedSignature.FindStringSubmatch(line); match != nil && !strings.HasSuffix(strings.TrimSpace(line), ";") {
+			name, kind = match[1], "method"
+		} else {
+			continue
+		}
+
+		last := blockEnd(lines, i)
+		if last == 0 || last < end {
+			continue
+		}
+		return &Symbol{Name: name, Kind: kind, Start: i + 1, End: last}
+	}
+	return nil
+}
+
+// blockEnd returns the line, 1-based, closing the first brace block opened on or shortly after line i,
+// or 0 when there is none. Braces in strings and comments are skipped.
+func blockEnd(lines []string, i int) int {
+	depth, opened, inComment := 0, false, false
+	for j := i; j < len(lines); j++ {
+		if !opened && j > i+3 {
+			// A signature spanning more lines is rare; this is a call, not a declaration
+			return 0
+		}
+
+		line := lines[j]
+		var quote byte
+		for k := 0; k < len(line); k++ {
+			c := line[k]
+			switch {
+			case inComment:
+				if strings.HasPrefix(line[k:], "*/") {
+					inComment = false
+					k++
+				}
+			case quote != 0:
+				if c == '\\' {
+					k++
+				} else if c == quote {
+					quote = 0
+				}
+			case strings.HasPrefix(line[k:], "//"):
+				k = len(line)
+			case strings.HasPrefix(line[k:], "/*"):
+				inComment = true
+				k++
+			case c == '"' || c == '\'' || c == '`':
+				quote = c
+			case c == ';' && !opened:
+				// A statement ends before any block opens
+				return 0
+			case c == '{':
+				depth++
+				opened = true
+			case c == '}':
+				depth--
+				if opened && depth == 0 {
+					return j + 1
+				}
+			}
+		}
+	}
+	return 0
+}
+
+// indentation returns the width of a line's leading whitespace, counting tabs as four spaces
+func indentation(line string) int {
+	width := 0
+	for _, c := range line {
+		switch c {
+		case ' ':
+			width++
+		case '\t':
+			width += 4
+		default:
+			return width
+		}
+	}
+	return width
+}
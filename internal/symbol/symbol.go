@@ -0,0 +1,67 @@
+package symbol
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// MaxLines bounds the length of a symbol worth quoting; larger declarations are left out of prompts
+const MaxLines = 400
+
+// Symbol is a function, method or type declaration enclosing commented lines
+type Symbol struct {
+	Name     string // Name of the declaration, e.g. Open or (*Client).Open
+	Kind     string // function, method, class or type
+	Start    int    // First line of the declaration, 1-based
+	End      int    // Last line
+	Language string // Language of the file, as a markdown code fence tag
+	Source   string // Source of the declaration
+}
+
+// Lines returns the line range of the symbol, e.g. L10-42
+func (s *Symbol) Lines() string {
+	return fmt.Sprintf("L%d-%d", s.Start, s.End)
+}
+
+// languages maps file extensions to code fence tags
+var languages = map[string]string{
+	".go": "go", ".py": "python", ".rb": "ruby", ".js": "javascript", ".jsx": "jsx", ".mjs": "javascript",
+	".ts": "typescript", ".tsx": "tsx", ".java": "java", ".kt": "kotlin", ".kts": "kotlin", ".scala": "scala",
+	".swift": "swift", ".rs": "rust", ".c": "c", ".h": "c", ".cc": "cpp", ".cpp": "cpp", ".hpp": "cpp",
+	".cs": "csharp", ".php": "php", ".dart": "dart",
+}
+
+// Enclosing finds the innermost declaration of the file at path that contains lines start to end:
+// precisely for Go, by indentation for Python and Ruby, and by braces for other C-like languages. It returns
+// nil when there is none, the language is unknown, or the declaration is longer than MaxLines.
+func Enclosing(path string, content []byte, start, end int) *Symbol {
+	ext := strings.ToLower(filepath.Ext(path))
+	language, ok := languages[ext]
+	if !ok || start < 1 || end < start {
+		return nil
+	}
+
+	lines := strings.Split(string(bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))), "\n")
+	if end > len(lines) {
+		return nil
+	}
+
+	var sym *Symbol
+	switch language {
+	case "go":
+		sym = enclosingGo(content, start, end)
+	case "python", "ruby":
+		sym = enclosingIndented(lines, start, end)
+	default:
+		sym = enclosingBraced(lines, start, end)
+	}
+	if sym == nil || sym.End-sym.Start+1 > MaxLines {
+		return nil
+	}
+
+	sym.Language = language
+	sym.Source = strings.Join(lines[sym.Start-1:sym.End], "\n")
+	return sym
+}
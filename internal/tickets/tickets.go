@@ -0,0 +1,147 @@
+package tickets
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/stefrushxyz/nitpick/internal/config"
+	"github.com/stefrushxyz/nitpick/internal/jira"
+	"github.com/stefrushxyz/nitpick/internal/linear"
+)
+
+// Issue trackers tickets are fetched from
+const (
+	TrackerJira   = "jira"
+	TrackerLinear = "linear"
+)
+
+// keyPattern matches ticket keys such as PROJ-123, used by both Jira and Linear
+var keyPattern = regexp.MustCompile(`\b([A-Z][A-Z0-9_]+)-[1-9][0-9]*\b`)
+
+// Ticket is an issue tracker ticket linked to a pull request
+type Ticket struct {
+	Tracker     string // Issue tracker of the ticket: jira or linear
+	Key         string
+	Summary     string
+	Description string // Description in Jira's wiki markup or Linear's markdown
+	Type        string // Issue type in Jira, team in Linear
+	Status      string
+	URL         string
+}
+
+// Linker finds the tickets of the configured issue trackers that a pull request refers to
+type Linker struct {
+	cfg    *config.Config
+	jira   *jira.Client
+	linear *linear.Client
+}
+
+// New creates a linker for the issue trackers configured in cfg, or returns nil when none is
+func New(cfg *config.Config) (*Linker, error) {
+	l := &Linker{cfg: cfg}
+	if cfg.Jira.BaseURL != "" {
+		client, err := jira.New(cfg.Jira.BaseURL, cfg.Jira.Email, cfg.Jira.Token, cfg.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		l.jira = client
+	}
+	if cfg.Linear.APIKey != "" {
+		l.linear = linear.New(cfg.Linear.APIKey, cfg.Timeout)
+	}
+
+	if l.jira == nil && l.linear == nil {
+		return nil, nil
+	}
+	return l, nil
+}
+
+// Keys returns the ticket keys each tracker is asked for: Jira keys in the pull request's title and branch,
+// and Linear identifiers in its title, branch and description, as Linear's own integration links them
+func (l *Linker) Keys(pr *github.PullRequest) map[string][]string {
+	keys := make(map[string][]string)
+	if l.jira != nil {
+		if found := FindKeys(l.cfg.Jira.Projects, pr.GetTitle(), pr.GetHead().GetRef()); len(found) > 0 {
+			keys[TrackerJira] = found
+		}
+	}
+	if l.linear != nil {
+		if found := FindKeys(l.cfg.Linear.Teams, pr.GetTitle(), pr.GetHead().GetRef(), pr.GetBody()); len(found) > 0 {
+			keys[TrackerLinear] = found
+		}
+	}
+	return keys
+}
+
+// Linked fetches the tickets a pull request refers to. Tickets of the trackers that could be reached are
+// returned along with the errors of the others.
+func (l *Linker) Linked(ctx context.Context, pr *github.PullRequest) ([]*Ticket, error) {
+	keys := l.Keys(pr)
+
+	var linked []*Ticket
+	var errs []error
+	if len(keys[TrackerJira]) > 0 {
+		issues, err := l.jira.Issues(ctx, keys[TrackerJira])
+		errs = append(errs, err)
+		for _, issue := range issues {
+			linked = append(linked, &Ticket{
+				Tracker:     TrackerJira,
+				Key:         issue.Key,
+				Summary:     issue.Summary,
+				Description: issue.Description,
+				Type:        issue.Type,
+				Status:      issue.Status,
+				URL:         issue.URL,
+			})
+		}
+	}
+	if len(keys[TrackerLinear]) > 0 {
+		issues, err := l.linear.Issues(ctx, keys[TrackerLinear])
+		errs = append(errs, err)
+		for _, issue := range issues {
+			linked = append(linked, &Ticket{
+				Tracker:     TrackerLinear,
+				Key:         issue.Identifier,
+				Summary:     issue.Title,
+				Description: strings.TrimSpace(issue.Description),
+				Type:        issue.Team.Name,
+				Status:      issue.State.Name,
+				URL:         issue.URL,
+			})
+		}
+	}
+	return linked, errors.Join(errs...)
+}
+
+// ForPrompt returns the tickets of the trackers whose tickets are included in prompts
+func (l *Linker) ForPrompt(linked []*Ticket) []*Ticket {
+	var included []*Ticket
+	for _, ticket := range linked {
+		if (ticket.Tracker == TrackerJira && l.cfg.Jira.Prompt) || (ticket.Tracker == TrackerLinear && l.cfg.Linear.Prompt) {
+			included = append(included, ticket)
+		}
+	}
+	return included
+}
+
+// FindKeys returns the distinct ticket keys in texts, such as a pull request's title and branch, in order
+// of appearance. Keys are limited to the given project or team keys unless prefixes is empty.
+func FindKeys(prefixes []string, texts ...string) []string {
+	var keys []string
+	for _, text := range texts {
+		// Branches such as feature/proj-123-login often carry the key in lower case
+		for _, match := range keyPattern.FindAllStringSubmatch(strings.ToUpper(text), -1) {
+			if len(prefixes) > 0 && !slices.Contains(prefixes, match[1]) {
+				continue
+			}
+			if !slices.Contains(keys, match[0]) {
+				keys = append(keys, match[0])
+			}
+		}
+	}
+	return keys
+}
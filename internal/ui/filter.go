@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// LowercaseFilter filters a list whose filter values are already lowercased, such as repositories made
+// with NewRepoItem, so a keystroke costs one pass over each value. Values containing the term come first,
+// ordered by where it starts; values containing its characters in order follow, in list order.
+func LowercaseFilter(term string, targets []string) []list.Rank {
+	term = strings.ToLower(term)
+	if term == "" {
+		ranks := make([]list.Rank, len(targets))
+		for i := range ranks {
+			ranks[i].Index = i
+		}
+		return ranks
+	}
+
+	var contains, scattered []list.Rank
+	for index, target := range targets {
+		if at := strings.Index(target, term); at >= 0 {
+			contains = append(contains, list.Rank{Index: index, MatchedIndexes: runeRange(target, at, len(term))})
+		} else if matched := subsequence(target, term); matched != nil {
+			scattered = append(scattered, list.Rank{Index: index, MatchedIndexes: matched})
+		}
+	}
+
+	sort.SliceStable(contains, func(i, j int) bool {
+		return contains[i].MatchedIndexes[0] < contains[j].MatchedIndexes[0]
+	})
+	return append(contains, scattered...)
+}
+
+// runeRange returns the rune indexes of the n bytes of s starting at byte offset at
+func runeRange(s string, at, n int) []int {
+	start := utf8.RuneCountInString(s[:at])
+	count := utf8.RuneCountInString(s[at : at+n])
+	indexes := make([]int, count)
+	for i := range indexes {
+		indexes[i] = start + i
+	}
+	return indexes
+}
+
+// subsequence returns the rune indexes of s matching the runes of term in order, or nil if s does not
+// contain them all
+func subsequence(s, term string) []int {
+	if term == "" {
+		return nil
+	}
+
+	var indexes []int
+	want, size := utf8.DecodeRuneInString(term)
+	runeIndex := 0
+	for _, r := range s {
+		if r == want {
+			indexes = append(indexes, runeIndex)
+			term = term[size:]
+			if term == "" {
+				return indexes
+			}
+			want, size = utf8.DecodeRuneInString(term)
+		}
+		runeIndex++
+	}
+	return nil
+}
@@ -3,19 +3,53 @@ package ui
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/list"
 	"github.com/google/go-github/v57/github"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+	"github.com/stefrushxyz/nitpick/internal/progress"
+	"github.com/stefrushxyz/nitpick/internal/workboard"
 )
 
-// RepoItem represents a repository in the list
+// RepoItem represents a repository in the list. Items made with NewRepoItem precompute their filter key
+// and format their description once, when first shown, which keeps lists of thousands of repositories
+// responsive.
 type RepoItem struct {
-	Repo *github.Repository
+	Repo  *github.Repository
+	Alias string // Alias configured for the repository, if any
+
+	filterKey   string  // Lowercased filter value, when precomputed
+	description *string // Description, once formatted
+}
+
+// NewRepoItem creates the list item of a repository with its alias, if any
+func NewRepoItem(repo *github.Repository, alias string) RepoItem {
+	item := RepoItem{Repo: repo, Alias: alias, description: new(string)}
+	item.filterKey = strings.ToLower(item.filterValue())
+	return item
 }
 
-// FilterValue returns the name of a repository
+// FilterValue returns the owner/name of a repository, followed by its alias so filtering matches either.
+// Items made with NewRepoItem return it lowercased, for LowercaseFilter.
 func (i RepoItem) FilterValue() string {
-	return i.Repo.GetName()
+	if i.filterKey != "" {
+		return i.filterKey
+	}
+	return i.filterValue()
+}
+
+// filterValue returns the owner/name of a repository, followed by its alias
+func (i RepoItem) filterValue() string {
+	name := i.Repo.GetName()
+	if owner := i.Repo.GetOwner().GetLogin(); owner != "" {
+		name = owner + "/" + name
+	}
+	if i.Alias != "" {
+		return name + " " + i.Alias
+	}
+	return name
 }
 
 // Title returns the title of a repository
@@ -37,12 +71,26 @@ func (i RepoItem) Title() string {
 	if len(indicators) > 0 {
 		name = fmt.Sprintf("%s %s", name, strings.Join(indicators, " "))
 	}
+	if i.Alias != "" {
+		name = fmt.Sprintf("%s (%s)", name, i.Alias)
+	}
 
 	return name
 }
 
 // Description returns the description of a repository
 func (i RepoItem) Description() string {
+	if i.description == nil {
+		return i.formatDescription()
+	}
+	if *i.description == "" {
+		*i.description = i.formatDescription()
+	}
+	return *i.description
+}
+
+// formatDescription formats the description of a repository with its language and last update
+func (i RepoItem) formatDescription() string {
 	desc := i.Repo.GetDescription()
 	if desc == "" {
 		desc = "No description"
@@ -69,11 +117,17 @@ func (i RepoItem) Description() string {
 // PRItem represents a pull request in the list
 type PRItem struct {
 	PR *github.PullRequest
+	CI *ghclient.CIStatus // CI status of the head commit, nil until fetched or when the provider reports none
 }
 
-// FilterValue returns the title of a pull request
+// FilterValue returns the title of a pull request, followed by its labels as label:name so the list can be
+// filtered by label
 func (i PRItem) FilterValue() string {
-	return i.PR.GetTitle()
+	value := i.PR.GetTitle()
+	for _, label := range PRLabels(i.PR) {
+		value += " label:" + label
+	}
+	return value
 }
 
 // Title returns the title of a pull request
@@ -94,8 +148,11 @@ func (i PRItem) Description() string {
 	if i.PR.GetDraft() {
 		status = append(status, "DRAFT")
 	}
-	if i.PR.GetMerged() {
+	// Listed pull requests only carry when they were merged
+	if i.PR.GetMerged() || i.PR.MergedAt != nil {
 		status = append(status, "MERGED")
+	} else if i.PR.GetState() == "closed" {
+		status = append(status, "CLOSED")
 	}
 
 	statusStr := ""
@@ -103,12 +160,190 @@ func (i PRItem) Description() string {
 		statusStr = fmt.Sprintf("[%s] ", strings.Join(status, ", "))
 	}
 
-	return fmt.Sprintf("%sby %s • Created %s", statusStr, author, created)
+	description := fmt.Sprintf("%sby %s • Created %s", statusStr, author, created)
+	for _, part := range PRPeople(i.PR) {
+		description += " • " + part
+	}
+	if ci := CILabel(i.CI); ci != "" {
+		description = ci + " • " + description
+	}
+	return description
+}
+
+// PRPeople describes the labels, assignees and requested reviewers of a pull request, leaving out those it
+// has none of
+func PRPeople(pr *github.PullRequest) []string {
+	var parts []string
+	if labels := PRLabels(pr); len(labels) > 0 {
+		parts = append(parts, "🔖 "+strings.Join(labels, ", "))
+	}
+	if assignees := Logins(pr.Assignees); len(assignees) > 0 {
+		parts = append(parts, "assigned to "+strings.Join(assignees, ", "))
+	}
+	if reviewers := PRRequestedReviewers(pr); len(reviewers) > 0 {
+		parts = append(parts, "awaiting "+strings.Join(reviewers, ", "))
+	}
+	return parts
+}
+
+// PRLabels returns the names of the labels of a pull request
+func PRLabels(pr *github.PullRequest) []string {
+	names := make([]string, 0, len(pr.Labels))
+	for _, label := range pr.Labels {
+		names = append(names, label.GetName())
+	}
+	return names
+}
+
+// PRRequestedReviewers returns the logins of the users and the slugs of the teams whose review of a pull
+// request is requested
+func PRRequestedReviewers(pr *github.PullRequest) []string {
+	reviewers := Logins(pr.RequestedReviewers)
+	for _, team := range pr.RequestedTeams {
+		reviewers = append(reviewers, team.GetSlug())
+	}
+	return reviewers
+}
+
+// Logins returns the logins of users
+func Logins(users []*github.User) []string {
+	logins := make([]string, 0, len(users))
+	for _, user := range users {
+		logins = append(logins, user.GetLogin())
+	}
+	return logins
+}
+
+// CILabel returns a short pass, fail or pending indicator of a CI status, or an empty string when there is
+// none to show
+func CILabel(status *ghclient.CIStatus) string {
+	if status == nil {
+		return ""
+	}
+	switch status.State {
+	case ghclient.CISuccess:
+		return "✅ CI passing"
+	case ghclient.CIFailure:
+		return "❌ CI failing"
+	case ghclient.CIPending:
+		return "⏳ CI pending"
+	}
+	return ""
+}
+
+// CardItem represents a pull request on the workboard
+type CardItem struct {
+	Card workboard.Card
+}
+
+// FilterValue returns the repository, lane and title of a card
+func (i CardItem) FilterValue() string {
+	return fmt.Sprintf("%s %s %s", i.Card.Key(), i.Card.Lane.Name, i.Card.PR.GetTitle())
+}
+
+// Title returns the lane, repository, number and title of a card's pull request
+func (i CardItem) Title() string {
+	return fmt.Sprintf("%s %s %s", i.Card.Lane.Icon, i.Card.Key(), i.Card.PR.GetTitle())
+}
+
+// Description returns the lane, author and last update of a card's pull request
+func (i CardItem) Description() string {
+	status := ""
+	if i.Card.PR.GetDraft() {
+		status = "[DRAFT] "
+	}
+	return fmt.Sprintf("%s • %sby %s • Updated %s", i.Card.Lane.Name, status,
+		i.Card.PR.GetUser().GetLogin(), i.Card.PR.GetUpdatedAt().Format("2006-01-02"))
+}
+
+// FileItem represents a file changed by a pull request
+type FileItem struct {
+	File *github.CommitFile
+}
+
+// FilterValue returns the path of a changed file
+func (i FileItem) FilterValue() string {
+	return i.File.GetFilename()
+}
+
+// Title returns the path of a changed file, with the path it was renamed from
+func (i FileItem) Title() string {
+	if previous := i.File.GetPreviousFilename(); previous != "" {
+		return fmt.Sprintf("%s → %s", previous, i.File.GetFilename())
+	}
+	return i.File.GetFilename()
+}
+
+// Description returns the lines added and deleted in a changed file, and how it changed
+func (i FileItem) Description() string {
+	return fmt.Sprintf("+%d -%d • %s", i.File.GetAdditions(), i.File.GetDeletions(), i.File.GetStatus())
+}
+
+// CommitItem represents a commit of a pull request
+type CommitItem struct {
+	Commit *github.RepositoryCommit
+}
+
+// FilterValue returns the SHA and message of a commit
+func (i CommitItem) FilterValue() string {
+	return i.Commit.GetSHA() + " " + i.Commit.GetCommit().GetMessage()
+}
+
+// Title returns the abbreviated SHA and the first line of the message of a commit
+func (i CommitItem) Title() string {
+	return fmt.Sprintf("%s %s", ShortSHA(i.Commit.GetSHA()), CommitSummary(i.Commit))
+}
+
+// Description returns the author and date of a commit
+func (i CommitItem) Description() string {
+	return fmt.Sprintf("by %s • %s", CommitAuthor(i.Commit), i.Commit.GetCommit().GetAuthor().GetDate().Format("2006-01-02 15:04"))
+}
+
+// ShortSHA returns the abbreviated form of a commit SHA
+func ShortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// CommitSummary returns the first line of the message of a commit
+func CommitSummary(commit *github.RepositoryCommit) string {
+	summary, _, _ := strings.Cut(commit.GetCommit().GetMessage(), "\n")
+	return strings.TrimSpace(summary)
+}
+
+// CommitAuthor returns the account of the author of a commit when it is linked to one, and otherwise the name
+// in the commit
+func CommitAuthor(commit *github.RepositoryCommit) string {
+	if login := commit.GetAuthor().GetLogin(); login != "" {
+		return login
+	}
+	return commit.GetCommit().GetAuthor().GetName()
 }
 
 // CommentItem represents a PR comment in the list
 type CommentItem struct {
 	Comment *github.PullRequestComment
+	Status  string // Local review progress: addressed, ignored or empty
+	Replies int    // Number of replies, when the comment stands for its thread
+	Fresh   bool   // Whether the comment, or a reply in its thread, was posted or edited since it was last seen
+}
+
+// CommentThreadItems groups comments into threads by the comment they reply to, and lists each thread as
+// its first comment with its number of replies, in the order of the first comments. status returns the local
+// review progress of a comment, and fresh whether it was posted or edited since it was last seen.
+func CommentThreadItems(comments []*github.PullRequestComment, status func(id int64) string, fresh func(id int64) bool) []list.Item {
+	threads := ghclient.GroupCommentThreads(comments)
+	items := make([]list.Item, len(threads))
+	for i, thread := range threads {
+		item := CommentItem{Comment: thread.Root, Status: status(thread.Root.GetID()), Replies: len(thread.Replies)}
+		item.Fresh = fresh(thread.Root.GetID()) || slices.ContainsFunc(thread.Replies, func(reply *github.PullRequestComment) bool {
+			return fresh(reply.GetID())
+		})
+		items[i] = item
+	}
+	return items
 }
 
 // FilterValue returns the body of a comment
@@ -133,11 +368,64 @@ func (i CommentItem) Title() string {
 			if len(line) > 80 {
 				line = line[:77] + "..."
 			}
-			return line
+			return i.prefix() + line
 		}
 	}
 
-	return "Empty comment"
+	return i.prefix() + "Empty comment"
+}
+
+// prefix returns the indicators shown before the title of a comment
+func (i CommentItem) prefix() string {
+	if i.Fresh {
+		return "🆕 " + statusPrefix(i.Status)
+	}
+	return statusPrefix(i.Status)
+}
+
+// statusPrefix returns the indicator shown before the title of a comment with the given review progress
+func statusPrefix(status string) string {
+	switch status {
+	case progress.Addressed:
+		return "✓ "
+	case progress.Ignored:
+		return "⊘ "
+	}
+	return ""
+}
+
+// ReviewLabel returns the tag of a review body with the given review state
+func ReviewLabel(state string) string {
+	switch state {
+	case ghclient.ReviewApproved:
+		return "✅ approved"
+	case ghclient.ReviewChangesRequested:
+		return "🔴 changes requested"
+	case ghclient.ReviewDismissed:
+		return "⊘ dismissed review"
+	}
+	return "📝 review"
+}
+
+// ReactionEmoji maps the contents of reactions to the emoji GitHub shows for them
+var ReactionEmoji = map[string]string{
+	"+1": "👍", "-1": "👎", "laugh": "😄", "hooray": "🎉", "confused": "😕", "heart": "❤️", "rocket": "🚀", "eyes": "👀",
+}
+
+// ReactionsLabel returns the counts of the reactions to a comment, such as "👍 2 🎉 1", or "" when it has none
+func ReactionsLabel(r *github.Reactions) string {
+	counts := map[string]int{
+		"+1": r.GetPlusOne(), "-1": r.GetMinusOne(), "laugh": r.GetLaugh(), "hooray": r.GetHooray(),
+		"confused": r.GetConfused(), "heart": r.GetHeart(), "rocket": r.GetRocket(), "eyes": r.GetEyes(),
+	}
+
+	var label []string
+	for _, content := range ghclient.Reactions {
+		if n := counts[content]; n > 0 {
+			label = append(label, fmt.Sprintf("%s %d", ReactionEmoji[content], n))
+		}
+	}
+	return strings.Join(label, " ")
 }
 
 // Description returns the description of a comment
@@ -159,7 +447,11 @@ func (i CommentItem) Description() string {
 
 	// Build file and line information using the same logic as detail view
 	fileInfo := ""
-	if i.Comment.GetPath() != "" {
+	if state := ghclient.ReviewState(i.Comment); state != "" {
+		fileInfo = " • " + ReviewLabel(state)
+	} else if ghclient.IsConversation(i.Comment) {
+		fileInfo = " • 💬 conversation"
+	} else if i.Comment.GetPath() != "" {
 		fileInfo = fmt.Sprintf(" • %s", i.Comment.GetPath())
 
 		// Add line information - handle multi-line comments properly
@@ -191,5 +483,18 @@ func (i CommentItem) Description() string {
 		}
 	}
 
-	return fmt.Sprintf("by %s • %s%s", author, timeInfo, fileInfo)
+	replies := ""
+	switch {
+	case i.Replies == 1:
+		replies = " • 1 reply"
+	case i.Replies > 1:
+		replies = fmt.Sprintf(" • %d replies", i.Replies)
+	}
+
+	reactions := ""
+	if label := ReactionsLabel(i.Comment.GetReactions()); label != "" {
+		reactions = " • " + label
+	}
+
+	return fmt.Sprintf("by %s • %s%s%s%s", author, timeInfo, fileInfo, replies, reactions)
 }
@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/charmbracelet/glamour/ansi"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stefrushxyz/nitpick/internal/config"
+)
+
+// darkBackground caches the terminal background query, which must not run while the TUI reads input
+var darkBackground = sync.OnceValue(lipgloss.HasDarkBackground)
+
+// MarkdownStyle returns the glamour style selected by theme: auto (dark or light, following the
+// terminal's background), high-contrast, the name of a built-in style, or the path of a glamour style JSON file
+func MarkdownStyle(theme string) (ansi.StyleConfig, error) {
+	if theme == config.ThemeHighContrast {
+		// Terminal's own colors, with emphasis by weight
+		return styles.NoTTYStyleConfig, nil
+	}
+	if theme == styles.AutoStyle {
+		if darkBackground() {
+			return styles.DarkStyleConfig, nil
+		}
+		return styles.LightStyleConfig, nil
+	}
+	if style, ok := styles.DefaultStyles[theme]; ok {
+		return *style, nil
+	}
+
+	content, err := os.ReadFile(theme)
+	if err != nil {
+		return ansi.StyleConfig{}, fmt.Errorf("theme %q is neither a built-in style nor a readable style file: %w", theme, err)
+	}
+	var style ansi.StyleConfig
+	if err := json.Unmarshal(content, &style); err != nil {
+		return ansi.StyleConfig{}, fmt.Errorf("failed to parse style file %s: %w", theme, err)
+	}
+	return style, nil
+}
@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stefrushxyz/nitpick/internal/config"
+)
+
+// Palette holds the colors of the TUI's own elements; markdown is colored by its glamour style
+type Palette struct {
+	Text     lipgloss.TerminalColor // Text of code and description panels
+	Muted    lipgloss.TerminalColor // Key help and secondary text
+	Subtle   lipgloss.TerminalColor // Metadata of comments, blame and tickets
+	Accent   lipgloss.TerminalColor // Headings
+	Link     lipgloss.TerminalColor // Links and commits
+	Status   lipgloss.TerminalColor // Status messages
+	Error    lipgloss.TerminalColor // Errors
+	BannerFg lipgloss.TerminalColor // Text of the title banner of a comment
+	BannerBg lipgloss.TerminalColor // Background of the title banner of a comment
+	Surface  lipgloss.TerminalColor // Background of code and link panels
+	Border   lipgloss.TerminalColor // Borders of panels
+	Added    lipgloss.TerminalColor // Lines added by a diff
+	Removed  lipgloss.TerminalColor // Lines removed by a diff
+
+	plain bool // Whether lists drop their colors too
+}
+
+// DefaultPalette is the palette of every theme but the high-contrast one
+var DefaultPalette = Palette{
+	Text:     lipgloss.Color("252"),
+	Muted:    lipgloss.Color("8"),
+	Subtle:   lipgloss.Color("248"),
+	Accent:   lipgloss.Color("12"),
+	Link:     lipgloss.Color("110"),
+	Status:   lipgloss.Color("2"),
+	Error:    lipgloss.Color("9"),
+	BannerFg: lipgloss.Color("230"),
+	BannerBg: lipgloss.Color("62"),
+	Surface:  lipgloss.Color("234"),
+	Border:   lipgloss.Color("240"),
+	Added:    lipgloss.Color("2"),
+	Removed:  lipgloss.Color("1"),
+}
+
+// HighContrastPalette draws everything in the terminal's own foreground and background, the pair it renders
+// with the most contrast, so no meaning hangs on a hue a colorblind user may not tell apart: headings, status
+// and selection stand out by weight and borders instead
+var HighContrastPalette = Palette{
+	Text:     lipgloss.NoColor{},
+	Muted:    lipgloss.NoColor{},
+	Subtle:   lipgloss.NoColor{},
+	Accent:   lipgloss.NoColor{},
+	Link:     lipgloss.NoColor{},
+	Status:   lipgloss.NoColor{},
+	Error:    lipgloss.NoColor{},
+	BannerFg: lipgloss.NoColor{},
+	BannerBg: lipgloss.NoColor{},
+	Surface:  lipgloss.NoColor{},
+	Border:   lipgloss.NoColor{},
+	Added:    lipgloss.NoColor{},
+	Removed:  lipgloss.NoColor{},
+	plain:    true,
+}
+
+// PaletteFor returns the palette of a theme
+func PaletteFor(theme string) Palette {
+	if theme == config.ThemeHighContrast {
+		return HighContrastPalette
+	}
+	return DefaultPalette
+}
+
+// StyleList applies the palette to a list: its title and the delegate rendering its items
+func (p Palette) StyleList(l *list.Model) {
+	delegate := list.NewDefaultDelegate()
+	styles := list.DefaultStyles()
+	if p.plain {
+		plain := lipgloss.NewStyle().Padding(0, 0, 0, 2)
+		selected := lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder(), false, false, false, true).
+			Padding(0, 0, 0, 1).
+			Bold(true)
+
+		delegate.Styles.NormalTitle = plain
+		delegate.Styles.NormalDesc = plain
+		delegate.Styles.SelectedTitle = selected
+		delegate.Styles.SelectedDesc = selected
+		delegate.Styles.DimmedTitle = plain
+		delegate.Styles.DimmedDesc = plain
+		delegate.Styles.FilterMatch = lipgloss.NewStyle().Underline(true)
+
+		styles.Title = lipgloss.NewStyle().Bold(true).Underline(true)
+		styles.NoItems = lipgloss.NewStyle()
+		styles.StatusBar = lipgloss.NewStyle()
+	}
+
+	l.SetDelegate(delegate)
+	l.Styles = styles
+	l.Help.Styles = help.New().Styles
+	if p.plain {
+		plain := lipgloss.NewStyle()
+		l.Help.Styles = help.Styles{
+			Ellipsis: plain, ShortKey: plain, ShortDesc: plain, ShortSeparator: plain,
+			FullKey: plain, FullDesc: plain, FullSeparator: plain,
+		}
+	}
+}
+
+// asciiGlyphs replaces the symbols and emoji the TUI draws with ASCII of the same width, so layouts computed
+// for the glyphs stay intact
+var asciiGlyphs = strings.NewReplacer(
+	"⚠️", "!", "⚠", "!",
+	"✅", "OK", "❌", "!!", "⏳", "..",
+	"🟢", "+ ", "🔴", "!!", "🟡", "! ", "🔵", "- ",
+	"📍", "@ ", "📁", "F ", "🧩", "{}", "👥", "@@", "🎫", "# ", "🔗", "& ", "🆕", "N ",
+	"🔖", "* ", "📝", "* ", "🔄", "<>", "🔀", "<>", "🌳", "Y ",
+	"🔎", "? ", "💡", "i ", "🔒", "P ", "🍴", "Y ",
+	"👍", "+1", "👎", "-1", "😄", ":D", "🎉", "\\o", "😕", ":/", "❤️", "<3", "🚀", "^^", "👀", "oo",
+	"✓", "+", "⊘", "-", "•", "*", "…", "~", "↑", "^", "↓", "v", "←", "<", "→", ">", "↳", ">", "⌫", "<",
+	"│", "|", "─", "-", "╭", "+", "╮", "+", "╰", "+", "╯", "+", "┌", "+", "┐", "+", "└", "+", "┘", "+",
+)
+
+// ASCII returns rendered output with only ASCII characters, for terminals with limited glyph support: the
+// TUI's symbols become ASCII lookalikes, and any other character, e.g. an emoji in a comment, question marks
+// as wide as it was
+func ASCII(s string) string {
+	s = asciiGlyphs.Replace(s)
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if r < utf8.RuneSelf {
+			b.WriteRune(r)
+			continue
+		}
+		b.WriteString(strings.Repeat("?", lipgloss.Width(string(r))))
+	}
+	return b.String()
+}
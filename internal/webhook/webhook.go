@@ -0,0 +1,133 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Payload formats of webhook messages
+const (
+	FormatAuto    = "auto"    // Detected from the webhook URL, falling back to JSON
+	FormatSlack   = "slack"   // Slack incoming webhook
+	FormatDiscord = "discord" // Discord webhook
+	FormatJSON    = "json"    // The Event as JSON, for other receivers
+)
+
+// Formats lists the accepted payload formats
+var Formats = []string{FormatAuto, FormatSlack, FormatDiscord, FormatJSON}
+
+// discordLimit is the maximum length of a Discord message
+const discordLimit = 2000
+
+// Event describes a newly arrived review comment
+type Event struct {
+	Repo     string `json:"repo"`
+	PR       int    `json:"pr"`
+	ID       int64  `json:"id"`
+	Reviewer string `json:"reviewer"`
+	Location string `json:"location,omitempty"` // path:line, or empty for a comment on the whole pull request
+	Summary  string `json:"summary"`
+	Body     string `json:"body"`
+	URL      string `json:"url"`
+}
+
+// Notifier posts events to a webhook
+type Notifier struct {
+	url    string
+	format string
+	client *http.Client
+}
+
+// New creates a notifier posting to url in the given format, or the format detected from url for auto
+func New(webhookURL, format string, timeout time.Duration) (*Notifier, error) {
+	u, err := url.Parse(webhookURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf("invalid webhook URL %q", webhookURL)
+	}
+
+	switch format {
+	case "", FormatAuto:
+		format = detectFormat(u)
+	case FormatSlack, FormatDiscord, FormatJSON:
+	default:
+		return nil, fmt.Errorf("unknown webhook format %q (expected one of %s)", format, strings.Join(Formats, ", "))
+	}
+
+	return &Notifier{url: webhookURL, format: format, client: &http.Client{Timeout: timeout}}, nil
+}
+
+// detectFormat picks the payload format of well-known webhook hosts
+func detectFormat(u *url.URL) string {
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case host == "hooks.slack.com":
+		return FormatSlack
+	case (host == "discord.com" || host == "discordapp.com") && strings.HasPrefix(u.Path, "/api/webhooks/"):
+		return FormatDiscord
+	default:
+		return FormatJSON
+	}
+}
+
+// Notify posts an event
+func (n *Notifier) Notify(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(n.payload(event))
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// payload builds the request body of an event in the notifier's format
+func (n *Notifier) payload(event Event) any {
+	target := fmt.Sprintf("%s#%d", event.Repo, event.PR)
+	location := ""
+	if event.Location != "" {
+		location = fmt.Sprintf(" on `%s`", event.Location)
+	}
+
+	switch n.format {
+	case FormatSlack:
+		text := fmt.Sprintf("*%s* commented on <%s|%s>%s\n>%s",
+			slackEscape(event.Reviewer), event.URL, slackEscape(target), location, slackEscape(event.Summary))
+		return map[string]string{"text": text}
+	case FormatDiscord:
+		content := fmt.Sprintf("**%s** commented on [%s](<%s>)%s\n> %s",
+			event.Reviewer, target, event.URL, location, event.Summary)
+		if runes := []rune(content); len(runes) > discordLimit {
+			content = string(runes[:discordLimit-3]) + "..."
+		}
+		return map[string]any{"username": "nitpick", "content": content}
+	default:
+		return event
+	}
+}
+
+// slackEscape escapes the characters Slack treats as markup in message text
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
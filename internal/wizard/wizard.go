@@ -0,0 +1,323 @@
+package wizard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stefrushxyz/nitpick/internal/clipboard"
+	"github.com/stefrushxyz/nitpick/internal/config"
+	ghclient "github.com/stefrushxyz/nitpick/internal/github"
+)
+
+// ErrCancelled is returned by Run when the user quits the wizard before finishing
+var ErrCancelled = errors.New("setup cancelled")
+
+// step is a page of the wizard
+type step int
+
+const (
+	stepAuth step = iota
+	stepToken
+	stepDevice
+	stepClipboard
+	stepTheme
+	stepDone
+)
+
+// Authentication methods offered on the first page
+const (
+	authPAT    = "Paste a personal access token"
+	authDevice = "Log in with the browser (device flow)"
+	authGH     = "Reuse the GitHub CLI (gh) login"
+)
+
+// Result holds the choices made in the wizard
+type Result struct {
+	Token     string
+	Clipboard string
+	Theme     string
+}
+
+// Messages for async operations
+type deviceCodeMsg struct {
+	code *ghclient.DeviceCode
+	err  error
+}
+
+type tokenMsg struct {
+	token string
+	err   error
+}
+
+// Model is the first-run setup wizard
+type Model struct {
+	host      string
+	clientID  string
+	step      step
+	cursor    int
+	input     textinput.Model
+	device    *ghclient.DeviceCode
+	result    Result
+	err       error
+	cancelled bool
+}
+
+// New creates a wizard for logging in to host. Device flow login is offered only with an OAuth client ID.
+func New(host, clientID string) *Model {
+	input := textinput.New()
+	input.Placeholder = "github_pat_..."
+	input.EchoMode = textinput.EchoPassword
+	input.EchoCharacter = '•'
+	input.Width = 60
+
+	return &Model{host: host, clientID: clientID, input: input}
+}
+
+// Run runs the wizard and returns the choices made
+func Run(host, clientID string) (Result, error) {
+	model, err := tea.NewProgram(New(host, clientID)).Run()
+	if err != nil {
+		return Result{}, err
+	}
+
+	m := model.(*Model)
+	if m.cancelled || m.step != stepDone {
+		return Result{}, ErrCancelled
+	}
+	return m.result, nil
+}
+
+// authMethods returns the authentication methods available for the configured host
+func (m *Model) authMethods() []string {
+	methods := []string{authPAT}
+	if m.clientID != "" {
+		methods = append(methods, authDevice)
+	}
+	if _, err := exec.LookPath("gh"); err == nil {
+		methods = append(methods, authGH)
+	}
+	return methods
+}
+
+// choices returns the options of the current step, if it is a selection step
+func (m *Model) choices() []string {
+	switch m.step {
+	case stepAuth:
+		return m.authMethods()
+	case stepClipboard:
+		return clipboard.Backends
+	case stepTheme:
+		return config.Themes
+	}
+	return nil
+}
+
+// Init initializes the wizard
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages and advances the wizard
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			m.cancelled = true
+			return m, tea.Quit
+		case "esc":
+			if m.step == stepAuth {
+				m.cancelled = true
+				return m, tea.Quit
+			}
+			m.err = nil
+			m.step = stepAuth
+			m.cursor = 0
+			return m, nil
+		}
+
+		if m.step == stepToken {
+			if msg.Type == tea.KeyEnter {
+				token := strings.TrimSpace(m.input.Value())
+				if token == "" {
+					m.err = errors.New("the token is empty")
+					return m, nil
+				}
+				return m.authenticated(token)
+			}
+			var cmd tea.Cmd
+			m.input, cmd = m.input.Update(msg)
+			return m, cmd
+		}
+
+		choices := m.choices()
+		switch msg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(choices)-1 {
+				m.cursor++
+			}
+		case "enter":
+			if len(choices) > 0 {
+				return m.choose(choices[m.cursor])
+			}
+		}
+
+	case deviceCodeMsg:
+		if m.step != stepDevice {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.err = msg.err
+			m.step = stepAuth
+			return m, nil
+		}
+		m.device = msg.code
+		return m, m.pollDeviceToken()
+
+	case tokenMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			m.step = stepAuth
+			return m, nil
+		}
+		return m.authenticated(msg.token)
+	}
+
+	return m, nil
+}
+
+// choose applies the selected option of a selection step
+func (m *Model) choose(choice string) (tea.Model, tea.Cmd) {
+	m.err = nil
+	switch m.step {
+	case stepAuth:
+		switch choice {
+		case authPAT:
+			m.step = stepToken
+			return m, m.input.Focus()
+		case authDevice:
+			m.step = stepDevice
+			m.device = nil
+			return m, m.requestDeviceCode()
+		case authGH:
+			return m, m.readGHToken()
+		}
+	case stepClipboard:
+		m.result.Clipboard = choice
+		m.step = stepTheme
+		m.cursor = 0
+	case stepTheme:
+		m.result.Theme = choice
+		m.step = stepDone
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// authenticated records the token and moves on to the clipboard step
+func (m *Model) authenticated(token string) (tea.Model, tea.Cmd) {
+	m.result.Token = token
+	m.err = nil
+	m.step = stepClipboard
+	m.cursor = 0
+	return m, nil
+}
+
+// requestDeviceCode starts a device flow login
+func (m *Model) requestDeviceCode() tea.Cmd {
+	return func() tea.Msg {
+		code, err := ghclient.RequestDeviceCode(context.Background(), m.host, m.clientID)
+		return deviceCodeMsg{code: code, err: err}
+	}
+}
+
+// pollDeviceToken waits for the user to authorize the device code
+func (m *Model) pollDeviceToken() tea.Cmd {
+	code := m.device
+	return func() tea.Msg {
+		token, err := ghclient.PollDeviceToken(context.Background(), m.host, m.clientID, code)
+		return tokenMsg{token: token, err: err}
+	}
+}
+
+// readGHToken reads the token of the GitHub CLI's login for the host
+func (m *Model) readGHToken() tea.Cmd {
+	return func() tea.Msg {
+		out, err := exec.Command("gh", "auth", "token", "--hostname", m.host).Output()
+		if err != nil {
+			return tokenMsg{err: fmt.Errorf("gh is not logged in to %s; run gh auth login first", m.host)}
+		}
+		return tokenMsg{token: strings.TrimSpace(string(out))}
+	}
+}
+
+// View renders the current step of the wizard
+func (m *Model) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+
+	var title, body, help string
+	switch m.step {
+	case stepAuth:
+		title = fmt.Sprintf("Welcome to nitpick! How do you want to log in to %s?", m.host)
+		body = m.renderChoices()
+		help = "↑/↓: choose • Enter: select • Esc: quit"
+	case stepToken:
+		title = "Paste a personal access token"
+		body = "Create one at https://" + m.host + "/settings/personal-access-tokens\n\n" + m.input.View()
+		help = "Enter: continue • Esc: back"
+	case stepDevice:
+		title = "Log in with the browser"
+		if m.device == nil {
+			body = "Requesting a device code..."
+		} else {
+			body = fmt.Sprintf("Open %s and enter the code:\n\n    %s\n\nWaiting for authorization...",
+				m.device.VerificationURI, lipgloss.NewStyle().Bold(true).Render(m.device.UserCode))
+		}
+		help = "Esc: back"
+	case stepClipboard:
+		title = "Which clipboard should prompts be copied to?"
+		body = m.renderChoices()
+		help = "↑/↓: choose • Enter: select • Esc: back to login"
+	case stepTheme:
+		title = "Which theme should markdown be rendered with?"
+		body = m.renderChoices()
+		help = "↑/↓: choose • Enter: select • Esc: back to login"
+	case stepDone:
+		return ""
+	}
+
+	elements := []string{titleStyle.Render(title), "", body}
+	if m.err != nil {
+		elements = append(elements, "", errStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+	}
+	elements = append(elements, "", helpStyle.Render(help))
+
+	return lipgloss.NewStyle().Padding(1, 2).Render(lipgloss.JoinVertical(lipgloss.Left, elements...))
+}
+
+// renderChoices renders the options of a selection step with the cursor
+func (m *Model) renderChoices() string {
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("10")).Bold(true)
+
+	var lines []string
+	for i, choice := range m.choices() {
+		if i == m.cursor {
+			lines = append(lines, selectedStyle.Render("> "+choice))
+		} else {
+			lines = append(lines, "  "+choice)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
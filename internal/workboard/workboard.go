@@ -0,0 +1,82 @@
+package workboard
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// Lane is a column of the workboard: the pull requests needing the user's attention for one reason
+type Lane struct {
+	Name  string // Reason the pull requests need attention
+	Icon  string
+	Query string // Pull request search query finding them
+}
+
+// The lanes of the workboard
+var (
+	ChangesRequested = Lane{Name: "Changes requested", Icon: "🔴", Query: "is:pr is:open archived:false author:@me review:changes_requested"}
+	ReviewRequested  = Lane{Name: "Review requested", Icon: "🟡", Query: "is:pr is:open archived:false review-requested:@me"}
+	Assigned         = Lane{Name: "Assigned", Icon: "🔵", Query: "is:pr is:open archived:false assignee:@me"}
+)
+
+// Authored finds the user's own open pull requests, for jumping to the feedback on them; it is not a column
+// of the workboard, which only shows those with changes requested
+var Authored = Lane{Name: "My pull requests", Icon: "🟢", Query: "is:pr is:open archived:false author:@me"}
+
+// Lanes are the columns of the workboard, most urgent first: feedback blocking the user's own pull requests,
+// then reviews others wait for, then pull requests merely assigned
+var Lanes = []Lane{ChangesRequested, ReviewRequested, Assigned}
+
+// Card is a pull request on the workboard
+type Card struct {
+	Lane Lane
+	PR   *github.PullRequest
+}
+
+// Key identifies the pull request of a card across repositories, as owner/name#N
+func (c Card) Key() string {
+	return fmt.Sprintf("%s#%d", c.PR.GetBase().GetRepo().GetFullName(), c.PR.GetNumber())
+}
+
+// Build lays out the search results of each lane, in the order of Lanes, as cards ordered by urgency: by lane,
+// then longest untouched first. A pull request found by several lanes only appears in the most urgent one.
+func Build(results [][]*github.PullRequest) []Card {
+	var cards []Card
+	seen := make(map[string]bool)
+	for i, prs := range results {
+		if i >= len(Lanes) {
+			break
+		}
+		var lane []Card
+		for _, pr := range prs {
+			card := Card{Lane: Lanes[i], PR: pr}
+			if seen[card.Key()] {
+				continue
+			}
+			seen[card.Key()] = true
+			lane = append(lane, card)
+		}
+		sortCards(lane)
+		cards = append(cards, lane...)
+	}
+	return cards
+}
+
+// Cards lays out the search results of a single lane as cards, longest untouched first
+func Cards(lane Lane, prs []*github.PullRequest) []Card {
+	cards := make([]Card, len(prs))
+	for i, pr := range prs {
+		cards[i] = Card{Lane: lane, PR: pr}
+	}
+	sortCards(cards)
+	return cards
+}
+
+// sortCards orders cards longest untouched first
+func sortCards(cards []Card) {
+	sort.SliceStable(cards, func(a, b int) bool {
+		return cards[a].PR.GetUpdatedAt().Before(cards[b].PR.GetUpdatedAt().Time)
+	})
+}